@@ -31,15 +31,25 @@ var generateCmd = &cobra.Command{
 		}
 
 		createDirectories(config)
-		modelOutputFile, queryResolverOutputFile, mutationResolverOutputFile, rootResolverOutputFile := createFiles(config)
-		g, err := generator.NewGenerator(config.SchemaDirectory, modelOutputFile, queryResolverOutputFile, mutationResolverOutputFile, rootResolverOutputFile, config.ModelPackageName, config.ResolverPackageName)
+		modelOutputFile, queryResolverOutputFile, mutationResolverOutputFile, subscriptionResolverOutputFile, rootResolverOutputFile := createFiles(config)
+		g, err := generator.NewGenerator(config.SchemaDirectory, modelOutputFile, queryResolverOutputFile, mutationResolverOutputFile, subscriptionResolverOutputFile, rootResolverOutputFile, config.ModelPackageName, config.ResolverPackageName, config.Scalars)
 		if err != nil {
 			log.Fatalf("error creating generator: %v", err)
 		}
+		g.TypedResolvers = config.TypedResolvers
+		g.FieldResolvers = config.FieldResolvers
 
 		if err := g.Generate(); err != nil {
 			log.Fatalf("error generating code: %v", err)
 		}
+
+		if g.Schema.GetMutation() == nil {
+			// the mutation resolver file is written only for a schema with a mutation type
+			mutationResolverOutputFile.Close()
+			if err := os.Remove(config.MutationResolverOutputFile); err != nil {
+				log.Fatalf("error removing mutation resolver output file: %v", err)
+			}
+		}
 	},
 }
 
@@ -65,9 +75,16 @@ type Config struct {
 	ModelOutputFile  string `yaml:"model_output_file"`
 	QueryResolverOutputFile string `yaml:"query_resolver_output_file"`
 	MutationResolverOutputFile string `yaml:"mutation_resolver_output_file"`
+	SubscriptionResolverOutputFile string `yaml:"subscription_resolver_output_file"`
 	RootResolverOutputFile string `yaml:"root_resolver_output_file"`
 	ModelPackageName string `yaml:"model_package_name"`
 	ResolverPackageName string `yaml:"resolver_package_name"`
+	// Scalars maps custom scalars to Go types, such as DateTime: time.Time
+	Scalars map[string]string `yaml:"scalars,omitempty"`
+	// TypedResolvers generates resolvers which take arguments and return results instead of HTTP handlers
+	TypedResolvers bool `yaml:"typed_resolvers,omitempty"`
+	// FieldResolvers lists the fields of object types resolved by field resolvers, such as Post: [author]
+	FieldResolvers map[string][]string `yaml:"field_resolvers,omitempty"`
 }
 
 var initConfig = Config{
@@ -75,6 +92,7 @@ var initConfig = Config{
 	ModelOutputFile:  "./graphql/model/models.go",
 	QueryResolverOutputFile: "./graphql/resolver/query.resolver.go",
 	MutationResolverOutputFile: "./graphql/resolver/mutate.resolver.go",
+	SubscriptionResolverOutputFile: "./graphql/resolver/subscription.resolver.go",
 	RootResolverOutputFile: "./graphql/resolver/resolver.go",
 	ModelPackageName: "example/graphql/model",
 	ResolverPackageName: "example/graphql/resolver",
@@ -149,7 +167,7 @@ func createDirectories(conf Config) {
 	}
 }
 
-func createFiles(conf Config) (*os.File, *os.File, *os.File, *os.File) {
+func createFiles(conf Config) (*os.File, *os.File, *os.File, *os.File, *os.File) {
 	modelOutputFile, err := os.Create(conf.ModelOutputFile)
 	if err != nil && !os.IsExist(err) {
 		log.Fatalf("error creating model output file: %v", err)
@@ -165,10 +183,24 @@ func createFiles(conf Config) (*os.File, *os.File, *os.File, *os.File) {
 		log.Fatalf("error creating mutation resolver output file: %v", err)
 	}
 
+	subscriptionResolverOutputFile, err := os.Create(subscriptionResolverOutputFilePath(conf))
+	if err != nil && !os.IsExist(err) {
+		log.Fatalf("error creating subscription resolver output file: %v", err)
+	}
+
 	rootResolverOutputFile, err := os.Create(conf.RootResolverOutputFile)
 	if err != nil && !os.IsExist(err) {
 		log.Fatalf("error creating root resolver output file: %v", err)
 	}
 
-	return modelOutputFile, queryResolverOutputFile, mutationResolverOutputFile, rootResolverOutputFile
-}
\ No newline at end of file
+	return modelOutputFile, queryResolverOutputFile, mutationResolverOutputFile, subscriptionResolverOutputFile, rootResolverOutputFile
+}
+// subscriptionResolverOutputFilePath falls back to the root resolver directory
+// for configs written before subscription_resolver_output_file existed.
+func subscriptionResolverOutputFilePath(conf Config) string {
+	if conf.SubscriptionResolverOutputFile != "" {
+		return conf.SubscriptionResolverOutputFile
+	}
+
+	return filepath.Join(filepath.Dir(conf.RootResolverOutputFile), "subscription.resolver.go")
+}
@@ -0,0 +1,73 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/schema"
+)
+
+// CoerceArgumentValues returns the values of the arguments of a field defined by definitions as a JSON object keyed by their names,
+// as described in "CoerceArgumentValues" of the specification.
+// Every argument is given in args as a literal or a variable in variables, which are coerced by CoerceVariableValues,
+// and an argument which isn't given has the default value of its definition.
+// The values are coerced to the types of their arguments in s as variables are, so an integer literal of an ID is a string.
+func CoerceArgumentValues(s *schema.Schema, definitions schema.ArgumentDefinitions, args []*query.Argument, variables json.RawMessage) (json.RawMessage, error) {
+	variableValues := make(map[string]json.RawMessage)
+	if len(variables) > 0 {
+		if err := json.Unmarshal(variables, &variableValues); err != nil {
+			return nil, fmt.Errorf("variables must be an object: %w", err)
+		}
+	}
+
+	values := NewOrderedMap()
+	for _, def := range definitions {
+		value, ok := argumentValue(def, args, variableValues)
+		if !ok {
+			if def.Default != nil {
+				value, _ = valueToJSON(def.Default, nil)
+			} else if !def.Type.Nullable {
+				return nil, fmt.Errorf("argument %s of non-null type %s is not provided", def.Name, def.Type)
+			} else {
+				continue
+			}
+		}
+
+		value, err := coerceInputValue(s, def.Type, value, "argument "+string(def.Name))
+		if err != nil {
+			return nil, err
+		}
+
+		values.Set(string(def.Name), value)
+	}
+
+	return json.Marshal(values)
+}
+
+// argumentValue returns the value of the argument defined by def in args, where ok is false if it isn't given.
+func argumentValue(def *schema.ArgumentDefinition, args []*query.Argument, variables map[string]json.RawMessage) (json.RawMessage, bool) {
+	for _, arg := range args {
+		if string(arg.Name) == string(def.Name) {
+			return valueToJSON(arg.Value, variables)
+		}
+	}
+
+	return nil, false
+}
+
+// DecodeArgumentValues coerces the arguments of a field as CoerceArgumentValues does, and decodes them into T,
+// the generated type of the arguments of the field such as model.PostArgs.
+func DecodeArgumentValues[T any](s *schema.Schema, definitions schema.ArgumentDefinitions, args []*query.Argument, variables json.RawMessage) (T, error) {
+	var res T
+	values, err := CoerceArgumentValues(s, definitions, args, variables)
+	if err != nil {
+		return res, err
+	}
+
+	if err := json.Unmarshal(values, &res); err != nil {
+		return res, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,183 @@
+package executor_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/schema"
+)
+
+func TestCoerceArgumentValues(t *testing.T) {
+	s := schema.MustParse([]byte(`type Query {
+	posts(id: ID!, filter: PostFilter, first: Int = 10, tags: [String!]): [Post!]!
+}
+
+type Post {
+	id: ID!
+}
+
+enum Status {
+	DRAFT
+	PUBLISHED
+}
+
+input Author {
+	name: String!
+	id: ID
+}
+
+input PostFilter {
+	status: Status
+	author: Author
+	draft: Boolean
+}`))
+	definitions := s.GetQuery().GetFieldByName([]byte("posts")).Arguments
+
+	tests := []struct {
+		name      string
+		query     string
+		variables json.RawMessage
+		want      string
+		wantErr   error
+	}{
+		{
+			name:  "literals are converted to JSON by argument name",
+			query: `{ posts(first: 1, id: "1", filter: { status: PUBLISHED, author: { name: "name" } }, tags: ["a", "b"]) { id } }`,
+			want:  `{"id":"1","filter":{"status":"PUBLISHED","author":{"name":"name"}},"first":1,"tags":["a","b"]}`,
+		},
+		{
+			name:  "integer literals of IDs are coerced to strings",
+			query: `{ posts(id: 1, filter: { author: { name: "name", id: 2 } }, tags: "a") { id } }`,
+			want:  `{"id":"1","filter":{"author":{"name":"name","id":"2"}},"first":10,"tags":["a"]}`,
+		},
+		{
+			name:      "variables are given by their names regardless of the argument names",
+			query:     `query ($postID: ID!, $status: Status) { posts(id: $postID, filter: { status: $status, draft: $draft }) { id } }`,
+			variables: json.RawMessage(`{"postID": "1", "status": "DRAFT"}`),
+			want:      `{"id":"1","filter":{"status":"DRAFT"},"first":10}`,
+		},
+		{
+			name:  "an argument given as null doesn't have the default value",
+			query: `{ posts(id: "1", first: null) { id } }`,
+			want:  `{"id":"1","first":null}`,
+		},
+		{
+			name:    "a non-null argument must be given",
+			query:   `query ($id: ID) { posts(id: $id) { id } }`,
+			wantErr: errors.New("argument id of non-null type ID! is not provided"),
+		},
+		{
+			name:      "a non-null argument must not be null",
+			query:     `query ($id: ID) { posts(id: $id) { id } }`,
+			variables: json.RawMessage(`{"id": null}`),
+			wantErr:   errors.New("argument id of non-null type ID! must not be null"),
+		},
+		{
+			name:    "literals out of the range of Int are rejected",
+			query:   `{ posts(id: "1", first: 3000000000) { id } }`,
+			wantErr: errors.New("argument first: expected a 32-bit signed integer, got 3000000000"),
+		},
+		{
+			name:    "literals of input objects are coerced field by field",
+			query:   `{ posts(id: "1", filter: { author: { id: "1" } }) { id } }`,
+			wantErr: errors.New("argument filter.author.name of non-null type String! is not provided"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := query.NewParserWithLexer().Parse([]byte(tt.query))
+			if err != nil {
+				t.Fatalf("Parse() error %v", err)
+			}
+
+			got, err := executor.CoerceArgumentValues(s, definitions, doc.Operations[0].Selections[0].(*query.Field).Arguments, tt.variables)
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Fatalf("CoerceArgumentValues() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("CoerceArgumentValues() error %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Errorf("CoerceArgumentValues() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeArgumentValues(t *testing.T) {
+	type postArgs struct {
+		Id    string    `json:"id"`
+		First *int      `json:"first"`
+		Tags  *[]string `json:"tags"`
+	}
+
+	s := schema.MustParse([]byte(`type Query {
+	post(id: ID!, first: Int = 10, tags: [String!]): Post
+}
+
+type Post {
+	id: ID!
+}`))
+	definitions := s.GetQuery().GetFieldByName([]byte("post")).Arguments
+
+	first := 10
+	tests := []struct {
+		name      string
+		query     string
+		variables json.RawMessage
+		want      postArgs
+		wantErr   error
+	}{
+		{
+			name:      "arguments are decoded with their default values",
+			query:     `query ($id: ID!) { post(id: $id, tags: ["a"]) { id } }`,
+			variables: json.RawMessage(`{"id": "1"}`),
+			want:      postArgs{Id: "1", First: &first, Tags: &[]string{"a"}},
+		},
+		{
+			name:    "arguments which can't be coerced aren't decoded",
+			query:   `{ post { id } }`,
+			wantErr: errors.New("argument id of non-null type ID! is not provided"),
+		},
+		{
+			name:    "arguments which don't fit the type fail to be coerced",
+			query:   `{ post(id: "1", first: "10") { id } }`,
+			wantErr: errors.New(`argument first: expected a 32-bit signed integer, got "10"`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := query.NewParserWithLexer().Parse([]byte(tt.query))
+			if err != nil {
+				t.Fatalf("Parse() error %v", err)
+			}
+
+			got, err := executor.DecodeArgumentValues[postArgs](s, definitions, doc.Operations[0].Selections[0].(*query.Field).Arguments, tt.variables)
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Fatalf("DecodeArgumentValues() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("DecodeArgumentValues() error %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DecodeArgumentValues() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
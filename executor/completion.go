@@ -0,0 +1,230 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+
+	"github.com/n9te9/goliteql/query"
+)
+
+// FieldErrors collects the field errors raised while the result of a root field is completed.
+// A field with an error at its path is null, and a null non-null field nulls its nearest nullable parent,
+// as described in "Handling Field Errors" of the specification.
+type FieldErrors struct {
+	mu     sync.Mutex
+	errors GraphQLErrors
+}
+
+// NewFieldErrors returns the field errors of a result, starting with errs reported by its resolver.
+func NewFieldErrors(errs GraphQLErrors) *FieldErrors {
+	return &FieldErrors{
+		errors: append(make(GraphQLErrors, 0, len(errs)), errs...),
+	}
+}
+
+// Add raises err.
+func (e *FieldErrors) Add(err GraphQLError) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.errors = append(e.errors, err)
+}
+
+// Raise raises err returned by the resolver of the field at path located at loc, converted by ResolverErrors,
+// so the errors without a path null the field and the others are relative to it.
+func (e *FieldErrors) Raise(path Path, loc *query.Loc, err error) {
+	for _, err := range ResolverErrors(err, loc).WithPathPrefix(path...) {
+		e.Add(err)
+	}
+}
+
+// Errors returns the errors raised so far, or nil if there isn't any.
+func (e *FieldErrors) Errors() GraphQLErrors {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.errors) == 0 {
+		return nil
+	}
+
+	return append(make(GraphQLErrors, 0, len(e.errors)), e.errors...)
+}
+
+// hasError reports whether an error is raised at path, or under path if descendants is true.
+func (e *FieldErrors) hasError(path Path, descendants bool) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, err := range e.errors {
+		if !descendants && len(err.Path) != len(path) {
+			continue
+		}
+
+		if path.isPrefixOf(err.Path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Complete returns the value of the field at path, which complete returns unless an error is raised at path.
+// ok is false when the value is null while nonNull is true, so the caller must null its nearest nullable parent.
+// A null non-null field raises an error located at loc, unless the null is caused by an error under path.
+// A leaf value which can't be serialized, such as an invalid enum value, raises an error at path and is null.
+func (e *FieldErrors) Complete(path Path, nonNull bool, loc *query.Loc, complete func(path Path) any) (value any, ok bool) {
+	if !e.hasError(path, false) {
+		value = complete(path)
+	}
+
+	if !isNull(value) {
+		if err := checkLeaf(value); err != nil {
+			e.Add(Errorf(ErrorCodeInternal, "%s", err).WithPath(path...).WithLocations(loc))
+			value = nil
+		}
+	}
+
+	if !isNull(value) {
+		return value, true
+	}
+
+	if !nonNull {
+		return nil, true
+	}
+
+	if !e.hasError(path, true) {
+		e.Add(Errorf(ErrorCodeInternal, "cannot return null for non-null field").WithPath(path...).WithLocations(loc))
+	}
+
+	return nil, false
+}
+
+// CompleteList completes every item of values at its index in path, keeping a nil list as null.
+// The list is null when one of its items is null while nonNullItems is true.
+func CompleteList[T any](errs *FieldErrors, path Path, loc *query.Loc, nonNullItems bool, values []T, complete func(path Path, v T) any) any {
+	if values == nil {
+		return nil
+	}
+
+	res := make([]any, len(values))
+	for i, v := range values {
+		item, ok := errs.Complete(path.Append(PathIndex(i)), nonNullItems, loc, func(path Path) any {
+			return complete(path, v)
+		})
+		if !ok {
+			return nil
+		}
+
+		res[i] = item
+	}
+
+	return res
+}
+
+// CompleteNullableList is CompleteList for lists declared as nullable in a model.
+func CompleteNullableList[T any](errs *FieldErrors, path Path, loc *query.Loc, nonNullItems bool, values *[]T, complete func(path Path, v T) any) any {
+	if values == nil {
+		return nil
+	}
+
+	return CompleteList(errs, path, loc, nonNullItems, *values, complete)
+}
+
+// CompleteListConcurrently is CompleteList which completes the items with the workers of ctx,
+// for the items whose fields are resolved by field resolvers. The items keep their order in the list.
+func CompleteListConcurrently[T any](ctx context.Context, errs *FieldErrors, path Path, loc *query.Loc, nonNullItems bool, values []T, complete func(path Path, v T) any) any {
+	if values == nil {
+		return nil
+	}
+
+	res := make([]any, len(values))
+	oks := make([]bool, len(values))
+
+	g := newWorkerGroup(ctx)
+	for i, v := range values {
+		g.Go(func() {
+			res[i], oks[i] = errs.Complete(path.Append(PathIndex(i)), nonNullItems, loc, func(path Path) any {
+				return complete(path, v)
+			})
+		})
+	}
+	g.Wait()
+
+	for _, ok := range oks {
+		if !ok {
+			return nil
+		}
+	}
+
+	return res
+}
+
+// CompleteNullableListConcurrently is CompleteListConcurrently for lists declared as nullable in a model.
+func CompleteNullableListConcurrently[T any](ctx context.Context, errs *FieldErrors, path Path, loc *query.Loc, nonNullItems bool, values *[]T, complete func(path Path, v T) any) any {
+	if values == nil {
+		return nil
+	}
+
+	return CompleteListConcurrently(ctx, errs, path, loc, nonNullItems, *values, complete)
+}
+
+// checkLeaf returns an error if v, the value of a leaf field, can't be serialized as a value of its type,
+// as described in "Result Coercion" of scalars and enums in the specification.
+// The objects and lists completed by the walkers aren't leaves and are always valid.
+func checkLeaf(v any) error {
+	switch v.(type) {
+	case *OrderedMap, []any:
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Int:
+		// Int of GraphQL is a Go int in the models, whose values may exceed 32 bits
+		if n := rv.Int(); n < math.MinInt32 || n > math.MaxInt32 {
+			return fmt.Errorf("Int cannot represent non 32-bit signed integer value: %d", n)
+		}
+	case reflect.Float32, reflect.Float64:
+		if f := rv.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("Float cannot represent non numeric value: %v", f)
+		}
+	}
+
+	switch v := v.(type) {
+	case interface{ IsValid() bool }:
+		// enums of the models
+		if !v.IsValid() {
+			return fmt.Errorf("%q is not a valid %s", rv.String(), rv.Type().Name())
+		}
+	case json.Marshaler:
+		// custom scalars
+		if _, err := v.MarshalJSON(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isNull reports whether v is written as null in a response, including nil pointers, slices and maps held by v.
+func isNull(v any) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return rv.IsNil()
+	}
+
+	return false
+}
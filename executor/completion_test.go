@@ -0,0 +1,315 @@
+package executor_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/query"
+)
+
+type completionPost struct {
+	Title  string
+	Author *string
+}
+
+func completePost(errs *executor.FieldErrors, path executor.Path, v completionPost, authorNonNull bool) any {
+	resp := executor.NewOrderedMap()
+
+	title, ok := errs.Complete(path.Append(executor.PathKey("title")), true, nil, func(path executor.Path) any {
+		return v.Title
+	})
+	if !ok {
+		return nil
+	}
+	resp.Set("title", title)
+
+	author, ok := errs.Complete(path.Append(executor.PathKey("author")), authorNonNull, nil, func(path executor.Path) any {
+		return v.Author
+	})
+	if !ok {
+		return nil
+	}
+	resp.Set("author", author)
+
+	return resp
+}
+
+func TestFieldErrors_Complete(t *testing.T) {
+	name := "name"
+
+	tests := []struct {
+		name          string
+		errors        executor.GraphQLErrors
+		posts         []completionPost
+		authorNonNull bool
+		itemNonNull   bool
+		listNonNull   bool
+		wantData      string
+		wantOK        bool
+		wantErrors    executor.GraphQLErrors
+	}{
+		{
+			name:     "fields without errors are completed",
+			posts:    []completionPost{{Title: "a", Author: &name}},
+			wantData: `{"posts":[{"title":"a","author":"name"}]}`,
+			wantOK:   true,
+		},
+		{
+			name: "a nullable field with an error is null",
+			errors: executor.GraphQLErrors{
+				executor.NewGraphQLError(executor.ErrorCodeInternal, "failed").WithPath(executor.PathKey("posts"), executor.PathIndex(0), executor.PathKey("author")),
+			},
+			posts:    []completionPost{{Title: "a", Author: &name}, {Title: "b", Author: &name}},
+			wantData: `{"posts":[{"title":"a","author":null},{"title":"b","author":"name"}]}`,
+			wantOK:   true,
+			wantErrors: executor.GraphQLErrors{
+				executor.NewGraphQLError(executor.ErrorCodeInternal, "failed").WithPath(executor.PathKey("posts"), executor.PathIndex(0), executor.PathKey("author")),
+			},
+		},
+		{
+			name: "a non-null field with an error nulls its nullable item",
+			errors: executor.GraphQLErrors{
+				executor.NewGraphQLError(executor.ErrorCodeInternal, "failed").WithPath(executor.PathKey("posts"), executor.PathIndex(1), executor.PathKey("title")),
+			},
+			posts:    []completionPost{{Title: "a", Author: &name}, {Title: "b", Author: &name}},
+			wantData: `{"posts":[{"title":"a","author":"name"},null]}`,
+			wantOK:   true,
+			wantErrors: executor.GraphQLErrors{
+				executor.NewGraphQLError(executor.ErrorCodeInternal, "failed").WithPath(executor.PathKey("posts"), executor.PathIndex(1), executor.PathKey("title")),
+			},
+		},
+		{
+			name:          "a null non-null field raises an error and nulls the list of non-null items",
+			posts:         []completionPost{{Title: "a"}},
+			authorNonNull: true,
+			itemNonNull:   true,
+			wantData:      `{"posts":null}`,
+			wantOK:        true,
+			wantErrors: executor.GraphQLErrors{
+				executor.NewGraphQLError(executor.ErrorCodeInternal, "cannot return null for non-null field").WithPath(executor.PathKey("posts"), executor.PathIndex(0), executor.PathKey("author")),
+			},
+		},
+		{
+			name:          "nulls propagate up to the root field",
+			posts:         []completionPost{{Title: "a"}},
+			authorNonNull: true,
+			itemNonNull:   true,
+			listNonNull:   true,
+			wantData:      `null`,
+			wantOK:        false,
+			wantErrors: executor.GraphQLErrors{
+				executor.NewGraphQLError(executor.ErrorCodeInternal, "cannot return null for non-null field").WithPath(executor.PathKey("posts"), executor.PathIndex(0), executor.PathKey("author")),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := executor.NewFieldErrors(tt.errors)
+
+			value, ok := errs.Complete(executor.Path{executor.PathKey("posts")}, tt.listNonNull, nil, func(path executor.Path) any {
+				return executor.CompleteList(errs, path, nil, tt.itemNonNull, tt.posts, func(path executor.Path, v completionPost) any {
+					return completePost(errs, path, v, tt.authorNonNull)
+				})
+			})
+			if ok != tt.wantOK {
+				t.Fatalf("Complete() ok = %v, want %v", ok, tt.wantOK)
+			}
+
+			var data any
+			if ok {
+				m := executor.NewOrderedMap()
+				m.Set("posts", value)
+				data = m
+			}
+
+			got, err := json.Marshal(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.wantData, string(got)); diff != "" {
+				t.Errorf("data mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantErrors, errs.Errors()); diff != "" {
+				t.Errorf("Errors() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFieldErrors_Raise(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		nonNull    bool
+		wantOK     bool
+		wantErrors executor.GraphQLErrors
+	}{
+		{
+			name:   "an error nulls the nullable field and is located at the field",
+			err:    errors.New("failed"),
+			wantOK: true,
+			wantErrors: executor.GraphQLErrors{
+				executor.NewGraphQLError(executor.ErrorCodeInternal, "failed").WithPath(executor.PathKey("post"), executor.PathKey("author")).WithLocations(&query.Loc{Start: query.Position{Line: 2, Column: 3}}),
+			},
+		},
+		{
+			name:    "an error of a non-null field nulls its parent without another error",
+			err:     executor.Errorf("NOT_FOUND", "author is not found"),
+			nonNull: true,
+			wantOK:  false,
+			wantErrors: executor.GraphQLErrors{
+				executor.Errorf("NOT_FOUND", "author is not found").WithPath(executor.PathKey("post"), executor.PathKey("author")).WithLocations(&query.Loc{Start: query.Position{Line: 2, Column: 3}}),
+			},
+		},
+		{
+			name:   "the path of an error is relative to the field",
+			err:    executor.Errorf(executor.ErrorCodeInternal, "name is not found").WithPath(executor.PathKey("name")),
+			wantOK: true,
+			wantErrors: executor.GraphQLErrors{
+				executor.Errorf(executor.ErrorCodeInternal, "name is not found").WithPath(executor.PathKey("post"), executor.PathKey("author"), executor.PathKey("name")),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := executor.NewFieldErrors(nil)
+			loc := &query.Loc{Start: query.Position{Line: 2, Column: 3}}
+
+			_, ok := errs.Complete(executor.Path{executor.PathKey("post"), executor.PathKey("author")}, tt.nonNull, loc, func(path executor.Path) any {
+				errs.Raise(path, loc, tt.err)
+				return nil
+			})
+			if ok != tt.wantOK {
+				t.Fatalf("Complete() ok = %v, want %v", ok, tt.wantOK)
+			}
+
+			if diff := cmp.Diff(tt.wantErrors, errs.Errors()); diff != "" {
+				t.Errorf("Errors() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type completionRole string
+
+func (r completionRole) IsValid() bool {
+	return r == "ADMIN" || r == "USER"
+}
+
+type completionScalar struct {
+	invalid bool
+}
+
+func (s completionScalar) MarshalJSON() ([]byte, error) {
+	if s.invalid {
+		return nil, errors.New("invalid scalar")
+	}
+
+	return []byte(`"scalar"`), nil
+}
+
+func TestFieldErrors_CompleteLeaf(t *testing.T) {
+	role := completionRole("ADMIN")
+
+	tests := []struct {
+		name       string
+		value      any
+		nonNull    bool
+		wantValue  any
+		wantOK     bool
+		wantErrors executor.GraphQLErrors
+	}{
+		{
+			name:      "a valid enum value is completed",
+			value:     &role,
+			wantValue: &role,
+			wantOK:    true,
+		},
+		{
+			name:   "an invalid enum value is null with an error",
+			value:  completionRole(""),
+			wantOK: true,
+			wantErrors: executor.GraphQLErrors{
+				executor.Errorf(executor.ErrorCodeInternal, `"" is not a valid completionRole`).WithPath(executor.PathKey("role")),
+			},
+		},
+		{
+			name:    "an invalid non-null enum value nulls its parent",
+			value:   completionRole("BOGUS"),
+			nonNull: true,
+			wantOK:  false,
+			wantErrors: executor.GraphQLErrors{
+				executor.Errorf(executor.ErrorCodeInternal, `"BOGUS" is not a valid completionRole`).WithPath(executor.PathKey("role")),
+			},
+		},
+		{
+			name:   "an Int out of 32 bits is null with an error",
+			value:  3000000000,
+			wantOK: true,
+			wantErrors: executor.GraphQLErrors{
+				executor.Errorf(executor.ErrorCodeInternal, "Int cannot represent non 32-bit signed integer value: 3000000000").WithPath(executor.PathKey("role")),
+			},
+		},
+		{
+			name:      "a custom scalar is completed",
+			value:     completionScalar{},
+			wantValue: completionScalar{},
+			wantOK:    true,
+		},
+		{
+			name:   "a custom scalar which fails to marshal is null with an error",
+			value:  completionScalar{invalid: true},
+			wantOK: true,
+			wantErrors: executor.GraphQLErrors{
+				executor.Errorf(executor.ErrorCodeInternal, "invalid scalar").WithPath(executor.PathKey("role")),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := executor.NewFieldErrors(nil)
+
+			value, ok := errs.Complete(executor.Path{executor.PathKey("role")}, tt.nonNull, nil, func(path executor.Path) any {
+				return tt.value
+			})
+			if ok != tt.wantOK {
+				t.Fatalf("Complete() ok = %v, want %v", ok, tt.wantOK)
+			}
+
+			if diff := cmp.Diff(tt.wantValue, value, cmp.AllowUnexported(completionScalar{})); diff != "" {
+				t.Errorf("Complete() mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantErrors, errs.Errors()); diff != "" {
+				t.Errorf("Errors() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompleteList_Leaves(t *testing.T) {
+	errs := executor.NewFieldErrors(nil)
+
+	got := executor.CompleteList(errs, executor.Path{executor.PathKey("roles")}, nil, false, []completionRole{"ADMIN", "BOGUS"}, func(path executor.Path, v completionRole) any {
+		return v
+	})
+
+	if diff := cmp.Diff([]any{completionRole("ADMIN"), nil}, got); diff != "" {
+		t.Errorf("CompleteList() mismatch (-want +got):\n%s", diff)
+	}
+
+	want := executor.GraphQLErrors{
+		executor.Errorf(executor.ErrorCodeInternal, `"BOGUS" is not a valid completionRole`).WithPath(executor.PathKey("roles"), executor.PathIndex(1)),
+	}
+	if diff := cmp.Diff(want, errs.Errors()); diff != "" {
+		t.Errorf("Errors() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,67 @@
+package dataloader
+
+import (
+	"context"
+	"sync"
+)
+
+type registryKey struct{}
+
+// registry holds the loaders of a request by their definitions.
+type registry struct {
+	mu      sync.Mutex
+	loaders map[any]any
+}
+
+// NewContext returns ctx with an empty registry of loaders, which holds the loaders of definitions used with the context.
+// The generated resolver calls it for every request, so that the field resolvers of a request share their loaders.
+func NewContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, registryKey{}, &registry{
+		loaders: make(map[any]any),
+	})
+}
+
+// Definition defines a loader which is created for every request, such as a loader of users by their IDs.
+// Definitions are usually declared as package-level variables and used in field resolvers.
+type Definition[K comparable, V any] struct {
+	batchFunc BatchFunc[K, V]
+	opts      []Option
+}
+
+// Define returns the definition of a loader which loads values with batchFunc.
+func Define[K comparable, V any](batchFunc BatchFunc[K, V], opts ...Option) *Definition[K, V] {
+	return &Definition[K, V]{
+		batchFunc: batchFunc,
+		opts:      opts,
+	}
+}
+
+// Loader returns the loader of d in the request of ctx, creating it on first use.
+// Without a registry in ctx, it returns a new loader every time, which neither batches nor caches keys across calls.
+func (d *Definition[K, V]) Loader(ctx context.Context) *Loader[K, V] {
+	r, ok := ctx.Value(registryKey{}).(*registry)
+	if !ok {
+		return New(d.batchFunc, d.opts...)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if l, ok := r.loaders[d]; ok {
+		return l.(*Loader[K, V])
+	}
+
+	l := New(d.batchFunc, d.opts...)
+	r.loaders[d] = l
+	return l
+}
+
+// Load loads key with the loader of d in the request of ctx.
+func (d *Definition[K, V]) Load(ctx context.Context, key K) (V, error) {
+	return d.Loader(ctx).Load(ctx, key)
+}
+
+// LoadMany loads keys with the loader of d in the request of ctx.
+func (d *Definition[K, V]) LoadMany(ctx context.Context, keys []K) ([]V, error) {
+	return d.Loader(ctx).LoadMany(ctx, keys)
+}
@@ -0,0 +1,223 @@
+// Package dataloader batches and caches the loads of field resolvers, so that the fields of the items of a list
+// are loaded with one call of a batch function instead of one call per item.
+package dataloader
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BatchFunc loads the values of keys at once, returning them in the order of keys.
+// An error fails the load of every key of the batch.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) ([]V, error)
+
+// DefaultWait is the time a loader waits for more keys before it calls its batch function.
+const DefaultWait = time.Millisecond
+
+type options struct {
+	maxBatch int
+	wait     time.Duration
+}
+
+// Option configures a loader.
+type Option func(*options)
+
+// WithMaxBatch limits the number of keys of a batch to n, where n <= 0 means no limit.
+// A batch is dispatched as soon as it has n keys.
+func WithMaxBatch(n int) Option {
+	return func(o *options) {
+		o.maxBatch = n
+	}
+}
+
+// WithWait sets the time a loader waits for more keys after the first key of a batch.
+func WithWait(d time.Duration) Option {
+	return func(o *options) {
+		o.wait = d
+	}
+}
+
+// Loader loads values by their keys with a batch function.
+// The keys loaded within the wait window of the first key of a batch are loaded together,
+// and the loaded values are cached, so a key is loaded only once during the lifetime of the loader.
+// A loader is meant to live as long as a request, so that the values aren't cached across requests.
+type Loader[K comparable, V any] struct {
+	batchFunc BatchFunc[K, V]
+	options   options
+
+	mu    sync.Mutex
+	cache map[K]*result[V]
+	batch *batch[K, V]
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type batch[K comparable, V any] struct {
+	keys    []K
+	results []*result[V]
+}
+
+// New returns a loader which loads values with batchFunc.
+func New[K comparable, V any](batchFunc BatchFunc[K, V], opts ...Option) *Loader[K, V] {
+	o := options{wait: DefaultWait}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Loader[K, V]{
+		batchFunc: batchFunc,
+		options:   o,
+		cache:     make(map[K]*result[V]),
+	}
+}
+
+// Load returns the value of key, waiting for the batch of key to be loaded unless it's cached.
+// The batch function is called with the context of the first load of the batch.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	r := l.load(ctx, key)
+
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// LoadMany returns the values of keys in their order, loading them in the same batches.
+// The error is the first error of the keys.
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) ([]V, error) {
+	results := make([]*result[V], len(keys))
+	for i, key := range keys {
+		results[i] = l.load(ctx, key)
+	}
+
+	values := make([]V, len(keys))
+	for i, r := range results {
+		select {
+		case <-r.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if r.err != nil {
+			return nil, r.err
+		}
+		values[i] = r.value
+	}
+
+	return values, nil
+}
+
+// Prime caches value as the value of key unless key is already cached.
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.cache[key]; ok {
+		return
+	}
+
+	r := &result[V]{done: make(chan struct{}), value: value}
+	close(r.done)
+	l.cache[key] = r
+}
+
+// Clear removes key from the cache, so that it's loaded again by the next load, such as after a mutation of its value.
+func (l *Loader[K, V]) Clear(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.cache, key)
+}
+
+// load returns the result of key, adding key to the current batch unless it's cached.
+func (l *Loader[K, V]) load(ctx context.Context, key K) *result[V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if r, ok := l.cache[key]; ok {
+		return r
+	}
+
+	r := &result[V]{done: make(chan struct{})}
+	l.cache[key] = r
+
+	if l.batch == nil {
+		b := &batch[K, V]{}
+		l.batch = b
+		time.AfterFunc(l.options.wait, func() {
+			l.dispatch(ctx, b)
+		})
+	}
+
+	b := l.batch
+	b.keys = append(b.keys, key)
+	b.results = append(b.results, r)
+
+	if l.options.maxBatch > 0 && len(b.keys) >= l.options.maxBatch {
+		l.batch = nil
+		go l.run(ctx, b)
+	}
+
+	return r
+}
+
+// dispatch runs b unless it has been run for reaching the max batch size.
+func (l *Loader[K, V]) dispatch(ctx context.Context, b *batch[K, V]) {
+	l.mu.Lock()
+	if l.batch != b {
+		l.mu.Unlock()
+		return
+	}
+	l.batch = nil
+	l.mu.Unlock()
+
+	l.run(ctx, b)
+}
+
+// run loads the keys of b with the batch function and completes their results.
+// The keys which fail are removed from the cache, so that they are loaded again by the next load.
+func (l *Loader[K, V]) run(ctx context.Context, b *batch[K, V]) {
+	values, err := l.call(ctx, b.keys)
+	if err == nil && len(values) != len(b.keys) {
+		err = fmt.Errorf("batch function returned %d values for %d keys", len(values), len(b.keys))
+	}
+
+	if err != nil {
+		l.mu.Lock()
+		for i, key := range b.keys {
+			if l.cache[key] == b.results[i] {
+				delete(l.cache, key)
+			}
+		}
+		l.mu.Unlock()
+	}
+
+	for i, r := range b.results {
+		if err != nil {
+			r.err = err
+		} else {
+			r.value = values[i]
+		}
+		close(r.done)
+	}
+}
+
+// call calls the batch function, converting its panic into an error so that the loads waiting for it fail instead of hanging.
+func (l *Loader[K, V]) call(ctx context.Context, keys []K) (values []V, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("batch function panicked: %v", v)
+		}
+	}()
+
+	return l.batchFunc(ctx, keys)
+}
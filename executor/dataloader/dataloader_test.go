@@ -0,0 +1,218 @@
+package dataloader_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor/dataloader"
+)
+
+// recorder records the batches of a batch function which returns the keys formatted as values.
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]int
+	err     error
+}
+
+func (r *recorder) batch(ctx context.Context, keys []int) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.batches = append(r.batches, append([]int{}, keys...))
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		values[i] = fmt.Sprintf("value %d", key)
+	}
+
+	return values, nil
+}
+
+// sortedBatches returns the batches with their keys sorted, as concurrent loads add keys in any order.
+func (r *recorder) sortedBatches() [][]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := make([][]int, len(r.batches))
+	for i, b := range r.batches {
+		res[i] = append([]int{}, b...)
+		sort.Ints(res[i])
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i][0] < res[j][0]
+	})
+
+	return res
+}
+
+func TestLoader_LoadMany(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []dataloader.Option
+		keys        []int
+		err         error
+		wantValues  []string
+		wantErr     error
+		wantBatches [][]int
+	}{
+		{
+			name:        "keys are batched and duplicated keys are loaded once",
+			keys:        []int{1, 2, 3, 2},
+			wantValues:  []string{"value 1", "value 2", "value 3", "value 2"},
+			wantBatches: [][]int{{1, 2, 3}},
+		},
+		{
+			name:        "batches are limited to the max batch size",
+			opts:        []dataloader.Option{dataloader.WithMaxBatch(2)},
+			keys:        []int{1, 2, 3, 4, 5},
+			wantValues:  []string{"value 1", "value 2", "value 3", "value 4", "value 5"},
+			wantBatches: [][]int{{1, 2}, {3, 4}, {5}},
+		},
+		{
+			name:        "an error of the batch function fails the keys of the batch",
+			keys:        []int{1, 2},
+			err:         errors.New("failed"),
+			wantErr:     errors.New("failed"),
+			wantBatches: [][]int{{1, 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{err: tt.err}
+			l := dataloader.New(r.batch, tt.opts...)
+
+			values, err := l.LoadMany(context.Background(), tt.keys)
+			if (err == nil) != (tt.wantErr == nil) || (err != nil && err.Error() != tt.wantErr.Error()) {
+				t.Fatalf("LoadMany() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantValues, values); diff != "" {
+				t.Errorf("LoadMany() mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantBatches, r.sortedBatches()); diff != "" {
+				t.Errorf("batches mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoader_Load(t *testing.T) {
+	r := &recorder{}
+	l := dataloader.New(r.batch, dataloader.WithWait(20*time.Millisecond))
+
+	keys := []int{1, 2, 3, 2}
+	values := make([]string, len(keys))
+
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			values[i], _ = l.Load(context.Background(), key)
+		}()
+	}
+	wg.Wait()
+
+	if diff := cmp.Diff([]string{"value 1", "value 2", "value 3", "value 2"}, values); diff != "" {
+		t.Errorf("Load() mismatch (-want +got):\n%s", diff)
+	}
+
+	// concurrent loads within the wait window are loaded in one batch
+	if diff := cmp.Diff([][]int{{1, 2, 3}}, r.sortedBatches()); diff != "" {
+		t.Errorf("batches mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoader_Cache(t *testing.T) {
+	r := &recorder{}
+	l := dataloader.New(r.batch)
+	ctx := context.Background()
+
+	l.Prime(1, "primed")
+	got, err := l.LoadMany(ctx, []int{1, 2})
+	if err != nil {
+		t.Fatalf("LoadMany() error %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"primed", "value 2"}, got); diff != "" {
+		t.Errorf("LoadMany() mismatch (-want +got):\n%s", diff)
+	}
+
+	// cached keys aren't loaded again until they are cleared
+	l.Load(ctx, 2)
+	l.Clear(1)
+	l.Load(ctx, 1)
+
+	if diff := cmp.Diff([][]int{{1}, {2}}, r.sortedBatches()); diff != "" {
+		t.Errorf("batches mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoader_FailedKeysAreLoadedAgain(t *testing.T) {
+	r := &recorder{err: errors.New("failed")}
+	l := dataloader.New(r.batch)
+	ctx := context.Background()
+
+	if _, err := l.Load(ctx, 1); err == nil {
+		t.Fatal("Load() error = nil, want failed")
+	}
+
+	r.mu.Lock()
+	r.err = nil
+	r.mu.Unlock()
+
+	got, err := l.Load(ctx, 1)
+	if err != nil {
+		t.Fatalf("Load() error %v", err)
+	}
+
+	if got != "value 1" {
+		t.Errorf("Load() = %q, want %q", got, "value 1")
+	}
+}
+
+func TestLoader_BatchFuncMustReturnValueForEveryKey(t *testing.T) {
+	l := dataloader.New(func(ctx context.Context, keys []int) ([]string, error) {
+		return []string{}, nil
+	})
+
+	_, err := l.Load(context.Background(), 1)
+	if err == nil || err.Error() != "batch function returned 0 values for 1 keys" {
+		t.Errorf("Load() error = %v", err)
+	}
+}
+
+func TestDefinition_Loader(t *testing.T) {
+	r := &recorder{}
+	users := dataloader.Define(r.batch)
+
+	ctx := dataloader.NewContext(context.Background())
+	if users.Loader(ctx) != users.Loader(ctx) {
+		t.Error("Loader() returned different loaders in a request")
+	}
+
+	other := dataloader.NewContext(context.Background())
+	if users.Loader(ctx) == users.Loader(other) {
+		t.Error("Loader() returned the same loader in different requests")
+	}
+
+	users.Load(ctx, 1)
+	users.Load(ctx, 1)
+	users.Load(other, 1)
+
+	if diff := cmp.Diff([][]int{{1}, {1}}, r.sortedBatches()); diff != "" {
+		t.Errorf("batches mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -25,7 +25,7 @@ func IsIncluded(directives []*query.Directive, v json.RawMessage) bool {
 				return false
 			}
 
-			if dir.Arguments[0].IsVariable {
+			if variable, ok := dir.Arguments[0].Value.(*query.VariableValue); ok {
 				if string(dir.Arguments[0].Name) != "if" {
 					return false
 				}
@@ -35,7 +35,7 @@ func IsIncluded(directives []*query.Directive, v json.RawMessage) bool {
 					return false
 				}
 
-				flag, ok := variables[string(dir.Arguments[0].Name)].(bool)
+				flag, ok := variables[string(variable.Name)].(bool)
 				if !ok {
 					return true
 				}
@@ -43,11 +43,8 @@ func IsIncluded(directives []*query.Directive, v json.RawMessage) bool {
 				return flag
 			}
 
-			if string(dir.Arguments[0].Value) == "true" {
-				return true
-			}
-
-			return false
+			flag, ok := dir.Arguments[0].Value.(*query.BooleanValue)
+			return ok && flag.Value
 		}
 	}
 
@@ -73,7 +70,7 @@ func IsSkipped(directives []*query.Directive, v json.RawMessage) bool {
 				return false
 			}
 
-			if dir.Arguments[0].IsVariable {
+			if variable, ok := dir.Arguments[0].Value.(*query.VariableValue); ok {
 				if string(dir.Arguments[0].Name) != "if" {
 					return true
 				}
@@ -83,7 +80,7 @@ func IsSkipped(directives []*query.Directive, v json.RawMessage) bool {
 					return false
 				}
 
-				flag, ok := variables[string(dir.Arguments[0].Name)].(bool)
+				flag, ok := variables[string(variable.Name)].(bool)
 				if !ok {
 					return true
 				}
@@ -91,7 +88,8 @@ func IsSkipped(directives []*query.Directive, v json.RawMessage) bool {
 				return flag
 			}
 
-			return string(dir.Arguments[0].Value) == "true"
+			flag, ok := dir.Arguments[0].Value.(*query.BooleanValue)
+			return ok && flag.Value
 		}
 	}
 
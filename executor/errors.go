@@ -0,0 +1,272 @@
+package executor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/n9te9/goliteql/query"
+)
+
+// ErrorCode is the code of a GraphQLError, which is written to the code of its extensions.
+type ErrorCode string
+
+const (
+	ErrorCodeParseFailed                ErrorCode = "GRAPHQL_PARSE_FAILED"
+	ErrorCodeValidationFailed           ErrorCode = "GRAPHQL_VALIDATION_FAILED"
+	ErrorCodeOperationResolutionFailure ErrorCode = "OPERATION_RESOLUTION_FAILURE"
+	ErrorCodeBadUserInput               ErrorCode = "BAD_USER_INPUT"
+	ErrorCodeInternal                   ErrorCode = "INTERNAL_SERVER_ERROR"
+)
+
+// PathSegment is a segment of the path of a response field, which is a PathKey or a PathIndex.
+type PathSegment interface {
+	isPathSegment()
+}
+
+// PathKey is the response key of a field in a path.
+type PathKey string
+
+func (PathKey) isPathSegment() {}
+
+// PathIndex is the index of an item of a list in a path.
+type PathIndex int
+
+func (PathIndex) isPathSegment() {}
+
+// Path is the path of a response field from the root of the response, such as ["posts", 0, "author"].
+type Path []PathSegment
+
+func (p *Path) UnmarshalJSON(b []byte) error {
+	var segments []any
+	if err := json.Unmarshal(b, &segments); err != nil {
+		return err
+	}
+
+	res := make(Path, 0, len(segments))
+	for _, s := range segments {
+		switch v := s.(type) {
+		case string:
+			res = append(res, PathKey(v))
+		case float64:
+			res = append(res, PathIndex(v))
+		default:
+			return fmt.Errorf("path segment must be a string or an integer but got %v", s)
+		}
+	}
+	*p = res
+
+	return nil
+}
+
+// Append returns a copy of p followed by segment, leaving p as it is.
+func (p Path) Append(segment PathSegment) Path {
+	return append(append(make(Path, 0, len(p)+1), p...), segment)
+}
+
+func (p Path) isPrefixOf(path Path) bool {
+	if len(p) > len(path) {
+		return false
+	}
+
+	for i, s := range p {
+		if path[i] != s {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Location is a location in the query an error is about.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// NewLocation returns the location of the start of loc.
+func NewLocation(loc *query.Loc) Location {
+	return Location{Line: loc.Start.Line, Column: loc.Start.Column}
+}
+
+// GraphQLError is an error of the errors of a response, as defined by the specification.
+type GraphQLError struct {
+	Message    string         `json:"message"`
+	Locations  []Location     `json:"locations,omitempty"`
+	Path       Path           `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// NewGraphQLError returns an error with message whose extensions have code.
+func NewGraphQLError(code ErrorCode, message string) GraphQLError {
+	return GraphQLError{
+		Message:    message,
+		Extensions: map[string]any{"code": code},
+	}
+}
+
+// Errorf returns an error with the formatted message whose extensions have code.
+func Errorf(code ErrorCode, format string, args ...any) GraphQLError {
+	return NewGraphQLError(code, fmt.Sprintf(format, args...))
+}
+
+func (e GraphQLError) Error() string {
+	return e.Message
+}
+
+// Code returns the code of the extensions of e, or an empty code if e doesn't have one.
+func (e GraphQLError) Code() ErrorCode {
+	switch code := e.Extensions["code"].(type) {
+	case ErrorCode:
+		return code
+	case string:
+		return ErrorCode(code)
+	}
+
+	return ""
+}
+
+// WithPath returns e at path.
+func (e GraphQLError) WithPath(path ...PathSegment) GraphQLError {
+	e.Path = append(make(Path, 0, len(path)), path...)
+	return e
+}
+
+// WithLocations returns e about the nodes at locs, skipping the nodes without a location.
+func (e GraphQLError) WithLocations(locs ...*query.Loc) GraphQLError {
+	locations := make([]Location, 0, len(locs))
+	for _, loc := range locs {
+		if loc != nil {
+			locations = append(locations, NewLocation(loc))
+		}
+	}
+
+	if len(locations) > 0 {
+		e.Locations = locations
+	}
+
+	return e
+}
+
+// GraphQLErrors is the errors of a response, which is omitted from the response when it's empty.
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Message)
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// WithPathPrefix returns e with prefix prepended to the path of every error,
+// such as the response key of the root field whose resolver returned them.
+func (e GraphQLErrors) WithPathPrefix(prefix ...PathSegment) GraphQLErrors {
+	if len(e) == 0 {
+		return e
+	}
+
+	res := make(GraphQLErrors, 0, len(e))
+	for _, err := range e {
+		path := append(append(make(Path, 0, len(prefix)+len(err.Path)), prefix...), err.Path...)
+		err.Path = path
+		res = append(res, err)
+	}
+
+	return res
+}
+
+// ToGraphQLErrors converts err into the errors of a response.
+// GraphQLError and GraphQLErrors found in err are kept as they are, errors joined by errors.Join are
+// converted one by one, and the other errors become an error with their message.
+// The result is a slice of its own, so the caller can modify it without modifying GraphQLErrors held by err.
+func ToGraphQLErrors(err error) GraphQLErrors {
+	if err == nil {
+		return nil
+	}
+
+	switch e := err.(type) {
+	case GraphQLErrors:
+		return append(make(GraphQLErrors, 0, len(e)), e...)
+	case GraphQLError:
+		return GraphQLErrors{e}
+	case interface{ Unwrap() []error }:
+		res := make(GraphQLErrors, 0)
+		for _, err := range e.Unwrap() {
+			res = append(res, ToGraphQLErrors(err)...)
+		}
+
+		return res
+	}
+
+	var gqlErrs GraphQLErrors
+	if errors.As(err, &gqlErrs) {
+		return append(make(GraphQLErrors, 0, len(gqlErrs)), gqlErrs...)
+	}
+
+	var gqlErr GraphQLError
+	if errors.As(err, &gqlErr) {
+		return GraphQLErrors{gqlErr}
+	}
+
+	return GraphQLErrors{{Message: err.Error()}}
+}
+
+// ResolverErrors converts err returned by the resolver of the field at loc into the errors of the field,
+// whose paths are relative to the field as the errors of CompleteRootField are.
+// The errors without a code are internal errors, and the errors without a path are about the field itself,
+// so they are located at loc and null the field.
+func ResolverErrors(err error, loc *query.Loc) GraphQLErrors {
+	errs := ToGraphQLErrors(err)
+	for i := range errs {
+		if errs[i].Code() == "" {
+			extensions := make(map[string]any, len(errs[i].Extensions)+1)
+			for key, value := range errs[i].Extensions {
+				extensions[key] = value
+			}
+			extensions["code"] = ErrorCodeInternal
+			errs[i].Extensions = extensions
+		}
+
+		if len(errs[i].Path) == 0 && len(errs[i].Locations) == 0 {
+			errs[i] = errs[i].WithLocations(loc)
+		}
+	}
+
+	return errs
+}
+
+// locatedError is an error about nodes of a query, such as the errors of the validator, which know where the nodes are.
+type locatedError interface {
+	error
+	Locations() []*query.Loc
+}
+
+// ValidationErrors converts errs reported by the validator into the errors of a response with the code GRAPHQL_VALIDATION_FAILED,
+// at the locations of the nodes they're about.
+func ValidationErrors(errs []error) GraphQLErrors {
+	res := make(GraphQLErrors, 0, len(errs))
+	for _, err := range errs {
+		e := NewGraphQLError(ErrorCodeValidationFailed, err.Error())
+
+		var located locatedError
+		if errors.As(err, &located) {
+			e = e.WithLocations(located.Locations()...)
+		}
+
+		res = append(res, e)
+	}
+
+	return res
+}
+
+// WriteErrorResponse writes RequestErrorResponse, the response of a request which fails before its execution begins,
+// whose errors are err converted by ToGraphQLErrors.
+func WriteErrorResponse(w http.ResponseWriter, statusCode int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(RequestErrorResponse{Errors: ToGraphQLErrors(err)})
+}
@@ -0,0 +1,237 @@
+package executor_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/query"
+)
+
+func TestToGraphQLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want executor.GraphQLErrors
+	}{
+		{
+			name: "nil error",
+			err:  nil,
+			want: nil,
+		},
+		{
+			name: "plain error keeps its message",
+			err:  errors.New("something went wrong"),
+			want: executor.GraphQLErrors{
+				{Message: "something went wrong"},
+			},
+		},
+		{
+			name: "wrapped GraphQLError keeps its code",
+			err:  fmt.Errorf("error resolving post: %w", executor.Errorf(executor.ErrorCodeBadUserInput, "post %s is not found", "1")),
+			want: executor.GraphQLErrors{
+				{Message: "post 1 is not found", Extensions: map[string]any{"code": executor.ErrorCodeBadUserInput}},
+			},
+		},
+		{
+			name: "joined errors are converted one by one",
+			err: errors.Join(
+				executor.NewGraphQLError(executor.ErrorCodeInternal, "first").WithPath(executor.PathKey("posts"), executor.PathIndex(1)),
+				errors.New("second"),
+			),
+			want: executor.GraphQLErrors{
+				{Message: "first", Path: executor.Path{executor.PathKey("posts"), executor.PathIndex(1)}, Extensions: map[string]any{"code": executor.ErrorCodeInternal}},
+				{Message: "second"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := executor.ToGraphQLErrors(tt.err)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ToGraphQLErrors() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolverErrors(t *testing.T) {
+	loc := &query.Loc{Start: query.Position{Line: 2, Column: 3}}
+
+	tests := []struct {
+		name string
+		err  error
+		want executor.GraphQLErrors
+	}{
+		{
+			name: "nil error",
+			err:  nil,
+			want: nil,
+		},
+		{
+			name: "plain error is an internal error located at the field",
+			err:  errors.New("something went wrong"),
+			want: executor.GraphQLErrors{
+				{Message: "something went wrong", Locations: []executor.Location{{Line: 2, Column: 3}}, Extensions: map[string]any{"code": executor.ErrorCodeInternal}},
+			},
+		},
+		{
+			name: "GraphQLError keeps its code and its path relative to the field",
+			err: errors.Join(
+				executor.Errorf(executor.ErrorCodeBadUserInput, "post is not found"),
+				executor.GraphQLError{Message: "author failed", Extensions: map[string]any{"retry": true}}.WithPath(executor.PathKey("author")),
+			),
+			want: executor.GraphQLErrors{
+				{Message: "post is not found", Locations: []executor.Location{{Line: 2, Column: 3}}, Extensions: map[string]any{"code": executor.ErrorCodeBadUserInput}},
+				{Message: "author failed", Path: executor.Path{executor.PathKey("author")}, Extensions: map[string]any{"code": executor.ErrorCodeInternal, "retry": true}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := executor.ResolverErrors(tt.err, loc)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ResolverErrors() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolverErrors_KeepsErrorsOfResolver(t *testing.T) {
+	errs := executor.GraphQLErrors{{Message: "post is not found"}}
+
+	executor.ResolverErrors(errs, &query.Loc{Start: query.Position{Line: 2, Column: 3}})
+	executor.ResolverErrors(fmt.Errorf("wrapped: %w", errs), nil)
+
+	if diff := cmp.Diff(executor.GraphQLErrors{{Message: "post is not found"}}, errs); diff != "" {
+		t.Errorf("errors of the resolver are modified (-want +got):\n%s", diff)
+	}
+}
+
+type locatedError struct {
+	error
+	locs []*query.Loc
+}
+
+func (e locatedError) Locations() []*query.Loc {
+	return e.locs
+}
+
+func TestValidationErrors(t *testing.T) {
+	loc := &query.Loc{Start: query.Position{Line: 1, Column: 9, Offset: 8}}
+
+	got := executor.ValidationErrors([]error{
+		errors.New("field user is not defined in schema"),
+		errors.New("variable $id is not used"),
+		fmt.Errorf("error validating field post: %w", locatedError{errors.New("field unknown is not defined on Post in schema"), []*query.Loc{loc, nil}}),
+	})
+
+	want := executor.GraphQLErrors{
+		executor.NewGraphQLError(executor.ErrorCodeValidationFailed, "field user is not defined in schema"),
+		executor.NewGraphQLError(executor.ErrorCodeValidationFailed, "variable $id is not used"),
+		executor.NewGraphQLError(executor.ErrorCodeValidationFailed, "error validating field post: field unknown is not defined on Post in schema").WithLocations(loc),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ValidationErrors() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGraphQLResponse_MarshalJSON(t *testing.T) {
+	loc := &query.Loc{Start: query.Position{Line: 2, Column: 3, Offset: 10}}
+
+	tests := []struct {
+		name string
+		resp executor.GraphQLResponse
+		want string
+	}{
+		{
+			name: "errors are omitted without errors",
+			resp: executor.GraphQLResponse{Data: map[string]any{"id": "1"}},
+			want: `{"data":{"id":"1"}}`,
+		},
+		{
+			name: "errors have locations, mixed paths and extensions",
+			resp: executor.GraphQLResponse{
+				Errors: executor.GraphQLErrors{
+					executor.Errorf(executor.ErrorCodeInternal, "author is not found").WithLocations(loc).WithPath(executor.PathIndex(0), executor.PathKey("author")),
+				}.WithPathPrefix(executor.PathKey("posts")),
+			},
+			want: `{"data":null,"errors":[{"message":"author is not found","locations":[{"line":2,"column":3}],"path":["posts",0,"author"],"extensions":{"code":"INTERNAL_SERVER_ERROR"}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.resp)
+			if err != nil {
+				t.Fatalf("Marshal() error %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Errorf("Marshal() mismatch (-want +got):\n%s", diff)
+			}
+
+			var resp executor.GraphQLResponse
+			if err := json.Unmarshal(got, &resp); err != nil {
+				t.Fatalf("Unmarshal() error %v", err)
+			}
+
+			if diff := cmp.Diff(len(tt.resp.Errors), len(resp.Errors)); diff != "" {
+				t.Errorf("Unmarshal() errors mismatch (-want +got):\n%s", diff)
+			}
+
+			for i, e := range resp.Errors {
+				if diff := cmp.Diff(tt.resp.Errors[i].Path, e.Path); diff != "" {
+					t.Errorf("Unmarshal() path mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteErrorResponse(t *testing.T) {
+	tests := []struct {
+		name           string
+		statusCode     int
+		err            error
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:           "a request error has no data",
+			statusCode:     http.StatusBadRequest,
+			err:            executor.Errorf(executor.ErrorCodeParseFailed, "failed to parse query"),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"errors":[{"message":"failed to parse query","extensions":{"code":"GRAPHQL_PARSE_FAILED"}}]}` + "\n",
+		},
+		{
+			name:           "every validation error is written",
+			statusCode:     http.StatusBadRequest,
+			err:            executor.ValidationErrors([]error{errors.New("field user is not defined in schema"), errors.New("variable $id is never used")}),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"errors":[{"message":"field user is not defined in schema","extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}},{"message":"variable $id is never used","extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}]}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			executor.WriteErrorResponse(w, tt.statusCode, tt.err)
+
+			if w.Code != tt.wantStatusCode {
+				t.Errorf("status code = %d, want %d", w.Code, tt.wantStatusCode)
+			}
+
+			if diff := cmp.Diff(tt.wantBody, w.Body.String()); diff != "" {
+				t.Errorf("WriteErrorResponse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,1038 @@
+package executor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/schema"
+)
+
+const (
+	kindScalar      = "SCALAR"
+	kindObject      = "OBJECT"
+	kindInterface   = "INTERFACE"
+	kindUnion       = "UNION"
+	kindEnum        = "ENUM"
+	kindInputObject = "INPUT_OBJECT"
+	kindList        = "LIST"
+	kindNonNull     = "NON_NULL"
+)
+
+func IsIntrospectionField(name []byte) bool {
+	switch string(name) {
+	case "__schema", "__type", "__typename":
+		return true
+	}
+
+	return false
+}
+
+// IsIntrospectionQuery reports whether every root field of op is an introspection field.
+func IsIntrospectionQuery(op *query.Operation) bool {
+	if op == nil || len(op.Selections) == 0 {
+		return false
+	}
+
+	for _, sel := range op.Selections {
+		f, ok := sel.(*query.Field)
+		if !ok || !IsIntrospectionField(f.Name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ServeIntrospection answers an introspection operation and writes it as GraphQLResponse.
+func ServeIntrospection(w http.ResponseWriter, s *schema.Schema, op *query.Operation, fragments query.FragmentDefinitions, variables json.RawMessage) {
+	resp := GraphQLResponse{}
+
+	data, err := Introspect(s, op, fragments, variables)
+	if err != nil {
+		resp.Errors = ToGraphQLErrors(err)
+	} else {
+		resp.Data = data
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// Introspect resolves the __schema, __type and __typename root fields of op against s.
+func Introspect(s *schema.Schema, op *query.Operation, fragments query.FragmentDefinitions, variables json.RawMessage) (*OrderedMap, error) {
+	if op == nil {
+		return nil, fmt.Errorf("operation is not defined")
+	}
+
+	i, err := newIntrospector(s, fragments, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	rootTypeName := i.rootTypeName(schema.OperationType(op.OperationType))
+	collected, err := i.collectFields(rootTypeName, op.Selections)
+	if err != nil {
+		return nil, err
+	}
+
+	res := NewOrderedMap()
+	for _, c := range collected {
+		f := c.fields[0]
+		switch string(f.Name) {
+		case "__typename":
+			res.Set(c.key, rootTypeName)
+		case "__schema":
+			v, err := i.resolveSchema(c.selections())
+			if err != nil {
+				return nil, err
+			}
+			res.Set(c.key, v)
+		case "__type":
+			v, err := i.resolveType(i.types[i.stringArgument(f, "name")], c.selections())
+			if err != nil {
+				return nil, err
+			}
+			res.Set(c.key, v)
+		default:
+			return nil, fmt.Errorf("field %s is not an introspection field", f.Name)
+		}
+	}
+
+	return res, nil
+}
+
+type introspectionType struct {
+	kind           string
+	name           string
+	description    string
+	specifiedByURL string
+	fields         []*introspectionField
+	interfaces     []string
+	possibleTypes  []string
+	enumValues     []*introspectionEnumValue
+	inputFields    []*introspectionInputValue
+	ofType         *introspectionType
+}
+
+type introspectionField struct {
+	name              string
+	description       string
+	args              []*introspectionInputValue
+	fieldType         *schema.FieldType
+	isDeprecated      bool
+	deprecationReason string
+}
+
+type introspectionInputValue struct {
+	name         string
+	description  string
+	fieldType    *schema.FieldType
+	defaultValue query.InputValue
+}
+
+type introspectionEnumValue struct {
+	name              string
+	description       string
+	isDeprecated      bool
+	deprecationReason string
+}
+
+type introspector struct {
+	schema    *schema.Schema
+	types     map[string]*introspectionType
+	typeNames []string
+	fragments query.FragmentDefinitions
+	variables json.RawMessage
+}
+
+func newIntrospector(s *schema.Schema, fragments query.FragmentDefinitions, variables json.RawMessage) (*introspector, error) {
+	i := &introspector{
+		schema:    s,
+		types:     make(map[string]*introspectionType),
+		fragments: fragments,
+		variables: variables,
+	}
+
+	for _, op := range s.Operations {
+		i.addType(&introspectionType{
+			kind:        kindObject,
+			name:        i.rootTypeName(op.OperationType),
+			description: string(op.Description),
+			fields:      newIntrospectionFields(op.Fields),
+		})
+	}
+
+	for _, t := range s.Types {
+		interfaces := make([]string, 0, len(t.Interfaces))
+		for _, iface := range t.Interfaces {
+			interfaces = append(interfaces, string(iface.Name))
+		}
+
+		i.addType(&introspectionType{
+			kind:        kindObject,
+			name:        string(t.Name),
+			description: string(t.Description),
+			fields:      newIntrospectionFields(t.Fields),
+			interfaces:  interfaces,
+		})
+	}
+
+	for _, iface := range s.Interfaces {
+		possibleTypes := make([]string, 0)
+		for _, t := range s.Types {
+			for _, impl := range t.Interfaces {
+				if bytes.Equal(impl.Name, iface.Name) {
+					possibleTypes = append(possibleTypes, string(t.Name))
+				}
+			}
+		}
+
+		i.addType(&introspectionType{
+			kind:          kindInterface,
+			name:          string(iface.Name),
+			description:   string(iface.Description),
+			fields:        newIntrospectionFields(iface.Fields),
+			interfaces:    []string{},
+			possibleTypes: possibleTypes,
+		})
+	}
+
+	for _, u := range s.Unions {
+		possibleTypes := make([]string, 0, len(u.Types))
+		for _, t := range u.Types {
+			possibleTypes = append(possibleTypes, string(t))
+		}
+
+		i.addType(&introspectionType{
+			kind:          kindUnion,
+			name:          string(u.Name),
+			description:   string(u.Description),
+			possibleTypes: possibleTypes,
+		})
+	}
+
+	for _, e := range s.Enums {
+		values := make([]*introspectionEnumValue, 0, len(e.Values))
+		for _, v := range e.Values {
+			isDeprecated, reason := deprecation(v.Directives)
+			values = append(values, &introspectionEnumValue{
+				name:              string(v.Name),
+				description:       string(v.Description),
+				isDeprecated:      isDeprecated,
+				deprecationReason: reason,
+			})
+		}
+
+		i.addType(&introspectionType{
+			kind:        kindEnum,
+			name:        string(e.Name),
+			description: string(e.Description),
+			enumValues:  values,
+		})
+	}
+
+	for _, input := range s.Inputs {
+		inputFields := make([]*introspectionInputValue, 0, len(input.Fields))
+		for _, f := range input.Fields {
+			inputFields = append(inputFields, &introspectionInputValue{
+				name:         string(f.Name),
+				description:  string(f.Description),
+				fieldType:    f.Type,
+				defaultValue: f.Default,
+			})
+		}
+
+		i.addType(&introspectionType{
+			kind:        kindInputObject,
+			name:        string(input.Name),
+			description: string(input.Description),
+			inputFields: inputFields,
+		})
+	}
+
+	for _, scalar := range s.Scalars {
+		i.addType(&introspectionType{
+			kind:           kindScalar,
+			name:           string(scalar.Name),
+			description:    string(scalar.Description),
+			specifiedByURL: specifiedByURL(scalar.Directives),
+		})
+	}
+
+	for _, t := range builtinScalarTypes() {
+		i.addType(t)
+	}
+
+	for _, t := range introspectionTypes() {
+		i.addType(t)
+	}
+
+	return i, nil
+}
+
+func (i *introspector) addType(t *introspectionType) {
+	if _, ok := i.types[t.name]; ok {
+		return
+	}
+
+	i.types[t.name] = t
+	i.typeNames = append(i.typeNames, t.name)
+}
+
+func (i *introspector) rootTypeName(operationType schema.OperationType) string {
+	definition := i.schema.Definition
+	if definition == nil {
+		definition = &schema.SchemaDefinition{}
+	}
+
+	switch operationType {
+	case schema.MutationOperation:
+		if len(definition.Mutation) > 0 {
+			return string(definition.Mutation)
+		}
+		return "Mutation"
+	case schema.SubscriptionOperation:
+		if len(definition.Subscription) > 0 {
+			return string(definition.Subscription)
+		}
+		return "Subscription"
+	default:
+		if len(definition.Query) > 0 {
+			return string(definition.Query)
+		}
+		return "Query"
+	}
+}
+
+func (i *introspector) typeRef(fieldType *schema.FieldType) *introspectionType {
+	if fieldType == nil {
+		return nil
+	}
+
+	if !fieldType.Nullable {
+		nullable := *fieldType
+		nullable.Nullable = true
+
+		return &introspectionType{
+			kind:   kindNonNull,
+			ofType: i.typeRef(&nullable),
+		}
+	}
+
+	if fieldType.IsList {
+		return &introspectionType{
+			kind:   kindList,
+			ofType: i.typeRef(fieldType.ListType),
+		}
+	}
+
+	return i.types[string(fieldType.Name)]
+}
+
+type collectedField struct {
+	key    string
+	fields []*query.Field
+}
+
+func (c *collectedField) selections() []query.Selection {
+	res := make([]query.Selection, 0)
+	for _, f := range c.fields {
+		res = append(res, f.Selections...)
+	}
+
+	return res
+}
+
+func (i *introspector) collectFields(typeName string, selections []query.Selection) ([]*collectedField, error) {
+	res := make([]*collectedField, 0, len(selections))
+	index := make(map[string]*collectedField)
+
+	var collect func(selections []query.Selection) error
+	collect = func(selections []query.Selection) error {
+		for _, sel := range selections {
+			switch s := sel.(type) {
+			case *query.Field:
+				if IsSkipped(s.Directives, i.variables) || !IsIncluded(s.Directives, i.variables) {
+					continue
+				}
+
+				key := string(s.ResponseKey())
+				if c, ok := index[key]; ok {
+					c.fields = append(c.fields, s)
+					continue
+				}
+
+				c := &collectedField{key: key, fields: []*query.Field{s}}
+				index[key] = c
+				res = append(res, c)
+			case *query.InlineFragment:
+				if IsSkipped(s.Directives, i.variables) || !IsIncluded(s.Directives, i.variables) {
+					continue
+				}
+
+				if len(s.TypeCondition) > 0 && string(s.TypeCondition) != typeName {
+					continue
+				}
+
+				if err := collect(s.Selections); err != nil {
+					return err
+				}
+			case *query.FragmentSpread:
+				if IsSkipped(s.Directives, i.variables) || !IsIncluded(s.Directives, i.variables) {
+					continue
+				}
+
+				fd := i.fragments.GetFragment(s.Name)
+				if fd == nil {
+					return fmt.Errorf("fragment %s is not defined", s.Name)
+				}
+
+				if string(fd.BasedTypeName) != typeName {
+					continue
+				}
+
+				if err := collect(fd.Selections); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	if err := collect(selections); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (i *introspector) resolveSchema(selections []query.Selection) (*OrderedMap, error) {
+	collected, err := i.collectFields("__Schema", selections)
+	if err != nil {
+		return nil, err
+	}
+
+	res := NewOrderedMap()
+	for _, c := range collected {
+		f := c.fields[0]
+		switch string(f.Name) {
+		case "__typename":
+			res.Set(c.key, "__Schema")
+		case "description":
+			res.Set(c.key, nil)
+		case "types":
+			types := make([]any, 0, len(i.typeNames))
+			for _, name := range i.typeNames {
+				v, err := i.resolveType(i.types[name], c.selections())
+				if err != nil {
+					return nil, err
+				}
+				types = append(types, v)
+			}
+			res.Set(c.key, types)
+		case "queryType":
+			v, err := i.resolveRootType(schema.QueryOperation, c.selections())
+			if err != nil {
+				return nil, err
+			}
+			res.Set(c.key, v)
+		case "mutationType":
+			v, err := i.resolveRootType(schema.MutationOperation, c.selections())
+			if err != nil {
+				return nil, err
+			}
+			res.Set(c.key, v)
+		case "subscriptionType":
+			v, err := i.resolveRootType(schema.SubscriptionOperation, c.selections())
+			if err != nil {
+				return nil, err
+			}
+			res.Set(c.key, v)
+		case "directives":
+			directives := make([]any, 0, len(i.schema.Directives))
+			for _, d := range i.schema.Directives {
+				v, err := i.resolveDirective(d, c.selections())
+				if err != nil {
+					return nil, err
+				}
+				directives = append(directives, v)
+			}
+			res.Set(c.key, directives)
+		default:
+			return nil, fmt.Errorf("field %s is not defined on __Schema", f.Name)
+		}
+	}
+
+	return res, nil
+}
+
+func (i *introspector) resolveRootType(operationType schema.OperationType, selections []query.Selection) (any, error) {
+	for _, op := range i.schema.Operations {
+		if op.OperationType == operationType {
+			return i.resolveType(i.types[i.rootTypeName(operationType)], selections)
+		}
+	}
+
+	return nil, nil
+}
+
+func (i *introspector) resolveType(t *introspectionType, selections []query.Selection) (any, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	collected, err := i.collectFields("__Type", selections)
+	if err != nil {
+		return nil, err
+	}
+
+	res := NewOrderedMap()
+	for _, c := range collected {
+		f := c.fields[0]
+		switch string(f.Name) {
+		case "__typename":
+			res.Set(c.key, "__Type")
+		case "kind":
+			res.Set(c.key, t.kind)
+		case "name":
+			res.Set(c.key, nullableString(t.name))
+		case "description":
+			res.Set(c.key, nullableString(t.description))
+		case "specifiedByURL":
+			res.Set(c.key, nullableString(t.specifiedByURL))
+		case "fields":
+			if t.kind != kindObject && t.kind != kindInterface {
+				res.Set(c.key, nil)
+				continue
+			}
+
+			includeDeprecated := i.boolArgument(f, "includeDeprecated")
+			fields := make([]any, 0, len(t.fields))
+			for _, field := range t.fields {
+				if field.isDeprecated && !includeDeprecated {
+					continue
+				}
+
+				v, err := i.resolveField(field, c.selections())
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, v)
+			}
+			res.Set(c.key, fields)
+		case "interfaces":
+			if t.kind != kindObject && t.kind != kindInterface {
+				res.Set(c.key, nil)
+				continue
+			}
+
+			v, err := i.resolveTypeNames(t.interfaces, c.selections())
+			if err != nil {
+				return nil, err
+			}
+			res.Set(c.key, v)
+		case "possibleTypes":
+			if t.kind != kindInterface && t.kind != kindUnion {
+				res.Set(c.key, nil)
+				continue
+			}
+
+			v, err := i.resolveTypeNames(t.possibleTypes, c.selections())
+			if err != nil {
+				return nil, err
+			}
+			res.Set(c.key, v)
+		case "enumValues":
+			if t.kind != kindEnum {
+				res.Set(c.key, nil)
+				continue
+			}
+
+			includeDeprecated := i.boolArgument(f, "includeDeprecated")
+			values := make([]any, 0, len(t.enumValues))
+			for _, value := range t.enumValues {
+				if value.isDeprecated && !includeDeprecated {
+					continue
+				}
+
+				v, err := i.resolveEnumValue(value, c.selections())
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, v)
+			}
+			res.Set(c.key, values)
+		case "inputFields":
+			if t.kind != kindInputObject {
+				res.Set(c.key, nil)
+				continue
+			}
+
+			v, err := i.resolveInputValues(t.inputFields, c.selections())
+			if err != nil {
+				return nil, err
+			}
+			res.Set(c.key, v)
+		case "ofType":
+			v, err := i.resolveType(t.ofType, c.selections())
+			if err != nil {
+				return nil, err
+			}
+			res.Set(c.key, v)
+		default:
+			return nil, fmt.Errorf("field %s is not defined on __Type", f.Name)
+		}
+	}
+
+	return res, nil
+}
+
+func (i *introspector) resolveTypeNames(names []string, selections []query.Selection) ([]any, error) {
+	res := make([]any, 0, len(names))
+	for _, name := range names {
+		v, err := i.resolveType(i.types[name], selections)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+
+	return res, nil
+}
+
+func (i *introspector) resolveField(field *introspectionField, selections []query.Selection) (*OrderedMap, error) {
+	collected, err := i.collectFields("__Field", selections)
+	if err != nil {
+		return nil, err
+	}
+
+	res := NewOrderedMap()
+	for _, c := range collected {
+		f := c.fields[0]
+		switch string(f.Name) {
+		case "__typename":
+			res.Set(c.key, "__Field")
+		case "name":
+			res.Set(c.key, field.name)
+		case "description":
+			res.Set(c.key, nullableString(field.description))
+		case "args":
+			v, err := i.resolveInputValues(field.args, c.selections())
+			if err != nil {
+				return nil, err
+			}
+			res.Set(c.key, v)
+		case "type":
+			v, err := i.resolveType(i.typeRef(field.fieldType), c.selections())
+			if err != nil {
+				return nil, err
+			}
+			res.Set(c.key, v)
+		case "isDeprecated":
+			res.Set(c.key, field.isDeprecated)
+		case "deprecationReason":
+			res.Set(c.key, nullableString(field.deprecationReason))
+		default:
+			return nil, fmt.Errorf("field %s is not defined on __Field", f.Name)
+		}
+	}
+
+	return res, nil
+}
+
+func (i *introspector) resolveInputValues(values []*introspectionInputValue, selections []query.Selection) ([]any, error) {
+	collected, err := i.collectFields("__InputValue", selections)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]any, 0, len(values))
+	for _, value := range values {
+		m := NewOrderedMap()
+		for _, c := range collected {
+			f := c.fields[0]
+			switch string(f.Name) {
+			case "__typename":
+				m.Set(c.key, "__InputValue")
+			case "name":
+				m.Set(c.key, value.name)
+			case "description":
+				m.Set(c.key, nullableString(value.description))
+			case "type":
+				v, err := i.resolveType(i.typeRef(value.fieldType), c.selections())
+				if err != nil {
+					return nil, err
+				}
+				m.Set(c.key, v)
+			case "defaultValue":
+				if value.defaultValue == nil {
+					m.Set(c.key, nil)
+				} else {
+					m.Set(c.key, value.defaultValue.String())
+				}
+			case "isDeprecated":
+				m.Set(c.key, false)
+			case "deprecationReason":
+				m.Set(c.key, nil)
+			default:
+				return nil, fmt.Errorf("field %s is not defined on __InputValue", f.Name)
+			}
+		}
+		res = append(res, m)
+	}
+
+	return res, nil
+}
+
+func (i *introspector) resolveEnumValue(value *introspectionEnumValue, selections []query.Selection) (*OrderedMap, error) {
+	collected, err := i.collectFields("__EnumValue", selections)
+	if err != nil {
+		return nil, err
+	}
+
+	res := NewOrderedMap()
+	for _, c := range collected {
+		f := c.fields[0]
+		switch string(f.Name) {
+		case "__typename":
+			res.Set(c.key, "__EnumValue")
+		case "name":
+			res.Set(c.key, value.name)
+		case "description":
+			res.Set(c.key, nullableString(value.description))
+		case "isDeprecated":
+			res.Set(c.key, value.isDeprecated)
+		case "deprecationReason":
+			res.Set(c.key, nullableString(value.deprecationReason))
+		default:
+			return nil, fmt.Errorf("field %s is not defined on __EnumValue", f.Name)
+		}
+	}
+
+	return res, nil
+}
+
+func (i *introspector) resolveDirective(directive *schema.DirectiveDefinition, selections []query.Selection) (*OrderedMap, error) {
+	collected, err := i.collectFields("__Directive", selections)
+	if err != nil {
+		return nil, err
+	}
+
+	res := NewOrderedMap()
+	for _, c := range collected {
+		f := c.fields[0]
+		switch string(f.Name) {
+		case "__typename":
+			res.Set(c.key, "__Directive")
+		case "name":
+			res.Set(c.key, string(directive.Name))
+		case "description":
+			res.Set(c.key, nullableString(string(directive.Description)))
+		case "locations":
+			locations := make([]string, 0, len(directive.Locations))
+			for _, l := range directive.Locations {
+				locations = append(locations, string(l.Name))
+			}
+			res.Set(c.key, locations)
+		case "args":
+			v, err := i.resolveInputValues(newIntrospectionInputValues(directive.Arguments), c.selections())
+			if err != nil {
+				return nil, err
+			}
+			res.Set(c.key, v)
+		case "isRepeatable":
+			res.Set(c.key, directive.Repeatable)
+		default:
+			return nil, fmt.Errorf("field %s is not defined on __Directive", f.Name)
+		}
+	}
+
+	return res, nil
+}
+
+func (i *introspector) argumentValue(f *query.Field, name string) json.RawMessage {
+	for _, arg := range f.Arguments {
+		if string(arg.Name) != name {
+			continue
+		}
+
+		variables := make(map[string]json.RawMessage)
+		if len(i.variables) > 0 {
+			if err := json.Unmarshal(i.variables, &variables); err != nil {
+				return nil
+			}
+		}
+
+		value, _ := valueToJSON(arg.Value, variables)
+		return value
+	}
+
+	return nil
+}
+
+func (i *introspector) boolArgument(f *query.Field, name string) bool {
+	var v bool
+	if err := json.Unmarshal(i.argumentValue(f, name), &v); err != nil {
+		return false
+	}
+
+	return v
+}
+
+func (i *introspector) stringArgument(f *query.Field, name string) string {
+	var v string
+	if err := json.Unmarshal(i.argumentValue(f, name), &v); err != nil {
+		return ""
+	}
+
+	return v
+}
+
+func newIntrospectionFields(fields schema.FieldDefinitions) []*introspectionField {
+	res := make([]*introspectionField, 0, len(fields))
+	for _, f := range fields {
+		if bytes.HasPrefix(f.Name, []byte("__")) {
+			continue
+		}
+
+		isDeprecated, reason := deprecation(f.Directives)
+		res = append(res, &introspectionField{
+			name:              string(f.Name),
+			description:       string(f.Description),
+			args:              newIntrospectionInputValues(f.Arguments),
+			fieldType:         f.Type,
+			isDeprecated:      isDeprecated,
+			deprecationReason: reason,
+		})
+	}
+
+	return res
+}
+
+func newIntrospectionInputValues(args []*schema.ArgumentDefinition) []*introspectionInputValue {
+	res := make([]*introspectionInputValue, 0, len(args))
+	for _, arg := range args {
+		res = append(res, &introspectionInputValue{
+			name:         string(arg.Name),
+			description:  string(arg.Description),
+			fieldType:    arg.Type,
+			defaultValue: arg.Default,
+		})
+	}
+
+	return res
+}
+
+func deprecation(directives []*schema.Directive) (bool, string) {
+	for _, d := range directives {
+		if string(d.Name) != "deprecated" {
+			continue
+		}
+
+		for _, arg := range d.Arguments {
+			if string(arg.Name) == "reason" {
+				return true, stringLiteral(arg.Value)
+			}
+		}
+
+		return true, "No longer supported"
+	}
+
+	return false, ""
+}
+
+func specifiedByURL(directives []*schema.Directive) string {
+	for _, d := range directives {
+		if string(d.Name) != "specifiedBy" {
+			continue
+		}
+
+		for _, arg := range d.Arguments {
+			if string(arg.Name) == "url" {
+				return stringLiteral(arg.Value)
+			}
+		}
+	}
+
+	return ""
+}
+
+func stringLiteral(value query.InputValue) string {
+	if v, ok := value.(*query.StringValue); ok {
+		return string(v.Value)
+	}
+
+	return ""
+}
+
+func nullableString(s string) any {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
+
+func namedType(name string) *schema.FieldType {
+	return &schema.FieldType{Name: []byte(name), Nullable: true}
+}
+
+func nonNullType(t *schema.FieldType) *schema.FieldType {
+	nonNull := *t
+	nonNull.Nullable = false
+	return &nonNull
+}
+
+func listType(t *schema.FieldType) *schema.FieldType {
+	return &schema.FieldType{IsList: true, ListType: t, Nullable: true}
+}
+
+func builtinScalarTypes() []*introspectionType {
+	return []*introspectionType{
+		{
+			kind:        kindScalar,
+			name:        "String",
+			description: "The `String` scalar type represents textual data, represented as UTF-8 character sequences.",
+		},
+		{
+			kind:        kindScalar,
+			name:        "Int",
+			description: "The `Int` scalar type represents non-fractional signed whole numeric values.",
+		},
+		{
+			kind:        kindScalar,
+			name:        "Float",
+			description: "The `Float` scalar type represents signed double-precision fractional values.",
+		},
+		{
+			kind:        kindScalar,
+			name:        "Boolean",
+			description: "The `Boolean` scalar type represents `true` or `false`.",
+		},
+		{
+			kind:        kindScalar,
+			name:        "ID",
+			description: "The `ID` scalar type represents a unique identifier.",
+		},
+	}
+}
+
+func introspectionTypes() []*introspectionType {
+	field := func(name string, fieldType *schema.FieldType, args ...*introspectionInputValue) *introspectionField {
+		return &introspectionField{name: name, fieldType: fieldType, args: args}
+	}
+
+	includeDeprecated := &introspectionInputValue{
+		name:         "includeDeprecated",
+		fieldType:    namedType("Boolean"),
+		defaultValue: &query.BooleanValue{Value: false},
+	}
+
+	enumValues := func(names ...string) []*introspectionEnumValue {
+		res := make([]*introspectionEnumValue, 0, len(names))
+		for _, name := range names {
+			res = append(res, &introspectionEnumValue{name: name})
+		}
+
+		return res
+	}
+
+	return []*introspectionType{
+		{
+			kind: kindObject,
+			name: "__Schema",
+			fields: []*introspectionField{
+				field("description", namedType("String")),
+				field("types", nonNullType(listType(nonNullType(namedType("__Type"))))),
+				field("queryType", nonNullType(namedType("__Type"))),
+				field("mutationType", namedType("__Type")),
+				field("subscriptionType", namedType("__Type")),
+				field("directives", nonNullType(listType(nonNullType(namedType("__Directive"))))),
+			},
+			interfaces: []string{},
+		},
+		{
+			kind: kindObject,
+			name: "__Type",
+			fields: []*introspectionField{
+				field("kind", nonNullType(namedType("__TypeKind"))),
+				field("name", namedType("String")),
+				field("description", namedType("String")),
+				field("specifiedByURL", namedType("String")),
+				field("fields", listType(nonNullType(namedType("__Field"))), includeDeprecated),
+				field("interfaces", listType(nonNullType(namedType("__Type")))),
+				field("possibleTypes", listType(nonNullType(namedType("__Type")))),
+				field("enumValues", listType(nonNullType(namedType("__EnumValue"))), includeDeprecated),
+				field("inputFields", listType(nonNullType(namedType("__InputValue")))),
+				field("ofType", namedType("__Type")),
+			},
+			interfaces: []string{},
+		},
+		{
+			kind: kindObject,
+			name: "__Field",
+			fields: []*introspectionField{
+				field("name", nonNullType(namedType("String"))),
+				field("description", namedType("String")),
+				field("args", nonNullType(listType(nonNullType(namedType("__InputValue"))))),
+				field("type", nonNullType(namedType("__Type"))),
+				field("isDeprecated", nonNullType(namedType("Boolean"))),
+				field("deprecationReason", namedType("String")),
+			},
+			interfaces: []string{},
+		},
+		{
+			kind: kindObject,
+			name: "__InputValue",
+			fields: []*introspectionField{
+				field("name", nonNullType(namedType("String"))),
+				field("description", namedType("String")),
+				field("type", nonNullType(namedType("__Type"))),
+				field("defaultValue", namedType("String")),
+			},
+			interfaces: []string{},
+		},
+		{
+			kind: kindObject,
+			name: "__EnumValue",
+			fields: []*introspectionField{
+				field("name", nonNullType(namedType("String"))),
+				field("description", namedType("String")),
+				field("isDeprecated", nonNullType(namedType("Boolean"))),
+				field("deprecationReason", namedType("String")),
+			},
+			interfaces: []string{},
+		},
+		{
+			kind: kindObject,
+			name: "__Directive",
+			fields: []*introspectionField{
+				field("name", nonNullType(namedType("String"))),
+				field("description", namedType("String")),
+				field("locations", nonNullType(listType(nonNullType(namedType("__DirectiveLocation"))))),
+				field("args", nonNullType(listType(nonNullType(namedType("__InputValue"))))),
+				field("isRepeatable", nonNullType(namedType("Boolean"))),
+			},
+			interfaces: []string{},
+		},
+		{
+			kind:       kindEnum,
+			name:       "__TypeKind",
+			enumValues: enumValues(kindScalar, kindObject, kindInterface, kindUnion, kindEnum, kindInputObject, kindList, kindNonNull),
+		},
+		{
+			kind: kindEnum,
+			name: "__DirectiveLocation",
+			enumValues: enumValues(
+				"QUERY", "MUTATION", "SUBSCRIPTION", "FIELD", "FRAGMENT_DEFINITION", "FRAGMENT_SPREAD", "INLINE_FRAGMENT", "VARIABLE_DEFINITION",
+				"SCHEMA", "SCALAR", "OBJECT", "FIELD_DEFINITION", "ARGUMENT_DEFINITION", "INTERFACE", "UNION", "ENUM", "ENUM_VALUE", "INPUT_OBJECT", "INPUT_FIELD_DEFINITION",
+			),
+		},
+	}
+}
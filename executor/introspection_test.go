@@ -0,0 +1,185 @@
+package executor_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/schema"
+)
+
+func TestIntrospect(t *testing.T) {
+	sdl := []byte(`type Query {
+	"Finds a post by its ID"
+	post("ID of the post" id: ID!): Post
+	posts: [Post!]!
+}
+
+type Mutation {
+	createPost(title: String!): Post!
+}
+
+interface Node {
+	id: ID!
+}
+
+type Post implements Node {
+	id: ID!
+	title: String!
+	legacyTitle: String @deprecated(reason: "use title")
+}
+
+union SearchResult = Post
+
+"""
+Status of a post
+"""
+enum Status {
+	"Not published yet"
+	DRAFT
+	PUBLISHED
+}
+
+input NewPost {
+	title: String!
+	status: Status = DRAFT
+}
+`)
+
+	tests := []struct {
+		name      string
+		query     []byte
+		variables json.RawMessage
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:  "__typename on root",
+			query: []byte(`query { __typename }`),
+			want:  `{"__typename":"Query"}`,
+		},
+		{
+			name:  "__type with fields and wrapped types",
+			query: []byte(`query { __type(name: "Post") { kind name interfaces { name } fields { name type { kind name ofType { kind name } } } } }`),
+			want:  `{"__type":{"kind":"OBJECT","name":"Post","interfaces":[{"name":"Node"}],"fields":[{"name":"id","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"ID"}}},{"name":"title","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String"}}}]}}`,
+		},
+		{
+			name:      "__type with includeDeprecated variable",
+			query:     []byte(`query ($includeDeprecated: Boolean) { __type(name: "Post") { fields(includeDeprecated: $includeDeprecated) { name isDeprecated deprecationReason } } }`),
+			variables: json.RawMessage(`{"includeDeprecated": true}`),
+			want:      `{"__type":{"fields":[{"name":"id","isDeprecated":false,"deprecationReason":null},{"name":"title","isDeprecated":false,"deprecationReason":null},{"name":"legacyTitle","isDeprecated":true,"deprecationReason":"use title"}]}}`,
+		},
+		{
+			name:  "__type of unknown type",
+			query: []byte(`query { __type(name: "Unknown") { name } }`),
+			want:  `{"__type":null}`,
+		},
+		{
+			name:  "__type of enum",
+			query: []byte(`query { __type(name: "Status") { enumValues { name } } }`),
+			want:  `{"__type":{"enumValues":[{"name":"DRAFT"},{"name":"PUBLISHED"}]}}`,
+		},
+		{
+			name:  "__type with descriptions",
+			query: []byte(`query { __type(name: "Status") { description enumValues { name description } } }`),
+			want:  `{"__type":{"description":"Status of a post","enumValues":[{"name":"DRAFT","description":"Not published yet"},{"name":"PUBLISHED","description":null}]}}`,
+		},
+		{
+			name:  "__type with descriptions of fields and arguments",
+			query: []byte(`query { __type(name: "Query") { fields { name description args { name description } } } }`),
+			want:  `{"__type":{"fields":[{"name":"post","description":"Finds a post by its ID","args":[{"name":"id","description":"ID of the post"}]},{"name":"posts","description":null,"args":[]}]}}`,
+		},
+		{
+			name:  "__schema root types",
+			query: []byte(`query { __schema { queryType { name } mutationType { name } subscriptionType { name } } }`),
+			want:  `{"__schema":{"queryType":{"name":"Query"},"mutationType":{"name":"Mutation"},"subscriptionType":null}}`,
+		},
+		{
+			name:  "__schema with fragments",
+			query: []byte(`query { __schema { types { ...TypeRef } } } fragment TypeRef on __Type { name }`),
+			want:  `{"__schema":{"types":[{"name":"Query"},{"name":"Mutation"},{"name":"Post"},{"name":"Node"},{"name":"SearchResult"},{"name":"Status"},{"name":"NewPost"},{"name":"String"},{"name":"Int"},{"name":"Float"},{"name":"Boolean"},{"name":"ID"},{"name":"__Schema"},{"name":"__Type"},{"name":"__Field"},{"name":"__InputValue"},{"name":"__EnumValue"},{"name":"__Directive"},{"name":"__TypeKind"},{"name":"__DirectiveLocation"}]}}`,
+		},
+		{
+			name:  "__type with possibleTypes and inputFields",
+			query: []byte(`query { __type(name: "Node") { possibleTypes { name } inputFields { name } } }`),
+			want:  `{"__type":{"possibleTypes":[{"name":"Post"}],"inputFields":null}}`,
+		},
+		{
+			name:  "__type of input with default value",
+			query: []byte(`query { __type(name: "NewPost") { inputFields { name defaultValue } } }`),
+			want:  `{"__type":{"inputFields":[{"name":"title","defaultValue":null},{"name":"status","defaultValue":"DRAFT"}]}}`,
+		},
+		{
+			name:    "unknown field on __Type",
+			query:   []byte(`query { __type(name: "Post") { unknown } }`),
+			wantErr: true,
+		},
+	}
+
+	s := schema.MustParse(sdl)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := query.NewParser(query.NewLexer()).Parse(tt.query)
+			if err != nil {
+				t.Fatalf("failed to parse query: %v", err)
+			}
+
+			op := doc.Operations.GetQuery()
+			if !executor.IsIntrospectionQuery(op) {
+				t.Fatalf("expected introspection query")
+			}
+
+			got, err := executor.Introspect(s, op, doc.FragmentDefinitions, tt.variables)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Introspect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("failed to marshal result: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(b)); diff != "" {
+				t.Errorf("Introspect() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsIntrospectionQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query []byte
+		want  bool
+	}{
+		{
+			name:  "introspection fields only",
+			query: []byte(`query { __schema { types { name } } __typename }`),
+			want:  true,
+		},
+		{
+			name:  "mixed with schema fields",
+			query: []byte(`query { __typename posts { id } }`),
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := query.NewParser(query.NewLexer()).Parse(tt.query)
+			if err != nil {
+				t.Fatalf("failed to parse query: %v", err)
+			}
+
+			if got := executor.IsIntrospectionQuery(doc.Operations.GetQuery()); got != tt.want {
+				t.Errorf("IsIntrospectionQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,58 +1,169 @@
 package executor
 
 import (
+	"encoding/json"
 
 	"github.com/n9te9/goliteql/query"
 )
 
 type Node struct {
+	Alias      []byte
 	Name       []byte
+	Arguments  []*query.Argument
 	SelectSets []query.Selection
 	Directives []*query.Directive
 	Children   []*Node
+	Loc        *query.Loc
 }
 
-func PlanExecution(selections []query.Selection) *Node {
+// ResponseKey returns the key of the field planned by the node in a response, which is its alias if it has one.
+func (n *Node) ResponseKey() []byte {
+	if len(n.Alias) > 0 {
+		return n.Alias
+	}
+
+	return n.Name
+}
+
+// PlanExecution plans the execution of the first root field in selections.
+// Fragment spreads are expanded with fragments into inline fragments, whose type conditions are
+// applied by CollectFields when the response is walked, and fields and fragments excluded by
+// @skip or @include with variables are dropped from the plan.
+func PlanExecution(selections []query.Selection, fragments query.FragmentDefinitions, variables json.RawMessage) *Node {
+	nodes := PlanRootFields(selections, fragments, variables)
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	return nodes[0]
+}
+
+// PlanRootFields plans the execution of every root field in selections in the order they appear,
+// as PlanExecution does for the first one.
+// Root fields selected more than once with the same response key are merged into one node.
+func PlanRootFields(selections []query.Selection, fragments query.FragmentDefinitions, variables json.RawMessage) []*Node {
+	fields := planFields(ExpandFragments(selections, fragments), variables)
+
+	merged := make([]*query.Field, 0, len(fields))
+	index := make(map[string]int)
+	for _, f := range fields {
+		key := string(f.ResponseKey())
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(merged)
+			merged = append(merged, f)
+			continue
+		}
+
+		field := *merged[i]
+		field.Selections = append(append(make([]query.Selection, 0, len(field.Selections)+len(f.Selections)), field.Selections...), f.Selections...)
+		merged[i] = &field
+	}
+
+	nodes := make([]*Node, 0, len(merged))
+	for _, s := range merged {
+		node := &Node{
+			Alias:      s.Alias,
+			Name:       s.Name,
+			Arguments:  s.Arguments,
+			SelectSets: s.Selections,
+			Directives: s.Directives,
+			Children:   make([]*Node, 0),
+			Loc:        s.Loc,
+		}
+
+		for _, c := range planFields(s.Selections, variables) {
+			node.Children = append(node.Children, digExecution(c, variables))
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
+
+func digExecution(s *query.Field, variables json.RawMessage) *Node {
+	node := &Node{
+		Alias:      s.Alias,
+		Name:       s.Name,
+		Arguments:  s.Arguments,
+		SelectSets: s.Selections,
+		Directives: s.Directives,
+		Loc:        s.Loc,
+	}
+	for _, c := range planFields(s.Selections, variables) {
+		node.Children = append(node.Children, digExecution(c, variables))
+	}
+
+	return node
+}
+
+// planFields returns the fields in selections, including the fields in inline fragments of any type condition.
+func planFields(selections []query.Selection, variables json.RawMessage) []*query.Field {
+	res := make([]*query.Field, 0, len(selections))
 	for _, sel := range selections {
 		switch s := sel.(type) {
 		case *query.Field:
-			node := &Node{
-				Name:       s.Name,
-				SelectSets: s.Selections,
-				Directives: s.Directives,
-				Children:   make([]*Node, 0),
+			if IsSkipped(s.Directives, variables) || !IsIncluded(s.Directives, variables) {
+				continue
 			}
 
-			for _, child := range s.Selections {
-				switch c := child.(type) {
-				case *query.Field:
-					node.Children = append(node.Children, digExecution(c))
-				}
+			res = append(res, s)
+		case *query.InlineFragment:
+			if IsSkipped(s.Directives, variables) || !IsIncluded(s.Directives, variables) {
+				continue
 			}
 
-			return node
+			res = append(res, planFields(s.Selections, variables)...)
 		}
 	}
 
-	return nil
+	return res
 }
 
-func digExecution(selectSet query.Selection) *Node {
-	switch s := selectSet.(type) {
-	case *query.Field:
-		node := &Node{
-			Name:       s.Name,
-			SelectSets: s.Selections,
-			Directives: s.Directives,
-		}
-		for _, child := range s.Selections {
-			switch c := child.(type) {
-			case *query.Field:
-				node.Children = append(node.Children, digExecution(c))
+// ExpandFragments replaces every fragment spread in selections with an inline fragment
+// which has the type condition and the selections of the fragment definition and the directives of the spread.
+// Spreads of undefined fragments and spreads of a fragment inside itself are dropped,
+// as they are reported by the validator.
+func ExpandFragments(selections []query.Selection, fragments query.FragmentDefinitions) []query.Selection {
+	return expandFragments(selections, fragments, make(map[string]struct{}))
+}
+
+func expandFragments(selections []query.Selection, fragments query.FragmentDefinitions, expanding map[string]struct{}) []query.Selection {
+	if selections == nil {
+		return nil
+	}
+
+	res := make([]query.Selection, 0, len(selections))
+	for _, sel := range selections {
+		switch s := sel.(type) {
+		case *query.Field:
+			field := *s
+			field.Selections = expandFragments(s.Selections, fragments, expanding)
+			res = append(res, &field)
+		case *query.InlineFragment:
+			fragment := *s
+			fragment.Selections = expandFragments(s.Selections, fragments, expanding)
+			res = append(res, &fragment)
+		case *query.FragmentSpread:
+			fd := fragments.GetFragment(s.Name)
+			if fd == nil {
+				continue
 			}
+
+			if _, ok := expanding[string(s.Name)]; ok {
+				continue
+			}
+
+			expanding[string(s.Name)] = struct{}{}
+			res = append(res, &query.InlineFragment{
+				TypeCondition: fd.BasedTypeName,
+				Selections:    expandFragments(fd.Selections, fragments, expanding),
+				Directives:    s.Directives,
+			})
+			delete(expanding, string(s.Name))
 		}
-		return node
 	}
 
-	return nil
+	return res
 }
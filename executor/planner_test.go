@@ -1,6 +1,7 @@
 package executor_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -13,6 +14,8 @@ func TestPlanExecution(t *testing.T) {
 	tests := []struct {
 		name         string
 		input        []query.Selection
+		fragments    query.FragmentDefinitions
+		variables    json.RawMessage
 		resultTree   *executor.Node
 		expectedName []byte
 	}{
@@ -115,11 +118,119 @@ func TestPlanExecution(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Fragment spreads are expanded into inline fragments",
+			input: []query.Selection{
+				&query.Field{
+					Name: []byte("posts"),
+					Selections: []query.Selection{
+						&query.FragmentSpread{
+							Name: []byte("PostFields"),
+							Directives: []*query.Directive{
+								{
+									Name: []byte("include"),
+									Arguments: []*query.DirectiveArgument{
+										{Name: []byte("if"), Value: &query.VariableValue{Name: []byte("withPost")}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			fragments: query.FragmentDefinitions{
+				{
+					Name:          []byte("PostFields"),
+					BasedTypeName: []byte("Post"),
+					Selections: []query.Selection{
+						&query.Field{Name: []byte("id")},
+					},
+				},
+			},
+			variables:    json.RawMessage(`{"withPost": true}`),
+			expectedName: []byte("posts"),
+			resultTree: &executor.Node{
+				Name: []byte("posts"),
+				SelectSets: []query.Selection{
+					&query.InlineFragment{
+						TypeCondition: []byte("Post"),
+						Directives: []*query.Directive{
+							{
+								Name: []byte("include"),
+								Arguments: []*query.DirectiveArgument{
+									{Name: []byte("if"), Value: &query.VariableValue{Name: []byte("withPost")}},
+								},
+							},
+						},
+						Selections: []query.Selection{
+							&query.Field{Name: []byte("id")},
+						},
+					},
+				},
+				Children: []*executor.Node{
+					{
+						Name: []byte("id"),
+					},
+				},
+			},
+		},
+		{
+			name: "Fragments excluded by directives are not planned",
+			input: []query.Selection{
+				&query.Field{
+					Name: []byte("posts"),
+					Selections: []query.Selection{
+						&query.FragmentSpread{
+							Name: []byte("PostFields"),
+							Directives: []*query.Directive{
+								{
+									Name: []byte("include"),
+									Arguments: []*query.DirectiveArgument{
+										{Name: []byte("if"), Value: &query.VariableValue{Name: []byte("withPost")}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			fragments: query.FragmentDefinitions{
+				{
+					Name:          []byte("PostFields"),
+					BasedTypeName: []byte("Post"),
+					Selections: []query.Selection{
+						&query.Field{Name: []byte("id")},
+					},
+				},
+			},
+			variables:    json.RawMessage(`{"withPost": false}`),
+			expectedName: []byte("posts"),
+			resultTree: &executor.Node{
+				Name: []byte("posts"),
+				SelectSets: []query.Selection{
+					&query.InlineFragment{
+						TypeCondition: []byte("Post"),
+						Directives: []*query.Directive{
+							{
+								Name: []byte("include"),
+								Arguments: []*query.DirectiveArgument{
+									{Name: []byte("if"), Value: &query.VariableValue{Name: []byte("withPost")}},
+								},
+							},
+						},
+						Selections: []query.Selection{
+							&query.Field{Name: []byte("id")},
+						},
+					},
+				},
+				Children: []*executor.Node{},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := executor.PlanExecution(tt.input)
+			result := executor.PlanExecution(tt.input, tt.fragments, tt.variables)
 			if result == nil {
 				t.Errorf("PlanExecution() returned nil")
 				return
@@ -131,3 +242,82 @@ func TestPlanExecution(t *testing.T) {
 		})
 	}
 }
+
+func TestPlanRootFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []query.Selection
+		fragments query.FragmentDefinitions
+		variables json.RawMessage
+		want      []*executor.Node
+	}{
+		{
+			name: "every root field is planned in order",
+			input: []query.Selection{
+				&query.Field{
+					Name:       []byte("posts"),
+					Selections: []query.Selection{&query.Field{Name: []byte("id")}},
+				},
+				&query.Field{
+					Alias:      []byte("viewer"),
+					Name:       []byte("me"),
+					Selections: []query.Selection{&query.Field{Name: []byte("name")}},
+				},
+			},
+			want: []*executor.Node{
+				{
+					Name:       []byte("posts"),
+					SelectSets: []query.Selection{&query.Field{Name: []byte("id")}},
+					Children:   []*executor.Node{{Name: []byte("id")}},
+				},
+				{
+					Alias:      []byte("viewer"),
+					Name:       []byte("me"),
+					SelectSets: []query.Selection{&query.Field{Name: []byte("name")}},
+					Children:   []*executor.Node{{Name: []byte("name")}},
+				},
+			},
+		},
+		{
+			name: "root fields with the same response key are merged",
+			input: []query.Selection{
+				&query.Field{
+					Name:       []byte("posts"),
+					Selections: []query.Selection{&query.Field{Name: []byte("id")}},
+				},
+				&query.FragmentSpread{Name: []byte("RootFields")},
+			},
+			fragments: query.FragmentDefinitions{
+				{
+					Name:          []byte("RootFields"),
+					BasedTypeName: []byte("Query"),
+					Selections: []query.Selection{
+						&query.Field{
+							Name:       []byte("posts"),
+							Selections: []query.Selection{&query.Field{Name: []byte("title")}},
+						},
+					},
+				},
+			},
+			want: []*executor.Node{
+				{
+					Name: []byte("posts"),
+					SelectSets: []query.Selection{
+						&query.Field{Name: []byte("id")},
+						&query.Field{Name: []byte("title")},
+					},
+					Children: []*executor.Node{{Name: []byte("id")}, {Name: []byte("title")}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := executor.PlanRootFields(tt.input, tt.fragments, tt.variables)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("PlanRootFields() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
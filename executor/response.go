@@ -1,46 +1,41 @@
 package executor
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"net/http"
 
 	"github.com/n9te9/goliteql/query"
 )
 
+// ExcludeSelectFields returns the values of resp selected by selectSets,
+// keyed by the response keys of the fields, which are their aliases if they have one.
 func ExcludeSelectFields(resp map[string]json.RawMessage, selectSets []query.Selection) map[string]json.RawMessage {
-	included := make(map[string]struct{}, len(selectSets))
+	res := make(map[string]json.RawMessage, len(selectSets))
 
 	for _, sel := range selectSets {
 		switch s := sel.(type) {
 		case *query.Field:
-			if _, ok := resp[string(s.Name)]; ok {
-				included[string(s.Name)] = struct{}{}
+			if v, ok := resp[string(s.Name)]; ok {
+				res[string(s.ResponseKey())] = v
 			}
 		}
 	}
 
-	for k := range resp {
-		if _, ok := included[k]; !ok {
-			delete(resp, k)
-		}
-	}
-
-	return resp
+	return res
 }
 
-type GraphQLError struct {
-	Message string `json:"message"`
-	Path 	[]string `json:"path,omitempty"`
-	Extensions map[string]any `json:"extensions,omitempty"`
-}
-
-func (e GraphQLError) Error() string {
-	return e.Message
+type GraphQLResponse struct {
+	Data   any           `json:"data"`
+	Errors GraphQLErrors `json:"errors,omitempty"`
 }
 
-type GraphQLResponse struct {
-	Data   any `json:"data"`
-	Errors []error `json:"errors,omitempty"`
+// RequestErrorResponse is the response of a request which fails before its execution begins,
+// such as a query which can't be parsed or validated, which doesn't have data unlike GraphQLResponse
+// as described in "Response Format" of the specification.
+type RequestErrorResponse struct {
+	Errors GraphQLErrors `json:"errors"`
 }
 
 func  MatchGraphQLResponse[T map[string]json.RawMessage | json.RawMessage | any](resp map[string]T) error {
@@ -57,3 +52,103 @@ func  MatchGraphQLResponse[T map[string]json.RawMessage | json.RawMessage | any]
 
 	return nil
 }
+
+// OrderedMap is a JSON object that keeps the insertion order of its keys,
+// so responses follow the order of the selection set.
+type OrderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+func NewOrderedMap() *OrderedMap {
+	return &OrderedMap{
+		keys:   make([]string, 0),
+		values: make(map[string]any),
+	}
+}
+
+func (m *OrderedMap) Set(key string, value any) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+
+	m.values[key] = value
+}
+
+func (m *OrderedMap) Get(key string) (any, bool) {
+	v, ok := m.values[key]
+	return v, ok
+}
+
+func (m *OrderedMap) Keys() []string {
+	return m.keys
+}
+
+func (m *OrderedMap) Len() int {
+	return len(m.keys)
+}
+
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, 64))
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// ResponseBuffer is an http.ResponseWriter that keeps the response in memory,
+// so a generated writer can be run outside of an HTTP round trip.
+type ResponseBuffer struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func NewResponseBuffer() *ResponseBuffer {
+	return &ResponseBuffer{
+		header:     make(http.Header),
+		statusCode: http.StatusOK,
+	}
+}
+
+func (b *ResponseBuffer) Header() http.Header {
+	return b.header
+}
+
+func (b *ResponseBuffer) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+func (b *ResponseBuffer) WriteHeader(statusCode int) {
+	b.statusCode = statusCode
+}
+
+func (b *ResponseBuffer) StatusCode() int {
+	return b.statusCode
+}
+
+func (b *ResponseBuffer) Bytes() []byte {
+	return b.body.Bytes()
+}
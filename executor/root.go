@@ -0,0 +1,157 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/schema"
+)
+
+// RootFieldResult is the completed value of a root field and its errors,
+// which is merged into the response with the results of the other root fields.
+type RootFieldResult struct {
+	key    string
+	value  any
+	ok     bool
+	errors GraphQLErrors
+}
+
+// CompleteRootField completes the value of the root field at key with complete, where errs are the errors of its resolver
+// relative to the root field. The result nulls the data when the value is null while nonNull is true.
+func CompleteRootField(key string, nonNull bool, loc *query.Loc, errs GraphQLErrors, complete func(errs *FieldErrors, path Path) any) *RootFieldResult {
+	fieldErrors := NewFieldErrors(errs.WithPathPrefix(PathKey(key)))
+	value, ok := fieldErrors.Complete(Path{PathKey(key)}, nonNull, loc, func(path Path) any {
+		return complete(fieldErrors, path)
+	})
+
+	return &RootFieldResult{
+		key:    key,
+		value:  value,
+		ok:     ok,
+		errors: fieldErrors.Errors(),
+	}
+}
+
+// FailRootField returns the result of the root field planned by node which fails with err.
+// The result nulls the data when the field is non-null, as CompleteRootField does for a null value.
+func FailRootField(node *Node, nonNull bool, err error) *RootFieldResult {
+	errs := ToGraphQLErrors(err)
+	for i := range errs {
+		errs[i] = errs[i].WithLocations(node.Loc)
+	}
+
+	return &RootFieldResult{
+		key:    string(node.ResponseKey()),
+		ok:     !nonNull,
+		errors: errs.WithPathPrefix(PathKey(node.ResponseKey())),
+	}
+}
+
+// IntrospectRootField resolves the __schema, __type or __typename root field planned by node
+// in an operation of operationType against s.
+func IntrospectRootField(s *schema.Schema, operationType query.OperationType, node *Node, fragments query.FragmentDefinitions, variables json.RawMessage) *RootFieldResult {
+	data, err := Introspect(s, &query.Operation{
+		OperationType: operationType,
+		Selections: []query.Selection{
+			&query.Field{
+				Alias:      node.Alias,
+				Name:       node.Name,
+				Arguments:  node.Arguments,
+				Selections: node.SelectSets,
+				Loc:        node.Loc,
+			},
+		},
+	}, fragments, variables)
+	if err != nil {
+		// __schema and __typename are non-null, and __type is null for unknown types
+		return FailRootField(node, string(node.Name) != "__type", err)
+	}
+
+	value, _ := data.Get(string(node.ResponseKey()))
+	return &RootFieldResult{
+		key:   string(node.ResponseKey()),
+		value: value,
+		ok:    true,
+	}
+}
+
+// MergeRootFieldResults merges results into one response in their order.
+// The data is null when one of the results nulls it.
+func MergeRootFieldResults(results ...*RootFieldResult) GraphQLResponse {
+	data := NewOrderedMap()
+	nulled := false
+
+	var errs GraphQLErrors
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+
+		errs = append(errs, r.errors...)
+		if !r.ok {
+			nulled = true
+			continue
+		}
+
+		data.Set(r.key, r.value)
+	}
+
+	resp := GraphQLResponse{Errors: errs}
+	if !nulled {
+		resp.Data = data
+	}
+
+	return resp
+}
+
+// ServeRootFields executes every root field planned by nodes with execute, and writes their results merged into one response.
+// Root fields are executed concurrently with the workers of ctx, or serially in the order of nodes if serial is true as mutations require.
+// Every execution writes to a writer of its own, whose headers are copied to w once all of them are done.
+func ServeRootFields(ctx context.Context, w http.ResponseWriter, nodes []*Node, serial bool, execute func(w http.ResponseWriter, node *Node) *RootFieldResult) {
+	results := make([]*RootFieldResult, len(nodes))
+	buffers := make([]*ResponseBuffer, len(nodes))
+	for i := range nodes {
+		buffers[i] = NewResponseBuffer()
+	}
+
+	if serial {
+		for i, node := range nodes {
+			results[i] = execute(buffers[i], node)
+		}
+	} else {
+		g := newWorkerGroup(ctx)
+		for i, node := range nodes {
+			g.Go(func() {
+				results[i] = execute(buffers[i], node)
+			})
+		}
+		g.Wait()
+	}
+
+	for _, b := range buffers {
+		for key, values := range b.Header() {
+			switch http.CanonicalHeaderKey(key) {
+			case "Content-Type", "Content-Length", "X-Content-Type-Options":
+				continue
+			}
+
+			for _, v := range values {
+				w.Header().Add(key, v)
+			}
+		}
+	}
+
+	b, err := json.Marshal(MergeRootFieldResults(results...))
+	if err != nil {
+		// the execution has begun, so the response has null data
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(GraphQLResponse{Errors: GraphQLErrors{Errorf(ErrorCodeInternal, "failed to encode response: %s", err)}})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
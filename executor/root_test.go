@@ -0,0 +1,126 @@
+package executor_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+)
+
+func TestServeRootFields(t *testing.T) {
+	nodes := []*executor.Node{
+		{Name: []byte("posts")},
+		{Alias: []byte("viewer"), Name: []byte("me")},
+		{Name: []byte("version")},
+	}
+
+	tests := []struct {
+		name    string
+		serial  bool
+		results map[string]func(node *executor.Node) *executor.RootFieldResult
+		want    string
+	}{
+		{
+			name: "results are merged in the order of the root fields",
+			results: map[string]func(node *executor.Node) *executor.RootFieldResult{
+				"posts": func(node *executor.Node) *executor.RootFieldResult {
+					return executor.CompleteRootField("posts", true, nil, nil, func(errs *executor.FieldErrors, path executor.Path) any {
+						return []string{"a", "b"}
+					})
+				},
+				"me": func(node *executor.Node) *executor.RootFieldResult {
+					return executor.CompleteRootField("viewer", false, nil, nil, func(errs *executor.FieldErrors, path executor.Path) any {
+						return "name"
+					})
+				},
+				"version": func(node *executor.Node) *executor.RootFieldResult {
+					return executor.FailRootField(node, false, errors.New("version is unknown"))
+				},
+			},
+			want: `{"data":{"posts":["a","b"],"viewer":"name","version":null},"errors":[{"message":"version is unknown","path":["version"]}]}`,
+		},
+		{
+			name:   "a null non-null root field nulls the data",
+			serial: true,
+			results: map[string]func(node *executor.Node) *executor.RootFieldResult{
+				"posts": func(node *executor.Node) *executor.RootFieldResult {
+					return executor.CompleteRootField("posts", true, nil, executor.GraphQLErrors{{Message: "failed"}}, func(errs *executor.FieldErrors, path executor.Path) any {
+						return []string{"a"}
+					})
+				},
+				"me": func(node *executor.Node) *executor.RootFieldResult {
+					return executor.CompleteRootField("viewer", false, nil, nil, func(errs *executor.FieldErrors, path executor.Path) any {
+						return "name"
+					})
+				},
+				"version": func(node *executor.Node) *executor.RootFieldResult {
+					return executor.CompleteRootField("version", false, nil, nil, func(errs *executor.FieldErrors, path executor.Path) any {
+						return 1
+					})
+				},
+			},
+			want: `{"data":null,"errors":[{"message":"failed","path":["posts"]}]}`,
+		},
+		{
+			name: "a non-null root field whose arguments can't be coerced nulls the data",
+			results: map[string]func(node *executor.Node) *executor.RootFieldResult{
+				"posts": func(node *executor.Node) *executor.RootFieldResult {
+					return executor.FailRootField(node, true, executor.Errorf(executor.ErrorCodeBadUserInput, "role BOGUS is not a value of Role"))
+				},
+				"me": func(node *executor.Node) *executor.RootFieldResult {
+					return executor.CompleteRootField("viewer", false, nil, nil, func(errs *executor.FieldErrors, path executor.Path) any {
+						return "name"
+					})
+				},
+				"version": func(node *executor.Node) *executor.RootFieldResult {
+					return executor.FailRootField(node, false, errors.New("version is unknown"))
+				},
+			},
+			want: `{"data":null,"errors":[{"message":"role BOGUS is not a value of Role","path":["posts"],"extensions":{"code":"BAD_USER_INPUT"}},{"message":"version is unknown","path":["version"]}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu    sync.Mutex
+				order []string
+			)
+
+			w := httptest.NewRecorder()
+			executor.ServeRootFields(executor.WithWorkerLimit(context.Background(), executor.DefaultWorkerLimit), w, nodes, tt.serial, func(w http.ResponseWriter, node *executor.Node) *executor.RootFieldResult {
+				mu.Lock()
+				order = append(order, string(node.Name))
+				mu.Unlock()
+
+				w.Header().Add("X-Root-Field", string(node.Name))
+				w.Header().Set("Content-Type", "text/plain")
+
+				return tt.results[string(node.Name)](node)
+			})
+
+			if diff := cmp.Diff(tt.want, w.Body.String()); diff != "" {
+				t.Errorf("response mismatch (-want +got):\n%s", diff)
+			}
+
+			if got := w.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", got)
+			}
+
+			if got := w.Header().Values("X-Root-Field"); len(got) != len(nodes) {
+				t.Errorf("X-Root-Field = %v, want the headers of every root field", got)
+			}
+
+			if tt.serial {
+				if diff := cmp.Diff([]string{"posts", "me", "version"}, order); diff != "" {
+					t.Errorf("execution order mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
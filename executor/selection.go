@@ -0,0 +1,70 @@
+package executor
+
+import (
+	"encoding/json"
+
+	"github.com/n9te9/goliteql/query"
+)
+
+// CollectFields flattens selections into the fields to resolve on an object,
+// where typeNames are the name of the object type and the names of the interfaces
+// and unions it belongs to.
+// Inline fragments are applied when their type condition is one of typeNames,
+// fields and fragments excluded by @skip or @include are dropped, and
+// fields selected more than once with the same response key are merged in the order they first appear.
+// Fragment spreads must be expanded by ExpandFragments beforehand, as PlanExecution does.
+func CollectFields(selections []query.Selection, variables json.RawMessage, typeNames ...string) []*query.Field {
+	res := make([]*query.Field, 0, len(selections))
+	index := make(map[string]int)
+
+	var collect func(selections []query.Selection)
+	collect = func(selections []query.Selection) {
+		for _, sel := range selections {
+			switch s := sel.(type) {
+			case *query.Field:
+				if IsSkipped(s.Directives, variables) || !IsIncluded(s.Directives, variables) {
+					continue
+				}
+
+				key := string(s.ResponseKey())
+				i, ok := index[key]
+				if !ok {
+					index[key] = len(res)
+					res = append(res, s)
+					continue
+				}
+
+				merged := *res[i]
+				merged.Selections = append(append(make([]query.Selection, 0, len(merged.Selections)+len(s.Selections)), merged.Selections...), s.Selections...)
+				res[i] = &merged
+			case *query.InlineFragment:
+				if IsSkipped(s.Directives, variables) || !IsIncluded(s.Directives, variables) {
+					continue
+				}
+
+				if !matchTypeCondition(s.TypeCondition, typeNames) {
+					continue
+				}
+
+				collect(s.Selections)
+			}
+		}
+	}
+	collect(selections)
+
+	return res
+}
+
+func matchTypeCondition(typeCondition []byte, typeNames []string) bool {
+	if len(typeCondition) == 0 {
+		return true
+	}
+
+	for _, name := range typeNames {
+		if string(typeCondition) == name {
+			return true
+		}
+	}
+
+	return false
+}
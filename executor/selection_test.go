@@ -0,0 +1,140 @@
+package executor_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/query"
+)
+
+func TestCollectFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		selections []query.Selection
+		variables  json.RawMessage
+		typeNames  []string
+		want       []*query.Field
+	}{
+		{
+			name: "inline fragments are applied by type condition",
+			selections: []query.Selection{
+				&query.Field{Name: []byte("__typename")},
+				&query.InlineFragment{
+					TypeCondition: []byte("Post"),
+					Selections: []query.Selection{
+						&query.Field{Name: []byte("title")},
+					},
+				},
+				&query.InlineFragment{
+					TypeCondition: []byte("User"),
+					Selections: []query.Selection{
+						&query.Field{Name: []byte("name")},
+					},
+				},
+				&query.InlineFragment{
+					TypeCondition: []byte("Node"),
+					Selections: []query.Selection{
+						&query.Field{Name: []byte("id")},
+					},
+				},
+			},
+			typeNames: []string{"Post", "Node", "SearchResult"},
+			want: []*query.Field{
+				{Name: []byte("__typename")},
+				{Name: []byte("title")},
+				{Name: []byte("id")},
+			},
+		},
+		{
+			name: "fields and fragments excluded by directives are dropped",
+			selections: []query.Selection{
+				&query.Field{
+					Name: []byte("id"),
+					Directives: []*query.Directive{
+						{
+							Name: []byte("skip"),
+							Arguments: []*query.DirectiveArgument{
+								{Name: []byte("if"), Value: &query.BooleanValue{Value: true}},
+							},
+						},
+					},
+				},
+				&query.InlineFragment{
+					TypeCondition: []byte("Post"),
+					Directives: []*query.Directive{
+						{
+							Name: []byte("include"),
+							Arguments: []*query.DirectiveArgument{
+								{Name: []byte("if"), Value: &query.BooleanValue{Value: false}},
+							},
+						},
+					},
+					Selections: []query.Selection{
+						&query.Field{Name: []byte("title")},
+					},
+				},
+				&query.Field{Name: []byte("content")},
+			},
+			typeNames: []string{"Post"},
+			want: []*query.Field{
+				{Name: []byte("content")},
+			},
+		},
+		{
+			name: "fields selected more than once are merged",
+			selections: []query.Selection{
+				&query.Field{
+					Name: []byte("author"),
+					Selections: []query.Selection{
+						&query.Field{Name: []byte("id")},
+					},
+				},
+				&query.InlineFragment{
+					TypeCondition: []byte("Post"),
+					Selections: []query.Selection{
+						&query.Field{
+							Name: []byte("author"),
+							Selections: []query.Selection{
+								&query.Field{Name: []byte("name")},
+							},
+						},
+					},
+				},
+			},
+			typeNames: []string{"Post"},
+			want: []*query.Field{
+				{
+					Name: []byte("author"),
+					Selections: []query.Selection{
+						&query.Field{Name: []byte("id")},
+						&query.Field{Name: []byte("name")},
+					},
+				},
+			},
+		},
+		{
+			name: "aliased fields are collected by response key",
+			selections: []query.Selection{
+				&query.Field{Alias: []byte("first"), Name: []byte("title")},
+				&query.Field{Name: []byte("title")},
+				&query.Field{Alias: []byte("first"), Name: []byte("title")},
+			},
+			typeNames: []string{"Post"},
+			want: []*query.Field{
+				{Alias: []byte("first"), Name: []byte("title"), Selections: []query.Selection{}},
+				{Name: []byte("title")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := executor.CollectFields(tt.selections, tt.variables, tt.typeNames...)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CollectFields() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
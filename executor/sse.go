@@ -0,0 +1,128 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const eventStreamContentType = "text/event-stream"
+
+// IsEventStreamRequest reports whether req prefers a text/event-stream response,
+// as graphql-sse clients do in distinct connections mode.
+// Clients which list JSON first keep receiving a single JSON response.
+func IsEventStreamRequest(req *http.Request) bool {
+	accept := strings.Split(req.Header.Get("Accept"), ",")[0]
+
+	mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+	if err != nil {
+		return false
+	}
+
+	return mediaType == eventStreamContentType
+}
+
+// ServeGraphQLSSE serves req over the graphql-sse protocol in distinct connections mode.
+// Every execution result is sent as a "next" event, followed by a "complete" event
+// once the operation ends.
+func ServeGraphQLSSE(w http.ResponseWriter, req *http.Request, subscribe SubscribeFunc) {
+	request, err := parseSSERequest(req)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		WriteErrorResponse(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	results, err := subscribe(req.WithContext(ctx), request)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", eventStreamContentType+"; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case b, ok := <-results:
+			if !ok {
+				writeEvent(w, "complete", nil)
+				flusher.Flush()
+				return
+			}
+
+			writeEvent(w, "next", b)
+			flusher.Flush()
+		}
+	}
+}
+
+func parseSSERequest(req *http.Request) (*GraphQLRequest, error) {
+	request := new(GraphQLRequest)
+
+	switch req.Method {
+	case http.MethodGet:
+		params := req.URL.Query()
+		request.Query = params.Get("query")
+		request.OperationName = params.Get("operationName")
+
+		if v := params.Get("variables"); v != "" {
+			if !json.Valid([]byte(v)) {
+				return nil, errors.New("variables must be JSON")
+			}
+			request.Variables = json.RawMessage(v)
+		}
+
+		if v := params.Get("extensions"); v != "" {
+			if !json.Valid([]byte(v)) {
+				return nil, errors.New("extensions must be JSON")
+			}
+			request.Extensions = json.RawMessage(v)
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(req.Body).Decode(request); err != nil {
+			return nil, fmt.Errorf("invalid request body: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unexpected method %s", req.Method)
+	}
+
+	if request.Query == "" {
+		return nil, errors.New("query is required")
+	}
+
+	return request, nil
+}
+
+func writeEvent(w http.ResponseWriter, event string, data []byte) {
+	fmt.Fprintf(w, "event: %s\n", event)
+
+	if len(data) == 0 {
+		fmt.Fprint(w, "data:\n\n")
+		return
+	}
+
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		fmt.Fprintf(w, "data: %s\n", line)
+	}
+	fmt.Fprint(w, "\n")
+}
@@ -0,0 +1,155 @@
+package executor_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+)
+
+func TestServeGraphQLSSE(t *testing.T) {
+	subscribe := func(req *http.Request, request *executor.GraphQLRequest) (<-chan []byte, error) {
+		if request.Query == "invalid" {
+			return nil, errors.New("invalid query")
+		}
+
+		var variables struct {
+			Count int `json:"count"`
+		}
+		if len(request.Variables) > 0 {
+			if err := json.Unmarshal(request.Variables, &variables); err != nil {
+				return nil, err
+			}
+		}
+
+		source := make(chan int, variables.Count)
+		for i := 1; i <= variables.Count; i++ {
+			source <- i
+		}
+		close(source)
+
+		return executor.Stream(req.Context(), source, func(v int) ([]byte, error) {
+			return json.Marshal(executor.GraphQLResponse{Data: map[string]int{"count": v}})
+		}), nil
+	}
+
+	tests := []struct {
+		name            string
+		request         func() *http.Request
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name: "POST streams next events and completes",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"subscription { count }","variables":{"count":2}}`))
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/event-stream; charset=utf-8",
+			wantBody:        "event: next\ndata: {\"data\":{\"count\":1}}\n\nevent: next\ndata: {\"data\":{\"count\":2}}\n\nevent: complete\ndata:\n\n",
+		},
+		{
+			name: "GET reads the request from query parameters",
+			request: func() *http.Request {
+				params := url.Values{}
+				params.Set("query", "subscription { count }")
+				params.Set("variables", `{"count":1}`)
+				return httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/event-stream; charset=utf-8",
+			wantBody:        "event: next\ndata: {\"data\":{\"count\":1}}\n\nevent: complete\ndata:\n\n",
+		},
+		{
+			name: "missing query is a bad request",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{}`))
+			},
+			wantStatus:      http.StatusBadRequest,
+			wantContentType: "application/json",
+			wantBody:        "{\"errors\":[{\"message\":\"query is required\"}]}\n",
+		},
+		{
+			name: "subscribe error is a bad request",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"invalid"}`))
+			},
+			wantStatus:      http.StatusBadRequest,
+			wantContentType: "application/json",
+			wantBody:        "{\"errors\":[{\"message\":\"invalid query\"}]}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.request()
+			req.Header.Set("Accept", "text/event-stream")
+
+			if !executor.IsEventStreamRequest(req) {
+				t.Fatalf("expected event stream request")
+			}
+
+			w := httptest.NewRecorder()
+			executor.ServeGraphQLSSE(w, req, subscribe)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+
+			if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("expected content type %q, got %q", tt.wantContentType, got)
+			}
+
+			if diff := cmp.Diff(tt.wantBody, w.Body.String()); diff != "" {
+				t.Errorf("ServeGraphQLSSE() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsEventStreamRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   bool
+	}{
+		{
+			name:   "event stream",
+			accept: "text/event-stream",
+			want:   true,
+		},
+		{
+			name:   "event stream preferred to json",
+			accept: "text/event-stream, application/json",
+			want:   true,
+		},
+		{
+			name:   "json preferred to event stream",
+			accept: "application/json, text/event-stream",
+			want:   false,
+		},
+		{
+			name:   "json",
+			accept: "application/json",
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+			req.Header.Set("Accept", tt.accept)
+
+			if got := executor.IsEventStreamRequest(req); got != tt.want {
+				t.Errorf("IsEventStreamRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
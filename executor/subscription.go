@@ -0,0 +1,285 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// GraphQLRequest is the request payload shared by every transport.
+type GraphQLRequest struct {
+	OperationName string          `json:"operationName,omitempty"`
+	Query         string          `json:"query"`
+	Variables     json.RawMessage `json:"variables,omitempty"`
+	Extensions    json.RawMessage `json:"extensions,omitempty"`
+}
+
+// SubscribeFunc executes request and streams its encoded execution results.
+// The channel is closed when the operation completes, and the operation is
+// cancelled through the context of req.
+type SubscribeFunc func(req *http.Request, request *GraphQLRequest) (<-chan []byte, error)
+
+// Stream encodes every value received from source and sends it on the returned channel
+// until source is closed or ctx is done.
+func Stream[T any](ctx context.Context, source <-chan T, encode func(T) ([]byte, error)) <-chan []byte {
+	results := make(chan []byte)
+
+	go func() {
+		defer close(results)
+
+		if source == nil {
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-source:
+				if !ok {
+					return
+				}
+
+				b, err := encode(v)
+				if err != nil {
+					b, _ = json.Marshal(GraphQLResponse{Errors: ToGraphQLErrors(err)})
+				}
+
+				select {
+				case results <- b:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return results
+}
+
+// ExecuteOnce runs a query or mutation received over a streaming transport through handler,
+// and returns its result as a stream of a single item.
+// A request which handler rejects, such as an invalid query, returns the GraphQLErrors of the response as its error.
+func ExecuteOnce(handler http.Handler, req *http.Request, request *GraphQLRequest) (<-chan []byte, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := http.NewRequestWithContext(req.Context(), http.MethodPost, req.URL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	r.Header = req.Header.Clone()
+	r.Header.Del("Connection")
+	r.Header.Del("Upgrade")
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Accept", "application/json")
+
+	w := NewResponseBuffer()
+	handler.ServeHTTP(w, r)
+
+	if w.StatusCode() != http.StatusOK {
+		// the request failed before its execution began, so its errors are the errors of the operation as they are
+		var resp RequestErrorResponse
+		if err := json.Unmarshal(w.Bytes(), &resp); err != nil || len(resp.Errors) == 0 {
+			return nil, errors.New(string(bytes.TrimSpace(w.Bytes())))
+		}
+
+		return nil, resp.Errors
+	}
+
+	results := make(chan []byte, 1)
+	results <- bytes.TrimSpace(w.Bytes())
+	close(results)
+
+	return results, nil
+}
+
+const graphqlTransportWSProtocol = "graphql-transport-ws"
+
+// ConnectionInitWaitTimeout is how long a graphql-transport-ws client may wait before sending connection_init.
+var ConnectionInitWaitTimeout = 10 * time.Second
+
+type transportWSMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type transportWSSession struct {
+	conn          *wsConn
+	req           *http.Request
+	subscribe     SubscribeFunc
+	mu            sync.Mutex
+	acknowledged  bool
+	subscriptions map[string]context.CancelFunc
+}
+
+// ServeGraphQLTransportWS upgrades req to a WebSocket and serves operations over
+// the graphql-transport-ws protocol until the connection is closed.
+func ServeGraphQLTransportWS(w http.ResponseWriter, req *http.Request, subscribe SubscribeFunc) {
+	conn, err := upgradeWebSocket(w, req, graphqlTransportWSProtocol)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	s := &transportWSSession{
+		conn:          conn,
+		req:           req,
+		subscribe:     subscribe,
+		subscriptions: make(map[string]context.CancelFunc),
+	}
+
+	timer := time.AfterFunc(ConnectionInitWaitTimeout, func() {
+		s.mu.Lock()
+		acknowledged := s.acknowledged
+		s.mu.Unlock()
+
+		if !acknowledged {
+			s.close(4408, "Connection initialisation timeout")
+		}
+	})
+	defer timer.Stop()
+
+	s.serve(ctx)
+}
+
+func (s *transportWSSession) serve(ctx context.Context) {
+	for {
+		b, err := s.conn.readMessage()
+		if err != nil {
+			return
+		}
+
+		var msg transportWSMessage
+		if err := json.Unmarshal(b, &msg); err != nil {
+			s.close(4400, "Invalid message received")
+			return
+		}
+
+		switch msg.Type {
+		case "connection_init":
+			s.mu.Lock()
+			acknowledged := s.acknowledged
+			s.acknowledged = true
+			s.mu.Unlock()
+
+			if acknowledged {
+				s.close(4429, "Too many initialisation requests")
+				return
+			}
+
+			s.send(transportWSMessage{Type: "connection_ack"})
+		case "ping":
+			s.send(transportWSMessage{Type: "pong"})
+		case "pong":
+		case "subscribe":
+			s.mu.Lock()
+			acknowledged := s.acknowledged
+			s.mu.Unlock()
+
+			if !acknowledged {
+				s.close(4401, "Unauthorized")
+				return
+			}
+
+			var request GraphQLRequest
+			if msg.ID == "" || json.Unmarshal(msg.Payload, &request) != nil {
+				s.close(4400, "Invalid message received")
+				return
+			}
+
+			s.mu.Lock()
+			if _, ok := s.subscriptions[msg.ID]; ok {
+				s.mu.Unlock()
+				s.close(4409, fmt.Sprintf("Subscriber for %s already exists", msg.ID))
+				return
+			}
+
+			subCtx, cancel := context.WithCancel(ctx)
+			s.subscriptions[msg.ID] = cancel
+			s.mu.Unlock()
+
+			go s.execute(subCtx, msg.ID, &request)
+		case "complete":
+			s.mu.Lock()
+			if cancel, ok := s.subscriptions[msg.ID]; ok {
+				cancel()
+				delete(s.subscriptions, msg.ID)
+			}
+			s.mu.Unlock()
+		default:
+			s.close(4400, "Invalid message received")
+			return
+		}
+	}
+}
+
+func (s *transportWSSession) execute(ctx context.Context, id string, request *GraphQLRequest) {
+	results, err := s.subscribe(s.req.WithContext(ctx), request)
+	if err != nil {
+		if s.finish(id) {
+			payload, _ := json.Marshal(ToGraphQLErrors(err))
+			s.send(transportWSMessage{ID: id, Type: "error", Payload: payload})
+		}
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case b, ok := <-results:
+			if !ok {
+				if s.finish(id) {
+					s.send(transportWSMessage{ID: id, Type: "complete"})
+				}
+				return
+			}
+
+			s.send(transportWSMessage{ID: id, Type: "next", Payload: b})
+		}
+	}
+}
+
+// finish forgets the subscription and reports whether it was still active,
+// that is, the client has not completed it on its own.
+func (s *transportWSSession) finish(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cancel, ok := s.subscriptions[id]
+	if !ok {
+		return false
+	}
+
+	cancel()
+	delete(s.subscriptions, id)
+
+	return true
+}
+
+func (s *transportWSSession) send(msg transportWSMessage) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return s.conn.writeMessage(b)
+}
+
+func (s *transportWSSession) close(code uint16, reason string) {
+	s.conn.writeClose(code, reason)
+	s.conn.Close()
+}
@@ -0,0 +1,348 @@
+package executor_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/query"
+)
+
+type testWSClient struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func dialTestWS(t *testing.T, url string) *testWSClient {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(url, "http://"))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+
+	key := make([]byte, 16)
+	rand.Read(key)
+	fmt.Fprintf(conn, "GET / HTTP/1.1\r\nHost: example.com\r\nConnection: Upgrade\r\nUpgrade: websocket\r\nSec-WebSocket-Version: 13\r\nSec-WebSocket-Key: %s\r\nSec-WebSocket-Protocol: graphql-transport-ws\r\n\r\n", base64.StdEncoding.EncodeToString(key))
+
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatalf("failed to read handshake: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("unexpected handshake status %d", resp.StatusCode)
+	}
+
+	if got := resp.Header.Get("Sec-WebSocket-Protocol"); got != "graphql-transport-ws" {
+		t.Fatalf("unexpected subprotocol %q", got)
+	}
+
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	return &testWSClient{conn: conn, r: r}
+}
+
+func (c *testWSClient) send(t *testing.T, message string) {
+	t.Helper()
+
+	frame := []byte{0x81}
+	switch length := len(message); {
+	case length < 126:
+		frame = append(frame, 0x80|byte(length))
+	default:
+		frame = append(frame, 0x80|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(length))
+	}
+
+	mask := []byte{1, 2, 3, 4}
+	frame = append(frame, mask...)
+	for i := range message {
+		frame = append(frame, message[i]^mask[i%4])
+	}
+
+	if _, err := c.conn.Write(frame); err != nil {
+		t.Fatalf("failed to write frame: %v", err)
+	}
+}
+
+// read returns the next text message, or the close code and reason as "close <code> <reason>".
+func (c *testWSClient) read(t *testing.T) string {
+	t.Helper()
+
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(c.r, header); err != nil {
+		t.Fatalf("failed to read frame: %v", err)
+	}
+
+	length := int(header[1] & 0x7f)
+	if length == 126 {
+		ext := make([]byte, 2)
+		io.ReadFull(c.r, ext)
+		length = int(binary.BigEndian.Uint16(ext))
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		t.Fatalf("failed to read payload: %v", err)
+	}
+
+	if header[0]&0x0f == 0x8 {
+		return fmt.Sprintf("close %d %s", binary.BigEndian.Uint16(payload), payload[2:])
+	}
+
+	return string(payload)
+}
+
+func TestServeGraphQLTransportWS(t *testing.T) {
+	subscribe := func(req *http.Request, request *executor.GraphQLRequest) (<-chan []byte, error) {
+		if request.Query == "invalid" {
+			return nil, errors.New("invalid query")
+		}
+
+		source := make(chan int)
+		go func() {
+			defer close(source)
+			for i := 1; i <= 2; i++ {
+				select {
+				case source <- i:
+				case <-req.Context().Done():
+					return
+				}
+			}
+		}()
+
+		return executor.Stream(req.Context(), source, func(v int) ([]byte, error) {
+			return json.Marshal(executor.GraphQLResponse{Data: map[string]int{"count": v}})
+		}), nil
+	}
+
+	tests := []struct {
+		name     string
+		messages []string
+		want     []string
+	}{
+		{
+			name: "subscribe streams next messages and completes",
+			messages: []string{
+				`{"type":"connection_init"}`,
+				`{"id":"1","type":"subscribe","payload":{"query":"subscription { count }"}}`,
+			},
+			want: []string{
+				`{"type":"connection_ack"}`,
+				`{"id":"1","type":"next","payload":{"data":{"count":1}}}`,
+				`{"id":"1","type":"next","payload":{"data":{"count":2}}}`,
+				`{"id":"1","type":"complete"}`,
+			},
+		},
+		{
+			name: "ping is answered with pong",
+			messages: []string{
+				`{"type":"connection_init"}`,
+				`{"type":"ping"}`,
+			},
+			want: []string{
+				`{"type":"connection_ack"}`,
+				`{"type":"pong"}`,
+			},
+		},
+		{
+			name: "subscribe error is sent as error message",
+			messages: []string{
+				`{"type":"connection_init"}`,
+				`{"id":"1","type":"subscribe","payload":{"query":"invalid"}}`,
+			},
+			want: []string{
+				`{"type":"connection_ack"}`,
+				`{"id":"1","type":"error","payload":[{"message":"invalid query"}]}`,
+			},
+		},
+		{
+			name: "subscribe before connection_init is unauthorized",
+			messages: []string{
+				`{"id":"1","type":"subscribe","payload":{"query":"subscription { count }"}}`,
+			},
+			want: []string{
+				`close 4401 Unauthorized`,
+			},
+		},
+		{
+			name: "duplicated connection_init closes the connection",
+			messages: []string{
+				`{"type":"connection_init"}`,
+				`{"type":"connection_init"}`,
+			},
+			want: []string{
+				`{"type":"connection_ack"}`,
+				`close 4429 Too many initialisation requests`,
+			},
+		},
+		{
+			name: "invalid message closes the connection",
+			messages: []string{
+				`{"type":"unknown"}`,
+			},
+			want: []string{
+				`close 4400 Invalid message received`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				executor.ServeGraphQLTransportWS(w, req, subscribe)
+			}))
+			defer server.Close()
+
+			client := dialTestWS(t, server.URL)
+			defer client.conn.Close()
+
+			for _, message := range tt.messages {
+				client.send(t, message)
+			}
+
+			got := make([]string, 0, len(tt.want))
+			for range tt.want {
+				got = append(got, client.read(t))
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ServeGraphQLTransportWS() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServeGraphQLTransportWS_RejectsPlainRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		executor.ServeGraphQLTransportWS(w, req, nil)
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("failed to request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	source := make(chan string, 2)
+	source <- "a"
+	source <- "b"
+	close(source)
+
+	results := executor.Stream(ctx, source, func(v string) ([]byte, error) {
+		if v == "b" {
+			return nil, errors.New("failed to encode b")
+		}
+
+		return json.Marshal(v)
+	})
+
+	got := make([]string, 0)
+	for b := range results {
+		got = append(got, string(b))
+	}
+
+	want := []string{`"a"`, `{"data":null,"errors":[{"message":"failed to encode b"}]}`}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Stream() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExecuteOnce(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var request executor.GraphQLRequest
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusUnprocessableEntity)
+			return
+		}
+
+		json.NewEncoder(w).Encode(executor.GraphQLResponse{Data: map[string]string{"query": request.Query}})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+	results, err := executor.ExecuteOnce(handler, req, &executor.GraphQLRequest{Query: "query { posts }"})
+	if err != nil {
+		t.Fatalf("ExecuteOnce() error = %v", err)
+	}
+
+	got := make([]string, 0)
+	for b := range results {
+		got = append(got, string(b))
+	}
+
+	want := []string{`{"data":{"query":"query { posts }"}}`}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ExecuteOnce() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExecuteOnce_Errors(t *testing.T) {
+	loc := &query.Loc{Start: query.Position{Line: 1, Column: 9, Offset: 8}}
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    executor.GraphQLErrors
+	}{
+		{
+			name: "the errors of a request failing before its execution are returned as they are",
+			handler: func(w http.ResponseWriter, req *http.Request) {
+				executor.WriteErrorResponse(w, http.StatusBadRequest, executor.GraphQLErrors{
+					executor.NewGraphQLError(executor.ErrorCodeValidationFailed, "field unknown is not defined in schema").WithLocations(loc),
+				})
+			},
+			want: executor.GraphQLErrors{{
+				Message:    "field unknown is not defined in schema",
+				Locations:  []executor.Location{{Line: 1, Column: 9}},
+				Extensions: map[string]any{"code": "GRAPHQL_VALIDATION_FAILED"},
+			}},
+		},
+		{
+			name: "a response which isn't a GraphQL response is returned as a message",
+			handler: func(w http.ResponseWriter, req *http.Request) {
+				http.Error(w, "Invalid JSON", http.StatusUnprocessableEntity)
+			},
+			want: executor.GraphQLErrors{{Message: "Invalid JSON"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+			results, err := executor.ExecuteOnce(tt.handler, req, &executor.GraphQLRequest{Query: "query { unknown }"})
+			if results != nil || err == nil {
+				t.Fatalf("ExecuteOnce() = %v, %v, want an error", results, err)
+			}
+
+			if diff := cmp.Diff(tt.want, executor.ToGraphQLErrors(err)); diff != "" {
+				t.Errorf("ExecuteOnce() error mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package executor
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// IsNullJSON reports whether data is absent or a JSON null.
+func IsNullJSON(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) == 0 || bytes.Equal(data, []byte("null"))
+}
+
+// UnmarshalList decodes a JSON list whose items are decoded by unmarshal.
+// Generated models use it for lists of unions and interfaces,
+// which encoding/json can not decode on its own.
+func UnmarshalList[T any](data []byte, unmarshal func([]byte) (T, error)) ([]T, error) {
+	if IsNullJSON(data) {
+		return nil, nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+
+	res := make([]T, len(items))
+	for i, item := range items {
+		v, err := unmarshal(item)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = v
+	}
+
+	return res, nil
+}
+
+// UnmarshalNullable decodes data with unmarshal, keeping a JSON null as nil.
+func UnmarshalNullable[T any](data []byte, unmarshal func([]byte) (T, error)) (*T, error) {
+	if IsNullJSON(data) {
+		return nil, nil
+	}
+
+	v, err := unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
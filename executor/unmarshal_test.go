@@ -0,0 +1,55 @@
+package executor_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+)
+
+func TestUnmarshalList(t *testing.T) {
+	unmarshal := func(data []byte) (string, error) {
+		var v struct {
+			Typename string `json:"__typename"`
+		}
+		err := json.Unmarshal(data, &v)
+		return v.Typename, err
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "items are decoded by unmarshal",
+			data: `[{"__typename":"Post"},{"__typename":"User"}]`,
+			want: []string{"Post", "User"},
+		},
+		{
+			name: "null is a nil list",
+			data: `null`,
+			want: nil,
+		},
+		{
+			name:    "not a list",
+			data:    `{"__typename":"Post"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := executor.UnmarshalList([]byte(tt.data), unmarshal)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UnmarshalList() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
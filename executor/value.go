@@ -0,0 +1,49 @@
+package executor
+
+import (
+	"encoding/json"
+
+	"github.com/n9te9/goliteql/query"
+)
+
+// valueToJSON converts value, a value in a query or a schema, to JSON, where ok is false for a variable which isn't given.
+// Enum values are converted to strings, and the variables in value are replaced with their values in variables.
+// A variable which isn't given is null in a list, and leaves its field out of an object.
+func valueToJSON(value query.InputValue, variables map[string]json.RawMessage) (res json.RawMessage, ok bool) {
+	switch v := value.(type) {
+	case nil:
+		return json.RawMessage("null"), true
+	case *query.VariableValue:
+		res, ok = variables[string(v.Name)]
+		return res, ok
+	case *query.ListValue:
+		values := make([]json.RawMessage, 0, len(v.Values))
+		for _, item := range v.Values {
+			res, ok := valueToJSON(item, variables)
+			if !ok {
+				res = json.RawMessage("null")
+			}
+			values = append(values, res)
+		}
+
+		b, _ := json.Marshal(values)
+		return b, true
+	case *query.ObjectValue:
+		fields := NewOrderedMap()
+		for _, field := range v.Fields {
+			if res, ok := valueToJSON(field.Value, variables); ok {
+				fields.Set(string(field.Name), res)
+			}
+		}
+
+		b, _ := json.Marshal(fields)
+		return b, true
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return json.RawMessage("null"), true
+	}
+
+	return b, true
+}
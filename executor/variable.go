@@ -0,0 +1,197 @@
+package executor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/schema"
+)
+
+// CoerceVariableValues returns the variables of op given in variables coerced to their types in s,
+// as described in "CoerceVariableValues" of the specification.
+// Variables which aren't given have their default values, which are coerced as the given values are,
+// and variables which op doesn't define are left out.
+// The error of a value which can't be coerced has the path of the value in the variable, such as $filter.tags[0].
+func CoerceVariableValues(s *schema.Schema, op *query.Operation, variables json.RawMessage) (json.RawMessage, error) {
+	values := make(map[string]json.RawMessage)
+	if len(variables) > 0 && string(variables) != "null" {
+		if err := json.Unmarshal(variables, &values); err != nil {
+			return nil, fmt.Errorf("variables must be an object: %w", err)
+		}
+	}
+
+	coerced := make(map[string]json.RawMessage)
+	for _, v := range op.Variables {
+		variableType := schema.NewFieldType(v.Type)
+		value, ok := values[string(v.Name)]
+		if !ok {
+			if v.DefaultValue == nil {
+				if !variableType.Nullable {
+					return nil, fmt.Errorf("variable $%s of non-null type %s is not provided", v.Name, variableType)
+				}
+				continue
+			}
+
+			value, _ = valueToJSON(v.DefaultValue, nil)
+		}
+
+		value, err := coerceInputValue(s, variableType, value, "variable $"+string(v.Name))
+		if err != nil {
+			return nil, err
+		}
+		coerced[string(v.Name)] = value
+	}
+
+	return json.Marshal(coerced)
+}
+
+// coerceInputValue coerces value, a JSON value at path in a variable or an argument, to the type t,
+// as described in "Input Coercion" of the types in the specification.
+// path names the variable or the argument, such as "variable $filter.tags[0]" or "argument filter.tags[0]".
+func coerceInputValue(s *schema.Schema, t *schema.FieldType, value json.RawMessage, path string) (json.RawMessage, error) {
+	value = bytes.TrimSpace(value)
+	if string(value) == "null" {
+		if !t.Nullable {
+			return nil, fmt.Errorf("%s of non-null type %s must not be null", path, t)
+		}
+
+		return value, nil
+	}
+
+	if t.IsList {
+		if value[0] != '[' {
+			// A single value is coerced to a list of the value.
+			item, err := coerceInputValue(s, t.ListType, value, path)
+			if err != nil {
+				return nil, err
+			}
+
+			return json.Marshal([]json.RawMessage{item})
+		}
+
+		var items []json.RawMessage
+		if err := json.Unmarshal(value, &items); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		for i, item := range items {
+			coerced, err := coerceInputValue(s, t.ListType, item, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			items[i] = coerced
+		}
+
+		return json.Marshal(items)
+	}
+
+	if input := s.Indexes.InputIndex[string(t.Name)]; input != nil {
+		return coerceInputObject(s, input, value, path)
+	}
+
+	if enum := s.Indexes.EnumIndex[string(t.Name)]; enum != nil {
+		var v string
+		if err := json.Unmarshal(value, &v); err != nil || !enum.HasValue(v) {
+			return nil, fmt.Errorf("%s: %s is not a value of enum %s", path, value, enum.Name)
+		}
+
+		return value, nil
+	}
+
+	if err := coerceScalar(s, string(t.Name), value); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if string(t.Name) == "ID" && value[0] != '"' {
+		// An integer ID is given as a string to resolvers, as IDs are serialized as strings.
+		return json.Marshal(string(value))
+	}
+
+	return value, nil
+}
+
+// coerceInputObject coerces value, a JSON object at path in a variable or an argument, to the input object type input.
+// The fields which aren't given have their default values, and the fields which input doesn't define are rejected.
+func coerceInputObject(s *schema.Schema, input *schema.InputDefinition, value json.RawMessage, path string) (json.RawMessage, error) {
+	if value[0] != '{' {
+		return nil, fmt.Errorf("%s: expected an object of input type %s, got %s", path, input.Name, value)
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(value, &fields); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for name := range fields {
+		if !hasInputField(input, name) {
+			return nil, fmt.Errorf("%s: field %s is not defined by input type %s", path, name, input.Name)
+		}
+	}
+
+	res := NewOrderedMap()
+	for _, f := range input.Fields {
+		fieldValue, ok := fields[string(f.Name)]
+		if !ok {
+			if f.Default != nil {
+				fieldValue, _ = valueToJSON(f.Default, nil)
+				res.Set(string(f.Name), fieldValue)
+			} else if !f.Type.Nullable {
+				return nil, fmt.Errorf("%s.%s of non-null type %s is not provided", path, f.Name, f.Type)
+			}
+			continue
+		}
+
+		coerced, err := coerceInputValue(s, f.Type, fieldValue, path+"."+string(f.Name))
+		if err != nil {
+			return nil, err
+		}
+		res.Set(string(f.Name), coerced)
+	}
+
+	return json.Marshal(res)
+}
+
+func hasInputField(input *schema.InputDefinition, name string) bool {
+	for _, f := range input.Fields {
+		if string(f.Name) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// coerceScalar validates value as a value of the scalar named name in s.
+// Values of custom scalars are validated by the Go types registered with schema.RegisterScalar,
+// and are passed to resolvers as they are.
+func coerceScalar(s *schema.Schema, name string, value json.RawMessage) error {
+	switch name {
+	case "Int":
+		if _, err := strconv.ParseInt(string(value), 10, 32); err != nil {
+			return fmt.Errorf("expected a 32-bit signed integer, got %s", value)
+		}
+	case "Float":
+		if _, err := strconv.ParseFloat(string(value), 64); err != nil {
+			return fmt.Errorf("expected a float, got %s", value)
+		}
+	case "String":
+		if value[0] != '"' {
+			return fmt.Errorf("expected a string, got %s", value)
+		}
+	case "Boolean":
+		if string(value) != "true" && string(value) != "false" {
+			return fmt.Errorf("expected a boolean, got %s", value)
+		}
+	case "ID":
+		if _, err := strconv.ParseInt(string(value), 10, 64); err != nil && value[0] != '"' {
+			return fmt.Errorf("expected a string or an integer ID, got %s", value)
+		}
+	default:
+		return s.ValidateScalarValue(name, value)
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package executor_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/schema"
+)
+
+func TestCoerceVariableValues(t *testing.T) {
+	s := schema.MustParse([]byte(`scalar DateTime
+
+type Query {
+	posts(first: Int, filter: PostFilter, after: DateTime): [Post!]!
+}
+
+type Post {
+	id: ID!
+}
+
+enum Status {
+	DRAFT
+	PUBLISHED
+}
+
+input Author {
+	name: String!
+}
+
+input PostFilter {
+	status: Status = PUBLISHED
+	tags: [String!]
+	author: Author
+	score: Float
+}`))
+	if err := schema.RegisterScalar[time.Time](s, "DateTime"); err != nil {
+		t.Fatalf("RegisterScalar() error %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		variables json.RawMessage
+		want      string
+		wantErr   error
+	}{
+		{
+			name:  "variables which aren't given have their default values",
+			query: `query ($first: Int = 10, $filter: PostFilter = { status: PUBLISHED }, $id: ID) { posts { id } }`,
+			want:  `{"filter":{"status":"PUBLISHED"},"first":10}`,
+		},
+		{
+			name:  "default values are coerced to the types of the variables",
+			query: `query ($id: ID = 1, $filter: PostFilter = { tags: "a" }) { posts { id } }`,
+			want:  `{"filter":{"status":"PUBLISHED","tags":["a"]},"id":"1"}`,
+		},
+		{
+			name:      "given variables are kept and undefined variables are left out",
+			query:     `query ($first: Int = 10, $id: ID) { posts { id } }`,
+			variables: json.RawMessage(`{"first": null, "id": "1", "other": true}`),
+			want:      `{"first":null,"id":"1"}`,
+		},
+		{
+			name:      "integer IDs are coerced to strings",
+			query:     `query ($id: ID!, $ids: [ID!]) { posts { id } }`,
+			variables: json.RawMessage(`{"id": 1, "ids": [2, "3"]}`),
+			want:      `{"id":"1","ids":["2","3"]}`,
+		},
+		{
+			name:      "a single value is coerced to a list",
+			query:     `query ($ids: [ID!]!) { posts { id } }`,
+			variables: json.RawMessage(`{"ids": "1"}`),
+			want:      `{"ids":["1"]}`,
+		},
+		{
+			name:      "input objects have the default values of their fields",
+			query:     `query ($filter: PostFilter) { posts { id } }`,
+			variables: json.RawMessage(`{"filter": {"tags": "a", "author": {"name": "name"}, "score": 1}}`),
+			want:      `{"filter":{"status":"PUBLISHED","tags":["a"],"author":{"name":"name"},"score":1}}`,
+		},
+		{
+			name:      "values of custom scalars are decoded into their Go types and kept as they are",
+			query:     `query ($after: DateTime, $afters: [DateTime!]) { posts { id } }`,
+			variables: json.RawMessage(`{"after": "2024-01-01T00:00:00Z", "afters": "2024-01-01T00:00:00Z"}`),
+			want:      `{"after":"2024-01-01T00:00:00Z","afters":["2024-01-01T00:00:00Z"]}`,
+		},
+		{
+			name:    "a non-null variable must be given",
+			query:   `query ($id: ID!) { posts { id } }`,
+			wantErr: errors.New("variable $id of non-null type ID! is not provided"),
+		},
+		{
+			name:      "a non-null variable must not be null",
+			query:     `query ($ids: [ID!]) { posts { id } }`,
+			variables: json.RawMessage(`{"ids": ["1", null]}`),
+			wantErr:   errors.New("variable $ids[1] of non-null type ID! must not be null"),
+		},
+		{
+			name:      "an Int must be a 32-bit integer",
+			query:     `query ($first: Int) { posts { id } }`,
+			variables: json.RawMessage(`{"first": 2147483648}`),
+			wantErr:   errors.New("variable $first: expected a 32-bit signed integer, got 2147483648"),
+		},
+		{
+			name:      "fields of input objects are coerced",
+			query:     `query ($filter: PostFilter) { posts { id } }`,
+			variables: json.RawMessage(`{"filter": {"author": {"name": 1}}}`),
+			wantErr:   errors.New("variable $filter.author.name: expected a string, got 1"),
+		},
+		{
+			name:      "non-null fields of input objects must be given",
+			query:     `query ($filter: PostFilter) { posts { id } }`,
+			variables: json.RawMessage(`{"filter": {"author": {}}}`),
+			wantErr:   errors.New("variable $filter.author.name of non-null type String! is not provided"),
+		},
+		{
+			name:      "input objects must not have undefined fields",
+			query:     `query ($filter: PostFilter) { posts { id } }`,
+			variables: json.RawMessage(`{"filter": {"title": "title"}}`),
+			wantErr:   errors.New("variable $filter: field title is not defined by input type PostFilter"),
+		},
+		{
+			name:      "enum values must be defined",
+			query:     `query ($filter: PostFilter) { posts { id } }`,
+			variables: json.RawMessage(`{"filter": {"status": "DELETED"}}`),
+			wantErr:   errors.New(`variable $filter.status: "DELETED" is not a value of enum Status`),
+		},
+		{
+			name:    "default values which can't be coerced are rejected",
+			query:   `query ($first: Int = 2147483648) { posts { id } }`,
+			wantErr: errors.New("variable $first: expected a 32-bit signed integer, got 2147483648"),
+		},
+		{
+			name:      "values of custom scalars must be decoded into their Go types",
+			query:     `query ($afters: [DateTime!]) { posts { id } }`,
+			variables: json.RawMessage(`{"afters": ["2024-01-01T00:00:00Z", "nope"]}`),
+			wantErr:   errors.New(`variable $afters[1]: expected DateTime value, got "nope"`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := query.NewParserWithLexer().Parse([]byte(tt.query))
+			if err != nil {
+				t.Fatalf("Parse() error %v", err)
+			}
+
+			got, err := executor.CoerceVariableValues(s, doc.Operations[0], tt.variables)
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Fatalf("CoerceVariableValues() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("CoerceVariableValues() error %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Errorf("CoerceVariableValues() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
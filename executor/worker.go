@@ -0,0 +1,132 @@
+package executor
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultWorkerLimit is the number of goroutines which resolve the fields of a request concurrently by default.
+const DefaultWorkerLimit = 16
+
+type workerLimitKey struct{}
+
+// WithWorkerLimit returns ctx which resolves the fields of a request with at most n goroutines besides the goroutine of the request.
+// The generated resolver calls it for every request with the limit of WithWorkerLimit option, where n <= 0 resolves fields serially.
+func WithWorkerLimit(ctx context.Context, n int) context.Context {
+	var workers chan struct{}
+	if n > 0 {
+		workers = make(chan struct{}, n)
+	}
+
+	return context.WithValue(ctx, workerLimitKey{}, workers)
+}
+
+// workerGroup runs functions in the workers of a request and waits for them.
+type workerGroup struct {
+	workers chan struct{}
+
+	wg        sync.WaitGroup
+	mu        sync.Mutex
+	recovered any
+}
+
+// newWorkerGroup returns a group which runs functions in the workers of ctx.
+// Without a worker limit in ctx, the functions run serially.
+func newWorkerGroup(ctx context.Context) *workerGroup {
+	workers, _ := ctx.Value(workerLimitKey{}).(chan struct{})
+	return &workerGroup{
+		workers: workers,
+	}
+}
+
+// Go runs f in a new goroutine if a worker of the request is free, or in the calling goroutine otherwise.
+// Falling back to the calling goroutine bounds the goroutines of a request,
+// and never blocks a field whose children wait for workers held by its ancestors.
+func (g *workerGroup) Go(f func()) {
+	g.wg.Add(1)
+
+	select {
+	case g.workers <- struct{}{}:
+		go func() {
+			defer func() {
+				<-g.workers
+			}()
+
+			g.run(f)
+		}()
+	default:
+		g.run(f)
+	}
+}
+
+func (g *workerGroup) run(f func()) {
+	defer g.wg.Done()
+	// a panicking resolver panics in the goroutine which waits for the group as it does without concurrency
+	defer func() {
+		if p := recover(); p != nil {
+			g.mu.Lock()
+			g.recovered = p
+			g.mu.Unlock()
+		}
+	}()
+
+	f()
+}
+
+// Wait waits for the functions of the group, and panics with the panic of one of them if any.
+func (g *workerGroup) Wait() {
+	g.wg.Wait()
+
+	if g.recovered != nil {
+		panic(g.recovered)
+	}
+}
+
+// FieldGroup completes fields of an object concurrently, such as the fields resolved by field resolvers,
+// and sets their values in the response of the object in the order of the selection set.
+type FieldGroup struct {
+	resp   *OrderedMap
+	group  *workerGroup
+	mu     sync.Mutex
+	values map[string]any
+	nulled bool
+}
+
+// NewFieldGroup returns a group which completes fields into resp with the workers of ctx.
+func NewFieldGroup(ctx context.Context, resp *OrderedMap) *FieldGroup {
+	return &FieldGroup{
+		resp:   resp,
+		group:  newWorkerGroup(ctx),
+		values: make(map[string]any),
+	}
+}
+
+// Go completes the field at key with complete, which returns the value of the field and false if it nulls the object.
+// The position of key in the response is reserved at once, so that the fields keep the order of the selection set
+// however long they take.
+func (g *FieldGroup) Go(key string, complete func() (any, bool)) {
+	g.resp.Set(key, nil)
+	g.group.Go(func() {
+		value, ok := complete()
+
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		g.values[key] = value
+		if !ok {
+			g.nulled = true
+		}
+	})
+}
+
+// Wait waits for the fields of the group and sets their values in the response.
+// It returns false when one of the fields nulls the object.
+func (g *FieldGroup) Wait() bool {
+	g.group.Wait()
+
+	for key, value := range g.values {
+		g.resp.Set(key, value)
+	}
+
+	return !g.nulled
+}
@@ -0,0 +1,236 @@
+package executor_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+)
+
+// counter records the maximum number of functions running at once.
+type counter struct {
+	mu      sync.Mutex
+	running int
+	max     int
+}
+
+func (c *counter) run(d time.Duration) {
+	c.mu.Lock()
+	c.running++
+	c.max = max(c.max, c.running)
+	c.mu.Unlock()
+
+	time.Sleep(d)
+
+	c.mu.Lock()
+	c.running--
+	c.mu.Unlock()
+}
+
+func TestFieldGroup(t *testing.T) {
+	type field struct {
+		key   string
+		delay time.Duration
+		value any
+		ok    bool
+	}
+
+	tests := []struct {
+		name        string
+		workerLimit int
+		fields      []field
+		want        string
+		wantOK      bool
+		wantMax     int
+	}{
+		{
+			name:        "fields keep the order of the selection set however long they take",
+			workerLimit: 4,
+			fields: []field{
+				{key: "slow", delay: 30 * time.Millisecond, value: "a", ok: true},
+				{key: "fast", value: "b", ok: true},
+				{key: "medium", delay: 10 * time.Millisecond, value: "c", ok: true},
+			},
+			want:    `{"id":"1","slow":"a","fast":"b","medium":"c"}`,
+			wantOK:  true,
+			wantMax: 3,
+		},
+		{
+			name:        "fields are resolved by at most the worker limit goroutines besides the calling goroutine",
+			workerLimit: 1,
+			fields: []field{
+				{key: "a", delay: 10 * time.Millisecond, value: 1, ok: true},
+				{key: "b", delay: 10 * time.Millisecond, value: 2, ok: true},
+				{key: "c", delay: 10 * time.Millisecond, value: 3, ok: true},
+			},
+			want:    `{"id":"1","a":1,"b":2,"c":3}`,
+			wantOK:  true,
+			wantMax: 2,
+		},
+		{
+			name:        "fields are resolved serially without workers",
+			workerLimit: 0,
+			fields: []field{
+				{key: "a", delay: time.Millisecond, value: 1, ok: true},
+				{key: "b", delay: time.Millisecond, value: 2, ok: true},
+			},
+			want:    `{"id":"1","a":1,"b":2}`,
+			wantOK:  true,
+			wantMax: 1,
+		},
+		{
+			name:        "a field which nulls the object fails the group",
+			workerLimit: 4,
+			fields: []field{
+				{key: "a", value: 1, ok: true},
+				{key: "b", ok: false},
+			},
+			want:    `{"id":"1","a":1,"b":null}`,
+			wantOK:  false,
+			wantMax: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := executor.WithWorkerLimit(context.Background(), tt.workerLimit)
+			c := &counter{}
+
+			resp := executor.NewOrderedMap()
+			resp.Set("id", "1")
+
+			fields := executor.NewFieldGroup(ctx, resp)
+			for _, f := range tt.fields {
+				fields.Go(f.key, func() (any, bool) {
+					c.run(f.delay)
+					return f.value, f.ok
+				})
+			}
+
+			if got := fields.Wait(); got != tt.wantOK {
+				t.Errorf("Wait() = %v, want %v", got, tt.wantOK)
+			}
+
+			b, err := json.Marshal(resp)
+			if err != nil {
+				t.Fatalf("Marshal() error %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(b)); diff != "" {
+				t.Errorf("response mismatch (-want +got):\n%s", diff)
+			}
+
+			if c.max > tt.wantMax {
+				t.Errorf("%d fields ran at once, want at most %d", c.max, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestFieldGroup_NestedGroupsDoNotWaitForWorkers(t *testing.T) {
+	ctx := executor.WithWorkerLimit(context.Background(), 1)
+
+	done := make(chan bool)
+	go func() {
+		resp := executor.NewOrderedMap()
+		fields := executor.NewFieldGroup(ctx, resp)
+		for _, key := range []string{"a", "b"} {
+			fields.Go(key, func() (any, bool) {
+				// the children run in the goroutine of their parent while it holds the only worker
+				children := executor.NewOrderedMap()
+				group := executor.NewFieldGroup(ctx, children)
+				group.Go("child", func() (any, bool) {
+					return key, true
+				})
+
+				return children, group.Wait()
+			})
+		}
+
+		done <- fields.Wait()
+	}()
+
+	select {
+	case ok := <-done:
+		if !ok {
+			t.Error("Wait() = false, want true")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("nested field groups are deadlocked")
+	}
+}
+
+func TestFieldGroup_Panic(t *testing.T) {
+	ctx := executor.WithWorkerLimit(context.Background(), executor.DefaultWorkerLimit)
+
+	defer func() {
+		if p := recover(); p != "resolver panicked" {
+			t.Errorf("recover() = %v, want the panic of the field", p)
+		}
+	}()
+
+	fields := executor.NewFieldGroup(ctx, executor.NewOrderedMap())
+	fields.Go("a", func() (any, bool) {
+		panic("resolver panicked")
+	})
+	fields.Wait()
+}
+
+func TestCompleteListConcurrently(t *testing.T) {
+	tests := []struct {
+		name         string
+		values       []int
+		nonNullItems bool
+		want         any
+		wantErrors   executor.GraphQLErrors
+	}{
+		{
+			name:         "items keep their order however long they take",
+			values:       []int{3, 1, 2},
+			nonNullItems: true,
+			want:         []any{3, 1, 2},
+		},
+		{
+			name:         "a null non-null item nulls the list",
+			values:       []int{1, 0, 2},
+			nonNullItems: true,
+			want:         nil,
+			wantErrors: executor.GraphQLErrors{
+				executor.Errorf(executor.ErrorCodeInternal, "cannot return null for non-null field").WithPath(executor.PathKey("posts"), executor.PathIndex(1)),
+			},
+		},
+		{
+			name:   "a null nullable item is kept",
+			values: []int{1, 0},
+			want:   []any{1, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := executor.WithWorkerLimit(context.Background(), executor.DefaultWorkerLimit)
+			errs := executor.NewFieldErrors(nil)
+
+			got := executor.CompleteListConcurrently(ctx, errs, executor.Path{executor.PathKey("posts")}, nil, tt.nonNullItems, tt.values, func(path executor.Path, v int) any {
+				time.Sleep(time.Duration(v) * time.Millisecond)
+				if v == 0 {
+					return nil
+				}
+
+				return v
+			})
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CompleteListConcurrently() mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantErrors, errs.Errors()); diff != "" {
+				t.Errorf("errors mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
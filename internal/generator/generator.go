@@ -7,14 +7,18 @@ import (
 	"go/token"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/n9te9/goliteql/schema"
 )
 
 type Generator struct {
 	Schema              *schema.Schema
+	schemaSource        []byte
 	queryAST            *ast.File
 	mutationAST         *ast.File
 	subscriptionAST     *ast.File
@@ -29,13 +33,43 @@ type Generator struct {
 	mutationResolverOutput io.Writer
 	mutationResolverAST    *ast.File
 
+	subscriptionResolverOutput io.Writer
+	subscriptionResolverAST    *ast.File
+
 	rootResolverOutput io.Writer
 	resolverAST        *ast.File
+
+	// TypedResolvers generates the resolvers of queries and mutations as methods which take the arguments of their fields
+	// and return their results, instead of handlers which read and write them through HTTP.
+	TypedResolvers bool
+
+	// FieldResolvers maps the names of object types to the names of their fields which are resolved by field resolvers,
+	// in addition to the fields with arguments.
+	FieldResolvers map[string][]string
+
+	// objectTypes holds the object types of the schema.
+	objectTypes map[string]struct{}
+	// abstractTypes holds the unions and interfaces of the schema.
+	// Their Go types are interfaces, so they are never referred to through a pointer.
+	abstractTypes map[string]struct{}
+	// concurrentTypes holds the types whose fields are resolved by field resolvers, directly or through the fields of their fields,
+	// which the walkers resolve concurrently.
+	concurrentTypes map[string]struct{}
+	// scalarTypes holds the Go types which the custom scalars of the schema are mapped to.
+	scalarTypes map[string]scalarType
+
+	// fset is the file set to print the generated code.
+	// Every doc comment of a description is positioned in a file of its own in fset,
+	// as the printer separates a comment in another file by a blank line from the code before it.
+	fset *token.FileSet
 }
 
 var gqlFilePattern = regexp.MustCompile(`^.+\.gql$|^.+\.graphql$`)
 
-func NewGenerator(schemaDirectory string, modelOutput, queryResolverOutput, mutationResolverOutput, rootResolverOutput io.Writer, modelPackagePath, resolverPackagePath string) (*Generator, error) {
+// NewGenerator creates a generator of the schema in schemaDirectory.
+// scalars maps the name of every custom scalar of the schema to a Go type,
+// written as its import path and type name such as "time.Time" or "github.com/google/uuid.UUID".
+func NewGenerator(schemaDirectory string, modelOutput, queryResolverOutput, mutationResolverOutput, subscriptionResolverOutput, rootResolverOutput io.Writer, modelPackagePath, resolverPackagePath string, scalars map[string]string) (*Generator, error) {
 	gqlFilePaths := make([]string, 0)
 
 	err := filepath.Walk(schemaDirectory, func(path string, info os.FileInfo, err error) error {
@@ -55,6 +89,7 @@ func NewGenerator(schemaDirectory string, modelOutput, queryResolverOutput, muta
 	}
 
 	fileContents := make([]byte, 0)
+	sources := make([]*schema.Source, 0, len(gqlFilePaths))
 
 	for _, path := range gqlFilePaths {
 		file, err := os.Open(path)
@@ -70,11 +105,12 @@ func NewGenerator(schemaDirectory string, modelOutput, queryResolverOutput, muta
 		content = append(content, []byte("\n")...)
 
 		fileContents = append(fileContents, content...)
+		sources = append(sources, &schema.Source{Name: path, Body: content})
 	}
 
 	lexer := schema.NewLexer()
 	parser := schema.NewParser(lexer)
-	s, err := parser.Parse(fileContents)
+	s, err := parser.ParseSources(sources...)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing schema: %w", err)
 	}
@@ -84,6 +120,33 @@ func NewGenerator(schemaDirectory string, modelOutput, queryResolverOutput, muta
 		return nil, fmt.Errorf("error merging schema: %w", err)
 	}
 
+	if err := schema.Validate(s); err != nil {
+		return nil, fmt.Errorf("error validating schema: %w", err)
+	}
+
+	objectTypes := make(map[string]struct{})
+	for _, t := range s.Types {
+		objectTypes[string(t.Name)] = struct{}{}
+	}
+
+	abstractTypes := make(map[string]struct{})
+	for _, u := range s.Unions {
+		abstractTypes[string(u.Name)] = struct{}{}
+	}
+	for _, i := range s.Interfaces {
+		abstractTypes[string(i.Name)] = struct{}{}
+	}
+
+	scalarTypes := make(map[string]scalarType)
+	for _, scalar := range s.Scalars {
+		goType, ok := scalars[string(scalar.Name)]
+		if !ok {
+			return nil, fmt.Errorf("error mapping scalar %s: no Go type is configured", scalar.Name)
+		}
+
+		scalarTypes[string(scalar.Name)] = newScalarType(goType)
+	}
+
 	importDecl := &ast.GenDecl{
 		Tok: token.IMPORT,
 		Specs: []ast.Spec{
@@ -110,6 +173,7 @@ func NewGenerator(schemaDirectory string, modelOutput, queryResolverOutput, muta
 
 	g := &Generator{
 		Schema:          s,
+		schemaSource:    fileContents,
 		queryAST:        &ast.File{},
 		mutationAST:     &ast.File{},
 		subscriptionAST: &ast.File{},
@@ -121,22 +185,37 @@ func NewGenerator(schemaDirectory string, modelOutput, queryResolverOutput, muta
 		},
 		queryResolverAST: &ast.File{
 			Name: ast.NewIdent(filepath.Base(resolverPackagePath)),
-			Decls: []ast.Decl{
-				importDecl,
-			},
 		},
 		mutationResolverAST: &ast.File{
 			Name: ast.NewIdent(filepath.Base(resolverPackagePath)),
-			Decls: []ast.Decl{
-				importDecl,
-			},
 		},
-		modelOutput:            modelOutput,
-		modelPackagePath:       modelPackagePath,
-		queryResolverOutput:    queryResolverOutput,
-		mutationResolverOutput: mutationResolverOutput,
-		rootResolverOutput:     rootResolverOutput,
-		resolverPackagePath:    resolverPackagePath,
+		subscriptionResolverAST: &ast.File{
+			Name: ast.NewIdent(filepath.Base(resolverPackagePath)),
+		},
+		modelOutput:                modelOutput,
+		modelPackagePath:           modelPackagePath,
+		queryResolverOutput:        queryResolverOutput,
+		mutationResolverOutput:     mutationResolverOutput,
+		subscriptionResolverOutput: subscriptionResolverOutput,
+		rootResolverOutput:         rootResolverOutput,
+		resolverPackagePath:        resolverPackagePath,
+		objectTypes:                objectTypes,
+		abstractTypes:              abstractTypes,
+		scalarTypes:                scalarTypes,
+		fset:                       token.NewFileSet(),
+	}
+
+	g.queryResolverAST.Decls = []ast.Decl{g.withScalarImports(importDecl, s.GetQuery())}
+
+	// the mutation resolver file isn't written without a mutation.
+	if s.GetMutation() != nil {
+		g.mutationResolverAST.Decls = append(g.mutationResolverAST.Decls, g.withScalarImports(importDecl, s.GetMutation()))
+	}
+
+	// the subscription resolver file has no declarations without a subscription,
+	// so it must not import anything in that case.
+	if s.GetSubscription() != nil {
+		g.subscriptionResolverAST.Decls = append(g.subscriptionResolverAST.Decls, g.withScalarImports(importDecl, s.GetSubscription()))
 	}
 
 	return g, nil
@@ -145,21 +224,60 @@ func NewGenerator(schemaDirectory string, modelOutput, queryResolverOutput, muta
 func (g *Generator) Generate() error {
 	// generate resolver code
 	if err := g.generateResolver(); err != nil {
-		panic(err)
+		return err
 	}
 
 	if err := g.generateModel(); err != nil {
-		panic(err)
+		return err
 	}
 
 	return nil
 }
 
 func (g *Generator) generateModel() error {
-	g.modelAST.Decls = append(g.modelAST.Decls, generateModelImport())
+	importDecl := &ast.GenDecl{
+		Tok: token.IMPORT,
+	}
+
+	// the imports are only used to decode inputs, unions and interfaces.
+	if len(g.Schema.Inputs) > 0 || len(g.abstractTypes) > 0 {
+		importDecl = generateModelImport()
+	}
+
+	if len(g.abstractTypes) > 0 {
+		importDecl.Specs = append(importDecl.Specs, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"github.com/n9te9/goliteql/executor"`,
+			},
+		})
+	}
+
+	if len(g.scalarTypes) > 0 {
+		importDecl.Specs = append(importDecl.Specs, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"github.com/n9te9/goliteql/schema"`,
+			},
+		})
+		importDecl.Specs = g.appendScalarImports(importDecl.Specs, g.Schema.Scalars)
+	}
+
+	if len(importDecl.Specs) > 0 {
+		g.modelAST.Decls = append(g.modelAST.Decls, importDecl)
+	}
+
+	if len(g.scalarTypes) > 0 {
+		g.modelAST.Decls = append(g.modelAST.Decls, g.generateScalarRegistration(g.Schema.Scalars))
+	}
+
+	for _, e := range g.Schema.Enums {
+		g.modelAST.Decls = append(g.modelAST.Decls, g.generateEnum(e)...)
+	}
 
 	for _, input := range g.Schema.Inputs {
-		g.modelAST.Decls = append(g.modelAST.Decls, &ast.GenDecl{
+		g.modelAST.Decls = append(g.modelAST.Decls, g.positionDocs(&ast.GenDecl{
+			Doc: descriptionDoc(input.Description),
 			Tok: token.TYPE,
 			Specs: []ast.Spec{
 				&ast.TypeSpec{
@@ -167,17 +285,28 @@ func (g *Generator) generateModel() error {
 						Name: string(input.Name),
 					},
 					Type: &ast.StructType{
-						Fields: generateModelField(input.Fields),
+						Fields: g.generateModelField(input.Fields),
 					},
 				},
 			},
-		})
+		}))
+
+		g.modelAST.Decls = append(g.modelAST.Decls, g.generateInputModelUnmarshalJSON(input))
+	}
+
+	for _, u := range g.Schema.Unions {
+		g.modelAST.Decls = append(g.modelAST.Decls, g.generateAbstractTypeInterface(string(u.Name), u.Description))
+		g.modelAST.Decls = append(g.modelAST.Decls, generateAbstractTypeUnmarshal(string(u.Name), possibleTypes(g.Schema, string(u.Name))))
+	}
 
-		g.modelAST.Decls = append(g.modelAST.Decls, generateInputModelUnmarshalJSON(input))
+	for _, i := range g.Schema.Interfaces {
+		g.modelAST.Decls = append(g.modelAST.Decls, g.generateAbstractTypeInterface(string(i.Name), i.Description))
+		g.modelAST.Decls = append(g.modelAST.Decls, generateAbstractTypeUnmarshal(string(i.Name), possibleTypes(g.Schema, string(i.Name))))
 	}
 
 	for _, t := range g.Schema.Types {
-		g.modelAST.Decls = append(g.modelAST.Decls, &ast.GenDecl{
+		g.modelAST.Decls = append(g.modelAST.Decls, g.positionDocs(&ast.GenDecl{
+			Doc: descriptionDoc(t.Description),
 			Tok: token.TYPE,
 			Specs: []ast.Spec{
 				&ast.TypeSpec{
@@ -185,33 +314,60 @@ func (g *Generator) generateModel() error {
 						Name: string(t.Name),
 					},
 					Type: &ast.StructType{
-						Fields: generateModelField(t.Fields),
+						Fields: g.generateModelField(t.Fields),
 					},
 				},
 			},
-		})
+		}))
+
+		names := abstractTypeNames(g.Schema, t)
+		for _, name := range names {
+			g.modelAST.Decls = append(g.modelAST.Decls, generateAbstractTypeMethod(t, name))
+		}
+
+		if len(names) > 0 {
+			g.modelAST.Decls = append(g.modelAST.Decls, generateTypenameMarshalJSON(t))
+		}
+
+		if g.hasAbstractField(t.Fields) {
+			g.modelAST.Decls = append(g.modelAST.Decls, g.generateAbstractFieldUnmarshalJSON(t))
+		}
+
+		g.modelAST.Decls = append(g.modelAST.Decls, g.generateSelectionSetInput(string(t.Name), t.Fields)...)
 	}
 
 	if op := g.Schema.GetQuery(); op != nil {
-		g.modelAST.Decls = append(g.modelAST.Decls, generateSelectionSetInput(op.Fields)...)
+		g.modelAST.Decls = append(g.modelAST.Decls, g.generateSelectionSetInput("", op.Fields)...)
 	}
 
 	if op := g.Schema.GetMutation(); op != nil {
-		g.modelAST.Decls = append(g.modelAST.Decls, generateSelectionSetInput(op.Fields)...)
+		g.modelAST.Decls = append(g.modelAST.Decls, g.generateSelectionSetInput("", op.Fields)...)
 	}
 
 	if op := g.Schema.GetSubscription(); op != nil {
-		g.modelAST.Decls = append(g.modelAST.Decls, generateSelectionSetInput(op.Fields)...)
+		g.modelAST.Decls = append(g.modelAST.Decls, g.generateSelectionSetInput("", op.Fields)...)
 	}
 
-	format.Node(g.modelOutput, token.NewFileSet(), g.modelAST)
+	format.Node(g.modelOutput, g.fset, g.modelAST)
 
 	return nil
 }
 
 func (g *Generator) generateResolver() error {
-	if isUsedDefinedType(g.Schema.GetQuery()) || isUsedDefinedType(g.Schema.GetMutation()) || isUsedDefinedType(g.Schema.GetSubscription()) {
+	fieldResolvers, err := g.fieldResolvers()
+	if err != nil {
+		return err
+	}
+	g.concurrentTypes = concurrentTypeNames(g.Schema, fieldResolvers)
+
+	if g.isUsedDefinedType(g.Schema.GetQuery()) || g.isUsedDefinedType(g.Schema.GetMutation()) || g.isUsedDefinedType(g.Schema.GetSubscription()) {
 		importSpecs := []ast.Spec{
+			&ast.ImportSpec{
+				Path: &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: `"context"`,
+				},
+			},
 			&ast.ImportSpec{
 				Path: &ast.BasicLit{
 					Kind:  token.STRING,
@@ -242,10 +398,36 @@ func (g *Generator) generateResolver() error {
 					Value: `"github.com/n9te9/goliteql/executor"`,
 				},
 			},
+			&ast.ImportSpec{
+				Path: &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: `"github.com/n9te9/goliteql/executor/dataloader"`,
+				},
+			},
+			&ast.ImportSpec{
+				Path: &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: `"github.com/n9te9/goliteql/schema"`,
+				},
+			},
+			&ast.ImportSpec{
+				Path: &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: `"github.com/n9te9/goliteql/validator"`,
+				},
+			},
 		}
 
 		importSpecs = append(importSpecs, generateResolverImport().Specs...)
 
+		if g.TypedResolvers && g.Schema.GetSubscription() == nil {
+			// io and strings are used only by the response writers, which typed resolvers don't have.
+			importSpecs = slices.DeleteFunc(importSpecs, func(spec ast.Spec) bool {
+				path := spec.(*ast.ImportSpec).Path.Value
+				return path == `"io"` || path == `"strings"`
+			})
+		}
+
 		// generate import statement
 		g.resolverAST.Decls = append(g.resolverAST.Decls, &ast.GenDecl{
 			Tok:   token.IMPORT,
@@ -253,60 +435,104 @@ func (g *Generator) generateResolver() error {
 		})
 	}
 
-	g.resolverAST.Decls = append(g.resolverAST.Decls, generateResolverInterface(g.Schema.GetQuery(), g.Schema.GetMutation(), g.Schema.GetSubscription()))
+	fieldResolverTypes := make([]*schema.TypeDefinition, 0)
+	for _, t := range g.Schema.Types {
+		if len(fieldsResolvedBy(t, fieldResolvers)) > 0 {
+			fieldResolverTypes = append(fieldResolverTypes, t)
+		}
+	}
+
+	g.resolverAST.Decls = append(g.resolverAST.Decls, generateResolverInterface(g.Schema.GetQuery(), g.Schema.GetMutation(), g.Schema.GetSubscription(), fieldResolverTypes))
 
 	queryFields := make(schema.FieldDefinitions, 0)
 	mutationFields := make(schema.FieldDefinitions, 0)
-	fields := make(schema.FieldDefinitions, 0)
+	subscriptionFields := make(schema.FieldDefinitions, 0)
 
 	if q := g.Schema.GetQuery(); q != nil {
 		queryFields = q.Fields
-		g.resolverAST.Decls = append(g.resolverAST.Decls, generateQueryExecutor(g.Schema.GetQuery()))
-		g.resolverAST.Decls = append(g.resolverAST.Decls, generateWrapResponseWriter(g.Schema.GetQuery())...)
+		g.resolverAST.Decls = append(g.resolverAST.Decls, g.generateQueryExecutor(g.Schema.GetQuery(), g.TypedResolvers))
+		if !g.TypedResolvers {
+			g.resolverAST.Decls = append(g.resolverAST.Decls, g.generateWrapResponseWriter(g.Schema.GetQuery())...)
+		}
 	}
 
 	if m := g.Schema.GetMutation(); m != nil {
 		mutationFields = m.Fields
-		g.resolverAST.Decls = append(g.resolverAST.Decls, generateMutationExecutor(g.Schema.GetMutation()))
-		g.resolverAST.Decls = append(g.resolverAST.Decls, generateWrapResponseWriter(g.Schema.GetMutation())...)
+		g.resolverAST.Decls = append(g.resolverAST.Decls, g.generateMutationExecutor(g.Schema.GetMutation(), g.TypedResolvers))
+		if !g.TypedResolvers {
+			g.resolverAST.Decls = append(g.resolverAST.Decls, g.generateWrapResponseWriter(g.Schema.GetMutation())...)
+		}
 	}
 
 	if s := g.Schema.GetSubscription(); s != nil {
-		fields = append(fields, s.Fields...)
-		g.resolverAST.Decls = append(g.resolverAST.Decls, generateSubscriptionExecutor(g.Schema.GetSubscription()))
-		g.resolverAST.Decls = append(g.resolverAST.Decls, generateWrapResponseWriter(g.Schema.GetSubscription())...)
+		subscriptionFields = s.Fields
+		g.resolverAST.Decls = append(g.resolverAST.Decls, g.generateSubscriptionExecutor(g.Schema.GetSubscription()))
+		g.resolverAST.Decls = append(g.resolverAST.Decls, g.generateWrapResponseWriter(g.Schema.GetSubscription())...)
+	}
+
+	if g.TypedResolvers {
+		// typed resolvers import context instead of net/http, and the model package only if they refer to it.
+		g.queryResolverAST.Decls = []ast.Decl{g.generateTypedResolverImport(g.modelPackagePath, g.Schema.GetQuery())}
+		if g.Schema.GetMutation() != nil {
+			g.mutationResolverAST.Decls = []ast.Decl{g.generateTypedResolverImport(g.modelPackagePath, g.Schema.GetMutation())}
+		}
 	}
 
 	if g.Schema.GetQuery() != nil {
-		g.queryResolverAST.Decls = append(g.queryResolverAST.Decls, generateInterfaceField(g.Schema.GetQuery()))
+		g.queryResolverAST.Decls = append(g.queryResolverAST.Decls, g.generateInterfaceField(g.Schema.GetQuery(), g.TypedResolvers))
 	}
 
 	if g.Schema.GetMutation() != nil {
-		g.mutationResolverAST.Decls = append(g.mutationResolverAST.Decls, generateInterfaceField(g.Schema.GetMutation()))
+		g.mutationResolverAST.Decls = append(g.mutationResolverAST.Decls, g.generateInterfaceField(g.Schema.GetMutation(), g.TypedResolvers))
 	}
 
-	g.resolverAST.Decls = append(g.resolverAST.Decls, generateResolverImplementationStruct()...)
-	g.resolverAST.Decls = append(g.resolverAST.Decls, generateResolverImplementation(fields)...)
+	if g.Schema.GetSubscription() != nil {
+		g.subscriptionResolverAST.Decls = append(g.subscriptionResolverAST.Decls, g.generateInterfaceField(g.Schema.GetSubscription(), false))
+	}
 
-	g.queryResolverAST.Decls = append(g.queryResolverAST.Decls, generateResolverImplementation(queryFields)...)
-	g.mutationResolverAST.Decls = append(g.mutationResolverAST.Decls, generateResolverImplementation(mutationFields)...)
+	g.resolverAST.Decls = append(g.resolverAST.Decls, generateSchemaSource(g.schemaSource))
+	g.resolverAST.Decls = append(g.resolverAST.Decls, generateResolverImplementationStruct(len(g.Schema.Scalars) > 0)...)
+
+	if g.TypedResolvers {
+		g.queryResolverAST.Decls = append(g.queryResolverAST.Decls, g.generateTypedResolverImplementation(queryFields)...)
+		g.mutationResolverAST.Decls = append(g.mutationResolverAST.Decls, g.generateTypedResolverImplementation(mutationFields)...)
+	} else {
+		g.queryResolverAST.Decls = append(g.queryResolverAST.Decls, g.generateResolverImplementation(queryFields)...)
+		g.mutationResolverAST.Decls = append(g.mutationResolverAST.Decls, g.generateResolverImplementation(mutationFields)...)
+	}
+	g.subscriptionResolverAST.Decls = append(g.subscriptionResolverAST.Decls, g.generateSubscriptionResolverImplementation(subscriptionFields)...)
 
 	g.resolverAST.Decls = append(g.resolverAST.Decls, generateResolverServeHTTP(g.Schema.GetQuery(), g.Schema.GetMutation(), g.Schema.GetSubscription()))
+	g.resolverAST.Decls = append(g.resolverAST.Decls, generateSubscribe(g.Schema.GetSubscription()))
+
+	if len(fieldResolverTypes) > 0 {
+		// field resolvers are written to the query resolver file, as they resolve the fields of query results.
+		g.queryResolverAST.Decls[0] = withImports(g.queryResolverAST.Decls[0].(*ast.GenDecl), `"context"`, fmt.Sprintf(`"%s"`, g.modelPackagePath))
+		for _, t := range fieldResolverTypes {
+			fields := fieldsResolvedBy(t, fieldResolvers)
+			g.queryResolverAST.Decls = append(g.queryResolverAST.Decls, g.generateFieldResolverInterface(t, fields))
+			g.queryResolverAST.Decls = append(g.queryResolverAST.Decls, g.generateFieldResolverImplementation(t, fields)...)
+		}
+	}
 
-	g.resolverAST.Decls = append(g.resolverAST.Decls, generateResponseStructForWrapResponseWriter(g.Schema.Indexes.TypeIndex, g.Schema.GetQuery())...)
-	g.resolverAST.Decls = append(g.resolverAST.Decls, generateResponseStructForWrapResponseWriter(g.Schema.Indexes.TypeIndex, g.Schema.GetMutation())...)
-	g.resolverAST.Decls = append(g.resolverAST.Decls, generateResponseStructForWrapResponseWriter(g.Schema.Indexes.TypeIndex, g.Schema.GetSubscription())...)
+	g.resolverAST.Decls = append(g.resolverAST.Decls, g.generateWalkers(g.Schema, fieldResolvers)...)
 
-	if err := format.Node(g.rootResolverOutput, token.NewFileSet(), g.resolverAST); err != nil {
+	if err := format.Node(g.rootResolverOutput, g.fset, g.resolverAST); err != nil {
 		return fmt.Errorf("error formatting resolver: %w", err)
 	}
 
-	if err := format.Node(g.queryResolverOutput, token.NewFileSet(), g.queryResolverAST); err != nil {
+	if err := format.Node(g.queryResolverOutput, g.fset, g.queryResolverAST); err != nil {
 		return fmt.Errorf("error formatting query resolver: %w", err)
 	}
 
-	if err := format.Node(g.mutationResolverOutput, token.NewFileSet(), g.mutationResolverAST); err != nil {
-		return fmt.Errorf("error formatting mutation resolver: %w", err)
+	if g.Schema.GetMutation() != nil {
+		if err := format.Node(g.mutationResolverOutput, g.fset, g.mutationResolverAST); err != nil {
+			return fmt.Errorf("error formatting mutation resolver: %w", err)
+		}
+	}
+
+	if err := format.Node(g.subscriptionResolverOutput, g.fset, g.subscriptionResolverAST); err != nil {
+		return fmt.Errorf("error formatting subscription resolver: %w", err)
 	}
 
 	return nil
@@ -314,6 +540,49 @@ func (g *Generator) generateResolver() error {
 
 type GraphQLType string
 
+type scalarType struct {
+	importPath string
+	name       string
+}
+
+// newScalarType parses goType such as "github.com/google/uuid.UUID",
+// whose type name is qualified by the last element of the import path.
+// Predeclared types such as "string" have no import path.
+func newScalarType(goType string) scalarType {
+	i := strings.LastIndex(goType, ".")
+	if i < 0 {
+		return scalarType{name: goType}
+	}
+
+	importPath := goType[:i]
+	return scalarType{
+		importPath: importPath,
+		name:       path.Base(importPath) + goType[i:],
+	}
+}
+
+func (g *Generator) isObject(t GraphQLType) bool {
+	_, ok := g.objectTypes[string(t)]
+	return ok
+}
+
+func (g *Generator) isAbstract(t GraphQLType) bool {
+	_, ok := g.abstractTypes[string(t)]
+	return ok
+}
+
+// isConcurrent reports whether the fields of t are resolved concurrently, so are the items of its lists.
+func (g *Generator) isConcurrent(t GraphQLType) bool {
+	_, ok := g.concurrentTypes[string(t)]
+	return ok
+}
+
+// isScalar reports whether t is a custom scalar, whose Go type is not declared in the model package.
+func (g *Generator) isScalar(t GraphQLType) bool {
+	_, ok := g.scalarTypes[string(t)]
+	return ok
+}
+
 func (g GraphQLType) IsPrimitive() bool {
 	switch g {
 	case "Int", "Float", "String", "Boolean", "ID":
@@ -323,8 +592,8 @@ func (g GraphQLType) IsPrimitive() bool {
 	}
 }
 
-func (g GraphQLType) golangType() string {
-	switch g {
+func (g *Generator) golangType(t GraphQLType) string {
+	switch t {
 	case "Int":
 		return "int"
 	case "Float":
@@ -336,10 +605,197 @@ func (g GraphQLType) golangType() string {
 	case "ID":
 		return "string"
 	default:
-		return string(g)
+		if t, ok := g.scalarTypes[string(t)]; ok {
+			return t.name
+		}
+
+		return string(t)
 	}
 }
 
+// goTypeString returns the Go type of fieldType, qualifying the types of the schema with pkg.
+// Models declare nullable lists as pointers, while the responses of resolvers don't.
+func (g *Generator) goTypeString(fieldType *schema.FieldType, pkg string, nullableListIsPointer bool) string {
+	if fieldType.IsList {
+		typeString := "[]" + g.goTypeString(fieldType.ListType, pkg, nullableListIsPointer)
+		if fieldType.Nullable && nullableListIsPointer {
+			return "*" + typeString
+		}
+
+		return typeString
+	}
+
+	graphQLType := GraphQLType(fieldType.Name)
+	typeString := g.golangType(graphQLType)
+	if !graphQLType.IsPrimitive() && !g.isScalar(graphQLType) {
+		typeString = pkg + typeString
+	}
+
+	if fieldType.Nullable && !g.isAbstract(graphQLType) {
+		return "*" + typeString
+	}
+
+	return typeString
+}
+
+// possibleTypes returns the object types which belong to the union or interface named name.
+func possibleTypes(s *schema.Schema, name string) []*schema.TypeDefinition {
+	res := make([]*schema.TypeDefinition, 0)
+
+	if u := s.Indexes.GetUnionDefinition(name); u != nil {
+		for _, member := range u.Types {
+			if t := s.Indexes.GetTypeDefinition(string(member)); t != nil {
+				res = append(res, t)
+			}
+		}
+
+		return res
+	}
+
+	for _, t := range s.Types {
+		for _, i := range t.Interfaces {
+			if string(i.Name) == name {
+				res = append(res, t)
+				break
+			}
+		}
+	}
+
+	return res
+}
+
+// abstractTypeNames returns the names of the interfaces and unions t belongs to.
+func abstractTypeNames(s *schema.Schema, t *schema.TypeDefinition) []string {
+	res := make([]string, 0)
+
+	for _, i := range t.Interfaces {
+		res = append(res, string(i.Name))
+	}
+
+	for _, u := range s.Unions {
+		if u.HasType(string(t.Name)) {
+			res = append(res, string(u.Name))
+		}
+	}
+
+	return res
+}
+
+// descriptionDoc returns description, the description of a definition in the schema, as a doc comment,
+// or nil if the definition doesn't have a description. The declaration holding it is positioned by positionDocs.
+func descriptionDoc(description []byte) *ast.CommentGroup {
+	if len(description) == 0 {
+		return nil
+	}
+
+	comments := make([]*ast.Comment, 0)
+	for _, line := range strings.Split(string(description), "\n") {
+		comments = append(comments, &ast.Comment{Text: strings.TrimRight("// "+line, " ")})
+	}
+	return &ast.CommentGroup{List: comments}
+}
+
+// docPositions hands out the positions of a block, such as a struct or a const declaration, one line after another
+// in a file of its own in g.fset, as the printer writes the doc comments of the elements of a block above them
+// with their indentation only by their positions.
+type docPositions struct {
+	file *token.File
+	line int
+}
+
+// newDocPositions returns the positions of a block of lines in a new file of g.fset.
+func (g *Generator) newDocPositions(lines int) *docPositions {
+	file := g.fset.AddFile(fmt.Sprintf("block%d", g.fset.Base()), -1, lines)
+	offsets := make([]int, lines)
+	for i := range offsets {
+		offsets[i] = i
+	}
+	file.SetLines(offsets)
+
+	return &docPositions{file: file}
+}
+
+func (p *docPositions) next() token.Pos {
+	p.line++
+	return p.file.LineStart(p.line)
+}
+
+// doc positions every line of doc on a line of its own, if doc isn't nil.
+func (p *docPositions) doc(doc *ast.CommentGroup) {
+	if doc == nil {
+		return
+	}
+
+	for _, c := range doc.List {
+		c.Slash = p.next()
+	}
+}
+
+// positionDocs positions decl, a type or a const declaration, on lines of a file of its own in g.fset
+// with its doc comment and the doc comments of its fields, methods or values, and returns decl.
+// The printer writes a doc comment above its element only when they are positioned on lines of the same file.
+// decl is left without positions when it has no doc comment at all.
+func (g *Generator) positionDocs(decl *ast.GenDecl) *ast.GenDecl {
+	docs := make([]*ast.CommentGroup, 0)
+	names := make([]*ast.Ident, 0)
+	var opening, closing *token.Pos
+
+	switch decl.Tok {
+	case token.CONST:
+		for _, spec := range decl.Specs {
+			spec := spec.(*ast.ValueSpec)
+			docs = append(docs, spec.Doc)
+			names = append(names, spec.Names[0])
+		}
+		opening, closing = &decl.Lparen, &decl.Rparen
+	case token.TYPE:
+		var list *ast.FieldList
+		switch t := decl.Specs[0].(*ast.TypeSpec).Type.(type) {
+		case *ast.StructType:
+			list = t.Fields
+		case *ast.InterfaceType:
+			list = t.Methods
+		}
+
+		if list != nil {
+			for _, f := range list.List {
+				docs = append(docs, f.Doc)
+				names = append(names, f.Names[0])
+			}
+			opening, closing = &list.Opening, &list.Closing
+		}
+	default:
+		return decl
+	}
+
+	// the declaration, the closing line and a line for every element
+	lines := len(names) + 2
+	for _, doc := range append(docs, decl.Doc) {
+		if doc != nil {
+			lines += len(doc.List)
+		}
+	}
+	if lines == len(names)+2 {
+		return decl
+	}
+
+	p := g.newDocPositions(lines)
+	p.doc(decl.Doc)
+	decl.TokPos = p.next()
+	if opening == nil {
+		return decl
+	}
+
+	*opening = decl.TokPos
+	for i, name := range names {
+		p.doc(docs[i])
+		name.NamePos = p.next()
+	}
+	*closing = p.next()
+
+	return decl
+}
+
 func toUpperCase(s string) string {
 	return string(s[0]-32) + s[1:]
 }
@@ -363,3 +819,193 @@ func (f FieldName) ExportedGolangFieldName() string {
 
 	panic(fmt.Sprintf("invalid field name: %s", f))
 }
+
+// appendScalarImports appends the import paths of the Go types of scalars to specs,
+// skipping the paths which specs already imports.
+func (g *Generator) appendScalarImports(specs []ast.Spec, scalars []*schema.ScalarDefinition) []ast.Spec {
+	imported := make(map[string]struct{})
+	for _, spec := range specs {
+		imported[spec.(*ast.ImportSpec).Path.Value] = struct{}{}
+	}
+
+	for _, scalar := range scalars {
+		t := g.scalarTypes[string(scalar.Name)]
+		if t.importPath == "" {
+			continue
+		}
+
+		value := fmt.Sprintf(`"%s"`, t.importPath)
+		if _, ok := imported[value]; ok {
+			continue
+		}
+		imported[value] = struct{}{}
+
+		specs = append(specs, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: value,
+			},
+		})
+	}
+
+	return specs
+}
+
+// withScalarImports returns a copy of importDecl which also imports
+// the Go types of the custom scalars returned by the fields of op.
+func (g *Generator) withScalarImports(importDecl *ast.GenDecl, op *schema.OperationDefinition) *ast.GenDecl {
+	if op == nil {
+		return importDecl
+	}
+
+	scalars := make([]*schema.ScalarDefinition, 0)
+	for _, field := range op.Fields {
+		name := field.Type.GetPremitiveType().Name
+		if g.isScalar(GraphQLType(name)) {
+			scalars = append(scalars, &schema.ScalarDefinition{Name: name})
+		}
+	}
+
+	if len(scalars) == 0 {
+		return importDecl
+	}
+
+	return &ast.GenDecl{
+		Tok:   importDecl.Tok,
+		Specs: g.appendScalarImports(append([]ast.Spec{}, importDecl.Specs...), scalars),
+	}
+}
+
+// withImports returns importDecl with paths, which are quoted import paths, added unless importDecl has them.
+func withImports(importDecl *ast.GenDecl, paths ...string) *ast.GenDecl {
+	res := &ast.GenDecl{
+		Tok:   importDecl.Tok,
+		Specs: append([]ast.Spec{}, importDecl.Specs...),
+	}
+
+	for _, path := range paths {
+		if !slices.ContainsFunc(res.Specs, func(spec ast.Spec) bool {
+			return spec.(*ast.ImportSpec).Path.Value == path
+		}) {
+			res.Specs = append(res.Specs, &ast.ImportSpec{
+				Path: &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: path,
+				},
+			})
+		}
+	}
+
+	return res
+}
+
+// fieldResolvers returns the fields of the object types which are resolved by field resolvers,
+// the fields with arguments and the fields in FieldResolvers.
+// The names of their methods must not conflict with each other or with the resolvers of root fields.
+func (g *Generator) fieldResolvers() (map[*schema.FieldDefinition]struct{}, error) {
+	res := make(map[*schema.FieldDefinition]struct{})
+	for typeName, fieldNames := range g.FieldResolvers {
+		t, ok := g.Schema.Indexes.TypeIndex[typeName]
+		if !ok {
+			return nil, fmt.Errorf("error configuring field resolvers: object type %s is not defined", typeName)
+		}
+
+		for _, fieldName := range fieldNames {
+			f := t.GetFieldByName([]byte(fieldName))
+			if f == nil {
+				return nil, fmt.Errorf("error configuring field resolvers: field %s.%s is not defined", typeName, fieldName)
+			}
+			res[f] = struct{}{}
+		}
+	}
+
+	methods := make(map[string]string)
+	for _, op := range []*schema.OperationDefinition{g.Schema.GetQuery(), g.Schema.GetMutation(), g.Schema.GetSubscription()} {
+		if op == nil {
+			continue
+		}
+
+		for _, f := range op.Fields {
+			methods[toUpperCase(string(f.Name))] = fmt.Sprintf("root field %s", f.Name)
+		}
+	}
+
+	for _, t := range g.Schema.Types {
+		for _, f := range t.Fields {
+			if len(f.Arguments) > 0 {
+				res[f] = struct{}{}
+			}
+
+			if _, ok := res[f]; !ok {
+				continue
+			}
+
+			name := fieldResolverName(t, f)
+			if other, ok := methods[name]; ok {
+				return nil, fmt.Errorf("error generating field resolvers: resolver %s of field %s.%s conflicts with the resolver of %s", name, t.Name, f.Name, other)
+			}
+			methods[name] = fmt.Sprintf("field %s.%s", t.Name, f.Name)
+		}
+	}
+
+	return res, nil
+}
+
+// concurrentTypeNames returns the names of the types of s whose fields are resolved by fieldResolvers,
+// directly or through the fields of their fields. A union or an interface is concurrent if one of its possible types is.
+func concurrentTypeNames(s *schema.Schema, fieldResolvers map[*schema.FieldDefinition]struct{}) map[string]struct{} {
+	res := make(map[string]struct{})
+	add := func(name string) bool {
+		if _, ok := res[name]; ok {
+			return false
+		}
+
+		res[name] = struct{}{}
+		return true
+	}
+
+	abstractTypeNames := make([]string, 0, len(s.Unions)+len(s.Interfaces))
+	for _, u := range s.Unions {
+		abstractTypeNames = append(abstractTypeNames, string(u.Name))
+	}
+	for _, i := range s.Interfaces {
+		abstractTypeNames = append(abstractTypeNames, string(i.Name))
+	}
+
+	// types are added until no more types are found, as the fields of a type may refer to types which are added later.
+	for changed := true; changed; {
+		changed = false
+
+		for _, t := range s.Types {
+			for _, f := range t.Fields {
+				_, resolved := fieldResolvers[f]
+				_, concurrent := res[string(f.Type.GetPremitiveType().Name)]
+				if (resolved || concurrent) && add(string(t.Name)) {
+					changed = true
+				}
+			}
+		}
+
+		for _, name := range abstractTypeNames {
+			for _, t := range possibleTypes(s, name) {
+				if _, ok := res[string(t.Name)]; ok && add(name) {
+					changed = true
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+// fieldsResolvedBy returns the fields of t in fieldResolvers in the order of their definitions.
+func fieldsResolvedBy(t *schema.TypeDefinition, fieldResolvers map[*schema.FieldDefinition]struct{}) schema.FieldDefinitions {
+	res := make(schema.FieldDefinitions, 0)
+	for _, f := range t.Fields {
+		if _, ok := fieldResolvers[f]; ok {
+			res = append(res, f)
+		}
+	}
+
+	return res
+}
@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -19,6 +22,7 @@ func TestGenerator_Generate(t *testing.T) {
 		modelPackagePath    string
 		resolverOutput      *bytes.Buffer
 		resolverPackagePath string
+		scalars             map[string]string
 		expected            error
 		expectGoFilePath    string
 	}{
@@ -42,12 +46,46 @@ func TestGenerator_Generate(t *testing.T) {
 			expected:            nil,
 			expectGoFilePath:    "../golden_files/operation_test/model.go",
 		},
+		{
+			name:                "Generate union and interface code",
+			schemaDirectory:     "../golden_files/abstract_test",
+			modelOutput:         bytes.NewBuffer(nil),
+			modelPackagePath:    "github.com/n9te9/goliteql/internal/generated/model",
+			resolverOutput:      bytes.NewBuffer(nil),
+			resolverPackagePath: "github.com/n9te9/goliteql/internal/generated/resolver",
+			expected:            nil,
+			expectGoFilePath:    "../golden_files/abstract_test/model.go",
+		},
+		{
+			name:                "Generate enum code",
+			schemaDirectory:     "../golden_files/enum_test",
+			modelOutput:         bytes.NewBuffer(nil),
+			modelPackagePath:    "github.com/n9te9/goliteql/internal/generated/model",
+			resolverOutput:      bytes.NewBuffer(nil),
+			resolverPackagePath: "github.com/n9te9/goliteql/internal/generated/resolver",
+			expected:            nil,
+			expectGoFilePath:    "../golden_files/enum_test/model.go",
+		},
+		{
+			name:                "Generate custom scalar code",
+			schemaDirectory:     "../golden_files/scalar_test",
+			modelOutput:         bytes.NewBuffer(nil),
+			modelPackagePath:    "github.com/n9te9/goliteql/internal/generated/model",
+			resolverOutput:      bytes.NewBuffer(nil),
+			resolverPackagePath: "github.com/n9te9/goliteql/internal/generated/resolver",
+			scalars: map[string]string{
+				"DateTime": "time.Time",
+				"JSON":     "encoding/json.RawMessage",
+			},
+			expected:         nil,
+			expectGoFilePath: "../golden_files/scalar_test/model.go",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			fmt.Println(filepath.Abs(tt.schemaDirectory))
-			generator, err := generator.NewGenerator(tt.schemaDirectory, tt.modelOutput, tt.resolverOutput, tt.resolverOutput, tt.resolverOutput, tt.modelPackagePath, tt.resolverPackagePath)
+			generator, err := generator.NewGenerator(tt.schemaDirectory, tt.modelOutput, tt.resolverOutput, tt.resolverOutput, tt.resolverOutput, tt.resolverOutput, tt.modelPackagePath, tt.resolverPackagePath, tt.scalars)
 			if err != nil {
 				t.Fatalf("error creating generator: %v", err)
 			}
@@ -68,3 +106,234 @@ func TestGenerator_Generate(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerator_Generate_Generators(t *testing.T) {
+	tests := []struct {
+		schemaDirectory  string
+		scalars          map[string]string
+		expectGoFilePath string
+	}{
+		{
+			schemaDirectory:  "../golden_files/abstract_test",
+			expectGoFilePath: "../golden_files/abstract_test/model.go",
+		},
+		{
+			schemaDirectory:  "../golden_files/enum_test",
+			expectGoFilePath: "../golden_files/enum_test/model.go",
+		},
+		{
+			schemaDirectory: "../golden_files/scalar_test",
+			scalars: map[string]string{
+				"DateTime": "time.Time",
+				"JSON":     "encoding/json.RawMessage",
+			},
+			expectGoFilePath: "../golden_files/scalar_test/model.go",
+		},
+	}
+
+	// every generator is created before any of them generates, and they generate at once,
+	// so that a generator depending on the schema of another one generates a different model
+	generators := make([]*generator.Generator, len(tests))
+	outputs := make([]*bytes.Buffer, len(tests))
+	for i, tt := range tests {
+		outputs[i] = bytes.NewBuffer(nil)
+		resolverOutput := bytes.NewBuffer(nil)
+
+		g, err := generator.NewGenerator(tt.schemaDirectory, outputs[i], resolverOutput, resolverOutput, resolverOutput, resolverOutput, "github.com/n9te9/goliteql/internal/generated/model", "github.com/n9te9/goliteql/internal/generated/resolver", tt.scalars)
+		if err != nil {
+			t.Fatalf("error creating generator of %s: %v", tt.schemaDirectory, err)
+		}
+		generators[i] = g
+	}
+
+	errs := make([]error, len(tests))
+	var wg sync.WaitGroup
+	for i, g := range generators {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = g.Generate()
+		}()
+	}
+	wg.Wait()
+
+	for i, tt := range tests {
+		if errs[i] != nil {
+			t.Fatalf("error generating %s: %v", tt.schemaDirectory, errs[i])
+		}
+
+		expectedContent, err := os.ReadFile(tt.expectGoFilePath)
+		if err != nil {
+			t.Fatalf("error reading file: %v", err)
+		}
+
+		if diff := cmp.Diff(string(expectedContent), outputs[i].String()); diff != "" {
+			t.Errorf("model of %s mismatch (-want +got):\n%s", tt.schemaDirectory, diff)
+		}
+	}
+}
+
+func TestGenerator_Generate_Build(t *testing.T) {
+	if testing.Short() {
+		t.Skip("building the generated code runs the go command")
+	}
+
+	tests := []struct {
+		name            string
+		schemaDirectory string
+		typedResolvers  bool
+		fieldResolvers  map[string][]string
+		scalars         map[string]string
+		// contains are pieces of the generated resolvers which the mode of the case must generate
+		contains []string
+		// files maps generated files to the files in testdata which replace them, such as implemented resolvers,
+		// or are added next to them, such as tests of the generated server, which are run then
+		files map[string]string
+	}{
+		{
+			name:            "Generate resolvers of a schema without mutation",
+			schemaDirectory: "../golden_files/abstract_test",
+		},
+		{
+			name:            "Generate typed resolvers of a schema without mutation",
+			schemaDirectory: "../golden_files/abstract_test",
+			typedResolvers:  true,
+		},
+		{
+			name:            "Generate resolvers",
+			schemaDirectory: "../golden_files/resolver_test",
+			scalars:         map[string]string{"DateTime": "time.Time"},
+			contains: []string{
+				"func (r *resolver) Posts(w http.ResponseWriter, req *http.Request)",
+				"executor.DecodeArgumentValues[model.UserPostsArgs](r.schema",
+			},
+		},
+		{
+			name:            "Generate typed resolvers with arguments of nested fields",
+			schemaDirectory: "../golden_files/resolver_test",
+			typedResolvers:  true,
+			scalars:         map[string]string{"DateTime": "time.Time"},
+			contains: []string{
+				"Posts(ctx context.Context, args model.PostsArgs) ([]model.Post, error)",
+				"UserPosts(ctx context.Context, obj *model.User, args model.UserPostsArgs) ([]model.Post, error)",
+				"data, err := r.UserPosts(ctx, v, args)",
+			},
+		},
+		{
+			name:            "Generate typed resolvers with field resolvers walked concurrently",
+			schemaDirectory: "../golden_files/resolver_test",
+			typedResolvers:  true,
+			fieldResolvers:  map[string][]string{"Post": {"author"}, "User": {"posts"}},
+			scalars:         map[string]string{"DateTime": "time.Time"},
+			contains: []string{
+				"PostAuthor(ctx context.Context, obj *model.Post) (*model.User, error)",
+				"data, err := r.PostAuthor(ctx, v)",
+				"fields := executor.NewFieldGroup(ctx, resp)",
+				"return executor.CompleteListConcurrently(req.Context(), errs, path, node.Loc, true, data, func(path executor.Path, v model.Post) any {",
+			},
+		},
+		{
+			name:            "Generate resolvers serving invalid enum values as field errors and rejecting invalid custom scalar variables",
+			schemaDirectory: "../golden_files/resolver_test",
+			scalars:         map[string]string{"DateTime": "time.Time"},
+			files: map[string]string{
+				"resolver/query.resolver.go": "testdata/server/query.resolver.go",
+				"resolver/server_test.go":    "testdata/server/server_test.go",
+			},
+		},
+		{
+			name:            "Generate resolvers with field resolvers walked concurrently",
+			schemaDirectory: "../golden_files/resolver_test",
+			fieldResolvers:  map[string][]string{"Post": {"author"}, "User": {"posts"}},
+			scalars:         map[string]string{"DateTime": "time.Time"},
+			contains: []string{
+				"data, err := r.PostAuthor(ctx, v)",
+				"fields.Go(string(sel.ResponseKey()), func() (any, bool) {",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the generated packages are built in this module, which provides the packages they import,
+			// in a directory ignored by ./... patterns
+			dir, err := os.MkdirTemp(".", "_build")
+			if err != nil {
+				t.Fatalf("error creating directory: %v", err)
+			}
+			t.Cleanup(func() {
+				os.RemoveAll(dir)
+			})
+
+			dir = filepath.Base(dir)
+			pkg := "github.com/n9te9/goliteql/internal/generator/" + dir
+			outputs := map[string]*bytes.Buffer{
+				"model/model.go":                    bytes.NewBuffer(nil),
+				"resolver/query.resolver.go":        bytes.NewBuffer(nil),
+				"resolver/mutate.resolver.go":       bytes.NewBuffer(nil),
+				"resolver/subscription.resolver.go": bytes.NewBuffer(nil),
+				"resolver/resolver.go":              bytes.NewBuffer(nil),
+			}
+
+			g, err := generator.NewGenerator(tt.schemaDirectory, outputs["model/model.go"], outputs["resolver/query.resolver.go"], outputs["resolver/mutate.resolver.go"], outputs["resolver/subscription.resolver.go"], outputs["resolver/resolver.go"], pkg+"/model", pkg+"/resolver", tt.scalars)
+			if err != nil {
+				t.Fatalf("error creating generator: %v", err)
+			}
+			g.TypedResolvers = tt.typedResolvers
+			g.FieldResolvers = tt.fieldResolvers
+
+			if err := g.Generate(); err != nil {
+				t.Fatalf("error generating code: %v", err)
+			}
+
+			resolvers := outputs["resolver/resolver.go"].String() + outputs["resolver/query.resolver.go"].String()
+			for _, want := range tt.contains {
+				if !strings.Contains(resolvers, want) {
+					t.Errorf("generated resolvers don't contain %q", want)
+				}
+			}
+
+			for name, output := range outputs {
+				// files which aren't written, such as the mutation resolver of a schema without mutation, aren't created
+				if output.Len() == 0 {
+					continue
+				}
+
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatalf("error creating directory: %v", err)
+				}
+				if err := os.WriteFile(path, output.Bytes(), 0644); err != nil {
+					t.Fatalf("error writing file: %v", err)
+				}
+			}
+
+			for name, file := range tt.files {
+				b, err := os.ReadFile(file)
+				if err != nil {
+					t.Fatalf("error reading file: %v", err)
+				}
+
+				// the files in testdata import the generated packages as a project named example does
+				b = bytes.ReplaceAll(b, []byte(`"example/graphql/`), []byte(`"`+pkg+"/"))
+				if err := os.WriteFile(filepath.Join(dir, name), b, 0644); err != nil {
+					t.Fatalf("error writing file: %v", err)
+				}
+			}
+
+			out, err := exec.Command("go", "build", "./"+dir+"/model", "./"+dir+"/resolver").CombinedOutput()
+			if err != nil {
+				t.Fatalf("error building generated code: %v\n%s", err, out)
+			}
+
+			if len(tt.files) == 0 {
+				return
+			}
+
+			out, err = exec.Command("go", "test", "./"+dir+"/resolver").CombinedOutput()
+			if err != nil {
+				t.Fatalf("error testing generated code: %v\n%s", err, out)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"strings"
 
 	"github.com/n9te9/goliteql/schema"
 )
@@ -28,29 +29,31 @@ func generateModelImport() *ast.GenDecl {
 	}
 }
 
-func generateSelectionSetInput(fields schema.FieldDefinitions) []ast.Decl {
+// generateSelectionSetInput generates the Args types of the fields with arguments of the type named typeName,
+// which is empty for the root operation types, such as PostArgs of Query.post and UserPostsArgs of User.posts.
+func (g *Generator) generateSelectionSetInput(typeName string, fields schema.FieldDefinitions) []ast.Decl {
 	decls := make([]ast.Decl, 0, len(fields))
 
 	generateTypeSpec := func(args schema.ArgumentDefinitions, operationName string) []ast.Spec {
 		var ret []ast.Spec
 		var list []*ast.Field
 
-		for i, arg := range args {
-			expr := generateExpr(arg.Type)
+		for _, arg := range args {
+			expr := g.generateExpr(arg.Type)
 			list = append(list, &ast.Field{
 				Names: []*ast.Ident{
 					ast.NewIdent(toUpperCase(string(arg.Name))),
 				},
 				Tag: &ast.BasicLit{
 					Kind:  token.STRING,
-					Value: fmt.Sprintf("`json:\"arg%d\"`", i),
+					Value: fmt.Sprintf("`json:\"%s\"`", arg.Name),
 				},
 				Type: expr,
 			})
 		}
 
 		ret = append(ret, &ast.TypeSpec{
-			Name: ast.NewIdent(toUpperCase(operationName) + "Args"),
+			Name: ast.NewIdent(typeName + toUpperCase(operationName) + "Args"),
 			Type: &ast.StructType{
 				Fields: &ast.FieldList{
 					List: list,
@@ -77,13 +80,14 @@ func generateSelectionSetInput(fields schema.FieldDefinitions) []ast.Decl {
 	return decls
 }
 
-func generateModelField(field schema.FieldDefinitions) *ast.FieldList {
+func (g *Generator) generateModelField(field schema.FieldDefinitions) *ast.FieldList {
 	fields := make([]*ast.Field, 0, len(field))
 
 	for _, f := range field {
-		fieldTypeExpr := generateExpr(f.Type)
+		fieldTypeExpr := g.generateExpr(f.Type)
 
 		fields = append(fields, &ast.Field{
+			Doc: descriptionDoc(f.Description),
 			Names: []*ast.Ident{
 				{
 					Name: toUpperCase(string(f.Name)),
@@ -102,71 +106,75 @@ func generateModelField(field schema.FieldDefinitions) *ast.FieldList {
 	}
 }
 
-func generateExpr(fieldType *schema.FieldType) ast.Expr {
+func (g *Generator) generateExpr(fieldType *schema.FieldType) ast.Expr {
 	graphQLType := GraphQLType(fieldType.Name)
 	if fieldType.Nullable {
 		if graphQLType.IsPrimitive() {
 			return &ast.StarExpr{
 				X: &ast.Ident{
-					Name: graphQLType.golangType(),
+					Name: g.golangType(graphQLType),
 				},
 			}
 		} else {
 			if fieldType.IsList {
 				return &ast.StarExpr{
 					X: &ast.ArrayType{
-						Elt: generateExpr(fieldType.ListType),
+						Elt: g.generateExpr(fieldType.ListType),
 					},
 				}
 			}
 
+			if g.isAbstract(graphQLType) {
+				return ast.NewIdent(g.golangType(graphQLType))
+			}
+
 			return &ast.StarExpr{
-				X: ast.NewIdent(graphQLType.golangType()),
+				X: ast.NewIdent(g.golangType(graphQLType)),
 			}
 		}
 	} else {
 		if graphQLType.IsPrimitive() {
 			return &ast.Ident{
-				Name: graphQLType.golangType(),
+				Name: g.golangType(graphQLType),
 			}
 		} else {
 			if fieldType.IsList {
 				return &ast.ArrayType{
-					Elt: generateExpr(fieldType.ListType),
+					Elt: g.generateExpr(fieldType.ListType),
 				}
 			}
 
-			return ast.NewIdent(graphQLType.golangType())
+			return ast.NewIdent(g.golangType(graphQLType))
 		}
 	}
 }
 
-func generateExprForMapper(fieldType *schema.FieldType) ast.Expr {
+func (g *Generator) generateExprForMapper(fieldType *schema.FieldType) ast.Expr {
 	graphQLType := GraphQLType(fieldType.Name)
 	if graphQLType.IsPrimitive() {
 		return &ast.StarExpr{
 			X: &ast.Ident{
-				Name: graphQLType.golangType(),
+				Name: g.golangType(graphQLType),
 			},
 		}
 	} else {
 		if fieldType.IsList {
 			return &ast.ArrayType{
-				Elt: generateExpr(fieldType.ListType),
+				Elt: g.generateExpr(fieldType.ListType),
 			}
 		}
 
 		return &ast.StarExpr{
-			X: ast.NewIdent(graphQLType.golangType()),
+			X: ast.NewIdent(g.golangType(graphQLType)),
 		}
 	}
 }
 
-func generateModelMapperField(field schema.FieldDefinitions) *ast.FieldList {
+func (g *Generator) generateModelMapperField(field schema.FieldDefinitions) *ast.FieldList {
 	fields := make([]*ast.Field, 0, len(field))
 
 	for _, f := range field {
-		fieldTypeIdent := generateExprForMapper(f.Type)
+		fieldTypeIdent := g.generateExprForMapper(f.Type)
 
 		fields = append(fields, &ast.Field{
 			Names: []*ast.Ident{
@@ -187,10 +195,13 @@ func generateModelMapperField(field schema.FieldDefinitions) *ast.FieldList {
 	}
 }
 
-func generateInputModelUnmarshalJSON(t *schema.InputDefinition) *ast.FuncDecl {
+func (g *Generator) generateInputModelUnmarshalJSON(t *schema.InputDefinition) *ast.FuncDecl {
 	var stmts []ast.Stmt
-	stmts = append(stmts, generateUnmarshalJSONBody(t.Fields)...)
+	stmts = append(stmts, g.generateUnmarshalJSONBody(t.Fields)...)
 	stmts = append(stmts, generateMappingSchemaValidation(t)...)
+	for _, f := range t.Fields {
+		stmts = append(stmts, g.generateEnumValidation("mapper."+toUpperCase(string(f.Name)), f.Type, !f.Type.IsList)...)
+	}
 	stmts = append(stmts, generateMapping(t.Fields)...)
 
 	return &ast.FuncDecl{
@@ -246,8 +257,52 @@ func generateInputModelUnmarshalJSON(t *schema.InputDefinition) *ast.FuncDecl {
 	}
 }
 
-func generateUnmarshalJSONBody(fields schema.FieldDefinitions) []ast.Stmt {
-	modelMapperType := generateModelMapperField(fields)
+// generateEnumValidation generates the statements which reject a value of an enum field of an input, held by expr,
+// which isn't a value of the enum. Only inputs are checked, as the values returned by resolvers are checked
+// while they are completed, so that an invalid value is a field error at its path.
+func (g *Generator) generateEnumValidation(expr string, fieldType *schema.FieldType, pointer bool) []ast.Stmt {
+	if fieldType.IsList {
+		body := g.generateEnumValidation("v", fieldType.ListType, fieldType.ListType.Nullable && !fieldType.ListType.IsList)
+		if len(body) == 0 {
+			return nil
+		}
+
+		return []ast.Stmt{
+			&ast.RangeStmt{
+				Key:   ast.NewIdent("_"),
+				Value: ast.NewIdent("v"),
+				Tok:   token.DEFINE,
+				X:     ast.NewIdent(expr),
+				Body:  &ast.BlockStmt{List: body},
+			},
+		}
+	}
+
+	if _, ok := g.Schema.Indexes.EnumIndex[string(fieldType.Name)]; !ok {
+		return nil
+	}
+
+	cond, value := fmt.Sprintf("!%s.IsValid()", expr), expr
+	if pointer {
+		cond, value = fmt.Sprintf("%s != nil && !%s.IsValid()", expr, expr), "*"+expr
+	}
+
+	return []ast.Stmt{
+		&ast.IfStmt{
+			Cond: ast.NewIdent(cond),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{ast.NewIdent(fmt.Sprintf("fmt.Errorf(\"%%s is not a valid %s\", %s)", fieldType.Name, value))},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (g *Generator) generateUnmarshalJSONBody(fields schema.FieldDefinitions) []ast.Stmt {
+	modelMapperType := g.generateModelMapperField(fields)
 
 	return []ast.Stmt{
 		&ast.DeclStmt{
@@ -456,3 +511,619 @@ func generateMappingSchemaValidation[T *schema.InputDefinition | *schema.TypeDef
 
 	return []ast.Stmt{}
 }
+
+func (g *Generator) hasAbstractField(fields schema.FieldDefinitions) bool {
+	for _, f := range fields {
+		if g.isAbstract(GraphQLType(f.Type.GetPremitiveType().Name)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (g *Generator) generateAbstractTypeInterface(name string, description []byte) ast.Decl {
+	return g.positionDocs(&ast.GenDecl{
+		Doc: descriptionDoc(description),
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: ast.NewIdent(name),
+				Type: &ast.InterfaceType{
+					Methods: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("Is" + name),
+								},
+								Type: &ast.FuncType{
+									Params: &ast.FieldList{},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+}
+func generateAbstractTypeMethod(t *schema.TypeDefinition, abstractTypeName string) ast.Decl {
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent(string(t.Name)),
+				},
+			},
+		},
+		Name: ast.NewIdent("Is" + abstractTypeName),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{},
+		},
+		Body: &ast.BlockStmt{},
+	}
+}
+
+// generateAbstractTypeUnmarshal generates the function decoding a union or interface,
+// which picks the concrete type by the __typename of the JSON object.
+func generateAbstractTypeUnmarshal(name string, members []*schema.TypeDefinition) ast.Decl {
+	cases := make([]ast.Stmt, 0, len(members))
+	for _, member := range members {
+		cases = append(cases, &ast.CaseClause{
+			List: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf("%q", string(member.Name)),
+				},
+			},
+			Body: []ast.Stmt{
+				&ast.DeclStmt{
+					Decl: &ast.GenDecl{
+						Tok: token.VAR,
+						Specs: []ast.Spec{
+							&ast.ValueSpec{
+								Names: []*ast.Ident{ast.NewIdent("v")},
+								Type:  ast.NewIdent(string(member.Name)),
+							},
+						},
+					},
+				},
+				&ast.IfStmt{
+					Init: &ast.AssignStmt{
+						Lhs: []ast.Expr{ast.NewIdent("err")},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{ast.NewIdent("json.Unmarshal(data, &v)")},
+					},
+					Cond: ast.NewIdent("err != nil"),
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{ast.NewIdent("nil"), ast.NewIdent("err")},
+							},
+						},
+					},
+				},
+				&ast.ReturnStmt{
+					Results: []ast.Expr{ast.NewIdent("v"), ast.NewIdent("nil")},
+				},
+			},
+		})
+	}
+
+	return &ast.FuncDecl{
+		Name: ast.NewIdent("Unmarshal" + name),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("data")},
+						Type:  ast.NewIdent("[]byte"),
+					},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{Type: ast.NewIdent(name)},
+					{Type: ast.NewIdent("error")},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.IfStmt{
+					Cond: ast.NewIdent("executor.IsNullJSON(data)"),
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{ast.NewIdent("nil"), ast.NewIdent("nil")},
+							},
+						},
+					},
+				},
+				&ast.ExprStmt{X: &ast.BasicLit{}},
+				&ast.DeclStmt{
+					Decl: &ast.GenDecl{
+						Tok: token.VAR,
+						Specs: []ast.Spec{
+							&ast.ValueSpec{
+								Names: []*ast.Ident{ast.NewIdent("typename")},
+								Type: &ast.StructType{
+									Fields: &ast.FieldList{
+										List: []*ast.Field{
+											{
+												Names: []*ast.Ident{ast.NewIdent("Typename")},
+												Type:  ast.NewIdent("string"),
+												Tag: &ast.BasicLit{
+													Kind:  token.STRING,
+													Value: "`json:\"__typename\"`",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				&ast.IfStmt{
+					Init: &ast.AssignStmt{
+						Lhs: []ast.Expr{ast.NewIdent("err")},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{ast.NewIdent("json.Unmarshal(data, &typename)")},
+					},
+					Cond: ast.NewIdent("err != nil"),
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{ast.NewIdent("nil"), ast.NewIdent("err")},
+							},
+						},
+					},
+				},
+				&ast.ExprStmt{X: &ast.BasicLit{}},
+				&ast.SwitchStmt{
+					Tag: ast.NewIdent("typename.Typename"),
+					Body: &ast.BlockStmt{
+						List: cases,
+					},
+				},
+				&ast.ExprStmt{X: &ast.BasicLit{}},
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("nil"),
+						ast.NewIdent(fmt.Sprintf("fmt.Errorf(\"unknown __typename %%q for %s\", typename.Typename)", name)),
+					},
+				},
+			},
+		},
+	}
+}
+
+// generateTypenameMarshalJSON generates MarshalJSON for a member of a union or interface,
+// which adds __typename so that the value can be decoded as the abstract type again.
+func generateTypenameMarshalJSON(t *schema.TypeDefinition) ast.Decl {
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{ast.NewIdent("t")},
+					Type:  ast.NewIdent(string(t.Name)),
+				},
+			},
+		},
+		Name: ast.NewIdent("MarshalJSON"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{Type: ast.NewIdent("[]byte")},
+					{Type: ast.NewIdent("error")},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.DeclStmt{
+					Decl: &ast.GenDecl{
+						Tok: token.TYPE,
+						Specs: []ast.Spec{
+							&ast.TypeSpec{
+								Name: ast.NewIdent("alias"),
+								Type: ast.NewIdent(string(t.Name)),
+							},
+						},
+					},
+				},
+				&ast.ExprStmt{X: &ast.BasicLit{}},
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("json"),
+								Sel: ast.NewIdent("Marshal"),
+							},
+							Args: []ast.Expr{
+								&ast.CompositeLit{
+									Type: &ast.StructType{
+										Fields: &ast.FieldList{
+											List: []*ast.Field{
+												{
+													Names: []*ast.Ident{ast.NewIdent("Typename")},
+													Type:  ast.NewIdent("string"),
+													Tag: &ast.BasicLit{
+														Kind:  token.STRING,
+														Value: "`json:\"__typename\"`",
+													},
+												},
+												{
+													Type: ast.NewIdent("alias"),
+												},
+											},
+										},
+									},
+									Elts: []ast.Expr{
+										&ast.KeyValueExpr{
+											Key: ast.NewIdent("Typename"),
+											Value: &ast.BasicLit{
+												Kind:  token.STRING,
+												Value: fmt.Sprintf("%q", string(t.Name)),
+											},
+										},
+										&ast.KeyValueExpr{
+											Key:   ast.NewIdent("alias"),
+											Value: ast.NewIdent("alias(t)"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// generateAbstractFieldUnmarshalJSON generates UnmarshalJSON for a type having fields of unions or interfaces,
+// which encoding/json can not decode on its own.
+func (g *Generator) generateAbstractFieldUnmarshalJSON(t *schema.TypeDefinition) ast.Decl {
+	mapperFields := []*ast.Field{
+		{
+			Type: ast.NewIdent("*alias"),
+		},
+	}
+
+	assignStmts := make([]ast.Stmt, 0)
+	for _, f := range t.Fields {
+		if !g.isAbstract(GraphQLType(f.Type.GetPremitiveType().Name)) {
+			continue
+		}
+
+		fieldName := toUpperCase(string(f.Name))
+		mapperFields = append(mapperFields, &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent(fieldName)},
+			Type:  ast.NewIdent("json.RawMessage"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: fmt.Sprintf("`json:\"%s\"`", string(f.Name)),
+			},
+		})
+
+		assignStmts = append(assignStmts, &ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{
+					ast.NewIdent("t." + fieldName),
+					ast.NewIdent("err"),
+				},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{
+					ast.NewIdent(g.generateUnmarshalCall(f.Type, "mapper."+fieldName, "", true)),
+				},
+			},
+			Cond: ast.NewIdent("err != nil"),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{ast.NewIdent("err")},
+					},
+				},
+			},
+		})
+	}
+
+	stmts := []ast.Stmt{
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.TYPE,
+				Specs: []ast.Spec{
+					&ast.TypeSpec{
+						Name: ast.NewIdent("alias"),
+						Type: ast.NewIdent(string(t.Name)),
+					},
+				},
+			},
+		},
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{ast.NewIdent("mapper")},
+						Type: &ast.StructType{
+							Fields: &ast.FieldList{
+								List: mapperFields,
+							},
+						},
+					},
+				},
+			},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent("mapper.alias")},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{ast.NewIdent("(*alias)(t)")},
+		},
+		&ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{ast.NewIdent("json.Unmarshal(data, &mapper)")},
+			},
+			Cond: ast.NewIdent("err != nil"),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{ast.NewIdent("err")},
+					},
+				},
+			},
+		},
+		&ast.ExprStmt{X: &ast.BasicLit{}},
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{ast.NewIdent("err")},
+						Type:  ast.NewIdent("error"),
+					},
+				},
+			},
+		},
+	}
+	stmts = append(stmts, assignStmts...)
+	stmts = append(stmts, &ast.ExprStmt{X: &ast.BasicLit{}}, &ast.ReturnStmt{
+		Results: []ast.Expr{ast.NewIdent("nil")},
+	})
+
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{ast.NewIdent("t")},
+					Type:  ast.NewIdent("*" + string(t.Name)),
+				},
+			},
+		},
+		Name: ast.NewIdent("UnmarshalJSON"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("data")},
+						Type:  ast.NewIdent("[]byte"),
+					},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{Type: ast.NewIdent("error")},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: stmts,
+		},
+	}
+}
+
+// generateUnmarshalCall returns the expression decoding data into a value of fieldType,
+// whose named type is a union or interface qualified by pkg.
+func (g *Generator) generateUnmarshalCall(fieldType *schema.FieldType, data, pkg string, nullableListIsPointer bool) string {
+	if !fieldType.IsList {
+		return fmt.Sprintf("%sUnmarshal%s(%s)", pkg, string(fieldType.Name), data)
+	}
+
+	if fieldType.Nullable && nullableListIsPointer {
+		nonNullType := *fieldType
+		nonNullType.Nullable = false
+
+		return fmt.Sprintf("executor.UnmarshalNullable(%s, %s)", data, g.generateUnmarshalFunc(&nonNullType, pkg, nullableListIsPointer))
+	}
+
+	return fmt.Sprintf("executor.UnmarshalList(%s, %s)", data, g.generateUnmarshalFunc(fieldType.ListType, pkg, nullableListIsPointer))
+}
+
+func (g *Generator) generateUnmarshalFunc(fieldType *schema.FieldType, pkg string, nullableListIsPointer bool) string {
+	if !fieldType.IsList {
+		return fmt.Sprintf("%sUnmarshal%s", pkg, string(fieldType.Name))
+	}
+
+	return fmt.Sprintf("func(data []byte) (%s, error) { return %s }", g.goTypeString(fieldType, pkg, nullableListIsPointer), g.generateUnmarshalCall(fieldType, "data", pkg, nullableListIsPointer))
+}
+
+// enumValueName returns the name of the Go constant of an enum value,
+// such as StatusInProgress for IN_PROGRESS of Status.
+func enumValueName(enumName string, value []byte) string {
+	name := enumName
+	for _, part := range strings.Split(string(value), "_") {
+		if part == "" {
+			continue
+		}
+
+		name += strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+	}
+
+	return name
+}
+
+func (g *Generator) generateEnum(e *schema.EnumDefinition) []ast.Decl {
+	name := string(e.Name)
+
+	valueSpecs := make([]ast.Spec, 0, len(e.Values))
+	valueNames := make([]ast.Expr, 0, len(e.Values))
+	for _, v := range e.Values {
+		valueName := enumValueName(name, v.Name)
+		valueSpecs = append(valueSpecs, &ast.ValueSpec{
+			Doc:   descriptionDoc(v.Description),
+			Names: []*ast.Ident{ast.NewIdent(valueName)},
+			Type:  ast.NewIdent(name),
+			Values: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf("%q", string(v.Name)),
+				},
+			},
+		})
+		valueNames = append(valueNames, ast.NewIdent(valueName))
+	}
+
+	decls := []ast.Decl{
+		g.positionDocs(&ast.GenDecl{
+			Doc: descriptionDoc(e.Description),
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: ast.NewIdent(name),
+					Type: ast.NewIdent("string"),
+				},
+			},
+		}),
+	}
+
+	if len(valueSpecs) > 0 {
+		decls = append(decls, g.positionDocs(&ast.GenDecl{
+			Tok:    token.CONST,
+			Lparen: 1,
+			Specs:  valueSpecs,
+		}))
+	}
+
+	isValidBody := []ast.Stmt{}
+	if len(valueNames) > 0 {
+		isValidBody = append(isValidBody, &ast.SwitchStmt{
+			Tag: ast.NewIdent("e"),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.CaseClause{
+						List: valueNames,
+						Body: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{ast.NewIdent("true")},
+							},
+						},
+					},
+				},
+			},
+		})
+		isValidBody = append(isValidBody, &ast.ExprStmt{X: &ast.BasicLit{}})
+	}
+	isValidBody = append(isValidBody, &ast.ReturnStmt{
+		Results: []ast.Expr{ast.NewIdent("false")},
+	})
+
+	decls = append(decls,
+		&ast.FuncDecl{
+			Recv: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("e")},
+						Type:  ast.NewIdent(name),
+					},
+				},
+			},
+			Name: ast.NewIdent("IsValid"),
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{Type: ast.NewIdent("bool")},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: isValidBody,
+			},
+		},
+	)
+
+	return decls
+}
+
+// generateScalarRegistration generates the function which registers the Go types of scalars to a schema
+// to validate their values in queries. The generated resolver calls it for its schema.
+func (g *Generator) generateScalarRegistration(scalars []*schema.ScalarDefinition) *ast.FuncDecl {
+	stmts := make([]ast.Stmt, 0, len(scalars)+1)
+	for _, scalar := range scalars {
+		stmts = append(stmts, &ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.CallExpr{
+						Fun: ast.NewIdent(fmt.Sprintf("schema.RegisterScalar[%s]", g.golangType(GraphQLType(scalar.Name)))),
+						Args: []ast.Expr{
+							ast.NewIdent("s"),
+							&ast.BasicLit{
+								Kind:  token.STRING,
+								Value: fmt.Sprintf("%q", string(scalar.Name)),
+							},
+						},
+					},
+				},
+			},
+			Cond: ast.NewIdent("err != nil"),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{ast.NewIdent("err")},
+					},
+				},
+			},
+		})
+	}
+	stmts = append(stmts, &ast.ReturnStmt{
+		Results: []ast.Expr{ast.NewIdent("nil")},
+	})
+
+	return &ast.FuncDecl{
+		Doc: &ast.CommentGroup{
+			List: []*ast.Comment{
+				{
+					Text: "// RegisterScalars registers the Go types of the custom scalars to s, which validate the values of the scalars in queries.",
+				},
+			},
+		},
+		Name: ast.NewIdent("RegisterScalars"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("s")},
+						Type:  ast.NewIdent("*schema.Schema"),
+					},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: ast.NewIdent("error"),
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: stmts,
+		},
+	}
+}
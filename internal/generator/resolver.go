@@ -1,9 +1,11 @@
 package generator
 
 import (
+	"bytes"
 	"fmt"
 	"go/ast"
 	"go/token"
+	"strconv"
 
 	"github.com/n9te9/goliteql/schema"
 )
@@ -85,7 +87,9 @@ func generateResolverImport() *ast.GenDecl {
 	}
 }
 
-func generateResolverInterface(query, mutation, subscription *schema.OperationDefinition) *ast.GenDecl {
+// generateResolverInterface generates the interface of the resolver, which embeds the interfaces of the resolvers of the operations
+// and of the field resolvers of fieldResolverTypes.
+func generateResolverInterface(query, mutation, subscription *schema.OperationDefinition, fieldResolverTypes []*schema.TypeDefinition) *ast.GenDecl {
 	generateField := func(query, mutation, subscription *schema.OperationDefinition) []*ast.Field {
 		fields := make([]*ast.Field, 0, 3)
 		if query != nil {
@@ -106,6 +110,12 @@ func generateResolverInterface(query, mutation, subscription *schema.OperationDe
 			})
 		}
 
+		for _, t := range fieldResolverTypes {
+			fields = append(fields, &ast.Field{
+				Type: ast.NewIdent(string(t.Name) + "Resolver"),
+			})
+		}
+
 		return fields
 	}
 
@@ -155,24 +165,24 @@ func generateResolverServeHTTP(query, mutation, subscription *schema.OperationDe
 	}
 }
 
-func generateTypeExprFromFieldType(fieldType *schema.FieldType) ast.Expr {
+func (g *Generator) generateTypeExprFromFieldType(fieldType *schema.FieldType) ast.Expr {
 	if fieldType.IsList {
 		return &ast.ArrayType{
-			Elt: generateTypeExprFromFieldType(fieldType.ListType),
+			Elt: g.generateTypeExprFromFieldType(fieldType.ListType),
 		}
 	}
 
 	graphQLType := GraphQLType(fieldType.Name)
 
-	var baseTypeExpr ast.Expr = ast.NewIdent(graphQLType.golangType())
-	if !graphQLType.IsPrimitive() {
+	var baseTypeExpr ast.Expr = ast.NewIdent(g.golangType(graphQLType))
+	if !graphQLType.IsPrimitive() && !g.isScalar(graphQLType) {
 		baseTypeExpr = &ast.SelectorExpr{
 			X:   ast.NewIdent("model"),
-			Sel: ast.NewIdent(graphQLType.golangType()),
+			Sel: ast.NewIdent(g.golangType(graphQLType)),
 		}
 	}
 
-	if fieldType.Nullable {
+	if fieldType.Nullable && !g.isAbstract(graphQLType) {
 		return &ast.StarExpr{
 			X: baseTypeExpr,
 		}
@@ -181,7 +191,7 @@ func generateTypeExprFromFieldType(fieldType *schema.FieldType) ast.Expr {
 	return baseTypeExpr
 }
 
-func generateResponseGraphQLResponseStruct(operationName string, fieldDefinition *schema.FieldDefinition) ast.Decl {
+func (g *Generator) generateResponseGraphQLResponseStruct(operationName string, fieldDefinition *schema.FieldDefinition) ast.Decl {
 	structName := fmt.Sprintf("%sGraphQLResponse", operationName)
 
 	return &ast.GenDecl{
@@ -203,7 +213,7 @@ func generateResponseGraphQLResponseStruct(operationName string, fieldDefinition
 								Names: []*ast.Ident{
 									ast.NewIdent("Data"),
 								},
-								Type: generateTypeExprFromFieldType(fieldDefinition.Type),
+								Type: g.generateTypeExprFromFieldType(fieldDefinition.Type),
 							},
 							{
 								Names: []*ast.Ident{
@@ -211,7 +221,7 @@ func generateResponseGraphQLResponseStruct(operationName string, fieldDefinition
 								},
 								Type: &ast.SelectorExpr{
 									X:   ast.NewIdent("executor"),
-									Sel: ast.NewIdent("GraphQLError"),
+									Sel: ast.NewIdent("GraphQLErrors"),
 								},
 							},
 						},
@@ -222,7 +232,7 @@ func generateResponseGraphQLResponseStruct(operationName string, fieldDefinition
 	}
 }
 
-func generateQueryExecutor(query *schema.OperationDefinition) *ast.FuncDecl {
+func (g *Generator) generateQueryExecutor(query *schema.OperationDefinition, typed bool) *ast.FuncDecl {
 	return &ast.FuncDecl{
 		Name: ast.NewIdent("queryExecutor"),
 		Recv: &ast.FieldList{
@@ -235,7 +245,7 @@ func generateQueryExecutor(query *schema.OperationDefinition) *ast.FuncDecl {
 		},
 		Type: &ast.FuncType{
 			Params:  generateOperationExecutorArgs(),
-			Results: &ast.FieldList{},
+			Results: generateRootFieldResultType(),
 		},
 		Doc: &ast.CommentGroup{
 			List: []*ast.Comment{
@@ -247,11 +257,11 @@ func generateQueryExecutor(query *schema.OperationDefinition) *ast.FuncDecl {
 				},
 			},
 		},
-		Body: generateExecutorBody(query, "query"),
+		Body: g.generateExecutorBody(query, "query", typed),
 	}
 }
 
-func generateMutationExecutor(mutation *schema.OperationDefinition) *ast.FuncDecl {
+func (g *Generator) generateMutationExecutor(mutation *schema.OperationDefinition, typed bool) *ast.FuncDecl {
 	return &ast.FuncDecl{
 		Name: ast.NewIdent("mutationExecutor"),
 		Recv: &ast.FieldList{
@@ -264,7 +274,7 @@ func generateMutationExecutor(mutation *schema.OperationDefinition) *ast.FuncDec
 		},
 		Type: &ast.FuncType{
 			Params:  generateOperationExecutorArgs(),
-			Results: &ast.FieldList{},
+			Results: generateRootFieldResultType(),
 		},
 		Doc: &ast.CommentGroup{
 			List: []*ast.Comment{
@@ -276,11 +286,14 @@ func generateMutationExecutor(mutation *schema.OperationDefinition) *ast.FuncDec
 				},
 			},
 		},
-		Body: generateExecutorBody(mutation, "mutation"),
+		Body: g.generateExecutorBody(mutation, "mutation", typed),
 	}
 }
 
-func generateSubscriptionExecutor(subscription *schema.OperationDefinition) *ast.FuncDecl {
+func (g *Generator) generateSubscriptionExecutor(subscription *schema.OperationDefinition) *ast.FuncDecl {
+	params := generateOperationExecutorArgs()
+	params.List = params.List[1:]
+
 	return &ast.FuncDecl{
 		Name: ast.NewIdent("subscriptionExecutor"),
 		Recv: &ast.FieldList{
@@ -292,8 +305,8 @@ func generateSubscriptionExecutor(subscription *schema.OperationDefinition) *ast
 			},
 		},
 		Type: &ast.FuncType{
-			Params:  generateOperationExecutorArgs(),
-			Results: &ast.FieldList{},
+			Params:  params,
+			Results: generateSubscribeResults(),
 		},
 		Doc: &ast.CommentGroup{
 			List: []*ast.Comment{
@@ -305,518 +318,402 @@ func generateSubscriptionExecutor(subscription *schema.OperationDefinition) *ast
 				},
 			},
 		},
-		Body: generateExecutorBody(subscription, "subscription"),
+		Body: g.generateSubscriptionExecutorBody(subscription),
 	}
 }
 
-func generateWrapResponseWriter(op *schema.OperationDefinition) []ast.Decl {
-	res := make([]ast.Decl, 0, len(op.Fields))
+func generateRootFieldResultType() *ast.FieldList {
+	return &ast.FieldList{
+		List: []*ast.Field{
+			{
+				Type: &ast.StarExpr{
+					X: &ast.SelectorExpr{
+						X:   ast.NewIdent("executor"),
+						Sel: ast.NewIdent("RootFieldResult"),
+					},
+				},
+			},
+		},
+	}
+}
 
-	for _, field := range op.Fields {
-		res = append(res, generateWrapResponseWriterStruct(field))
-		res = append(res, generateWrapResponseWriterFunc(field))
-		res = append(res, generateWrapResponseWriterWrite(string(field.Name), field))
+func generateSubscribeResults() *ast.FieldList {
+	return &ast.FieldList{
+		List: []*ast.Field{
+			{
+				Type: &ast.ChanType{
+					Dir:   ast.RECV,
+					Value: ast.NewIdent("[]byte"),
+				},
+			},
+			{
+				Type: ast.NewIdent("error"),
+			},
+		},
 	}
+}
 
-	return res
+func generateReturnNilWithError(err ast.Expr) *ast.ReturnStmt {
+	return &ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent("nil"),
+			err,
+		},
+	}
 }
 
-func generateWrapResponseWriterStruct(field *schema.FieldDefinition) *ast.GenDecl {
-	return &ast.GenDecl{
-		Tok: token.TYPE,
-		Specs: []ast.Spec{
-			&ast.TypeSpec{
-				Name: ast.NewIdent("Wrap" + string(field.Name) + "ResponseWriter"),
-				Type: &ast.StructType{
-					Fields: &ast.FieldList{
-						List: []*ast.Field{
-							{
-								Type: &ast.SelectorExpr{
-									X:   ast.NewIdent("http"),
-									Sel: ast.NewIdent("ResponseWriter"),
-								},
-							},
-							{
-								Names: []*ast.Ident{ast.NewIdent("selections")},
-								Type: &ast.ArrayType{
-									Len: nil,
-									Elt: &ast.SelectorExpr{
-										X:   ast.NewIdent("query"),
-										Sel: ast.NewIdent("Selection"),
-									},
-								},
-							},
-							{
-								Names: []*ast.Ident{
-									ast.NewIdent("variables"),
-								},
-								Type: &ast.SelectorExpr{
-									X:   ast.NewIdent("json"),
-									Sel: ast.NewIdent("RawMessage"),
-								},
-							},
-						},
-					},
+func generateIfErrReturn(results ...ast.Expr) *ast.IfStmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: results,
 				},
 			},
 		},
 	}
 }
 
-func generateWrapResponseWriterFunc(field *schema.FieldDefinition) *ast.FuncDecl {
-	return &ast.FuncDecl{
-		Name: ast.NewIdent("new" + string(field.Name) + "Writer"),
-		Type: &ast.FuncType{
-			Params: &ast.FieldList{
-				List: []*ast.Field{
-					{
-						Names: []*ast.Ident{ast.NewIdent("w")},
-						Type: &ast.SelectorExpr{
-							X:   ast.NewIdent("http"),
-							Sel: ast.NewIdent("ResponseWriter"),
+func (g *Generator) generateSubscriptionExecutorBody(subscription *schema.OperationDefinition) *ast.BlockStmt {
+	body := []ast.Stmt{
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent("node"),
+				Op: token.EQL,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					generateReturnNilWithError(ast.NewIdent(`fmt.Errorf("subscription does not have a root field")`)),
+				},
+			},
+		},
+	}
+
+	cases := make([]ast.Stmt, 0, len(subscription.Fields))
+	for _, field := range subscription.Fields {
+		fieldName := string(field.Name)
+
+		encoder := &ast.FuncLit{
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{ast.NewIdent("v")},
+							Type:  g.generateTypeExprFromFieldType(field.Type),
 						},
 					},
-					{
-						Names: []*ast.Ident{ast.NewIdent("selections")},
-						Type: &ast.ArrayType{
-							Len: nil,
-							Elt: &ast.SelectorExpr{
-								X:   ast.NewIdent("query"),
-								Sel: ast.NewIdent("Selection"),
-							},
+				},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{Type: ast.NewIdent("[]byte")},
+						{Type: ast.NewIdent("error")},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Tok: token.DEFINE,
+						Lhs: []ast.Expr{ast.NewIdent("b"), ast.NewIdent("err")},
+						Rhs: []ast.Expr{ast.NewIdent("json.Marshal(executor.GraphQLResponse{Data: v})")},
+					},
+					generateIfErrReturn(ast.NewIdent("nil"), ast.NewIdent("err")),
+					&ast.ExprStmt{
+						X: &ast.BasicLit{
+							Kind:  token.STRING,
+							Value: "// every event has loaders of its own, so that it doesn't see the values cached for the previous events",
 						},
 					},
-					{
-						Names: []*ast.Ident{
-							ast.NewIdent("variables"),
+					&ast.AssignStmt{
+						Tok: token.DEFINE,
+						Lhs: []ast.Expr{ast.NewIdent("rw")},
+						Rhs: []ast.Expr{ast.NewIdent(fmt.Sprintf("new%sWriter(executor.WithWorkerLimit(dataloader.NewContext(req.Context()), r.workerLimit), r, executor.NewResponseBuffer(), string(node.ResponseKey()), node.SelectSets, variables, node.Loc)", fieldName))},
+					},
+					&ast.IfStmt{
+						Init: &ast.AssignStmt{
+							Tok: token.DEFINE,
+							Lhs: []ast.Expr{ast.NewIdent("_"), ast.NewIdent("err")},
+							Rhs: []ast.Expr{ast.NewIdent("rw.Write(b)")},
 						},
-						Type: &ast.SelectorExpr{
-							X:   ast.NewIdent("json"),
-							Sel: ast.NewIdent("RawMessage"),
+						Cond: &ast.BinaryExpr{
+							X:  ast.NewIdent("err"),
+							Op: token.NEQ,
+							Y:  ast.NewIdent("nil"),
+						},
+						Body: &ast.BlockStmt{
+							List: []ast.Stmt{
+								generateReturnNilWithError(ast.NewIdent("err")),
+							},
+						},
+					},
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							ast.NewIdent("json.Marshal(executor.MergeRootFieldResults(rw.result))"),
 						},
 					},
 				},
 			},
-			Results: &ast.FieldList{
-				List: []*ast.Field{
-					{
-						Type: &ast.StarExpr{
-							X: &ast.Ident{
-								Name: "Wrap" + string(field.Name) + "ResponseWriter",
+		}
+
+		cases = append(cases, &ast.CaseClause{
+			List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf("\"%s\"", fieldName)}},
+			Body: []ast.Stmt{
+				&ast.AssignStmt{
+					Tok: token.DEFINE,
+					Lhs: []ast.Expr{ast.NewIdent("body"), ast.NewIdent("err")},
+					Rhs: []ast.Expr{ast.NewIdent(fmt.Sprintf("executor.CoerceArgumentValues(r.schema, %s, node.Arguments, variables)", argumentDefinitionsExpr("subscription", fieldName)))},
+				},
+				generateIfErrReturn(ast.NewIdent("nil"), ast.NewIdent("err")),
+				&ast.AssignStmt{
+					Tok: token.ASSIGN,
+					Lhs: []ast.Expr{ast.NewIdent("req.Body")},
+					Rhs: []ast.Expr{ast.NewIdent("io.NopCloser(strings.NewReader(string(body)))")},
+				},
+				&ast.AssignStmt{
+					Tok: token.DEFINE,
+					Lhs: []ast.Expr{ast.NewIdent("source"), ast.NewIdent("err")},
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("r"),
+								Sel: ast.NewIdent(toUpperCase(fieldName)),
 							},
+							Args: []ast.Expr{ast.NewIdent("req")},
 						},
 					},
 				},
-			},
-		},
-		Body: &ast.BlockStmt{
-			List: []ast.Stmt{
+				generateIfErrReturn(ast.NewIdent("nil"), ast.NewIdent("err")),
 				&ast.ReturnStmt{
 					Results: []ast.Expr{
-						&ast.UnaryExpr{
-							Op: token.AND,
-							X: &ast.CompositeLit{
-								Type: ast.NewIdent("Wrap" + string(field.Name) + "ResponseWriter"),
-								Elts: []ast.Expr{
-									&ast.KeyValueExpr{
-										Key:   ast.NewIdent("ResponseWriter"),
-										Value: ast.NewIdent("w"),
-									},
-									&ast.KeyValueExpr{
-										Key:   ast.NewIdent("selections"),
-										Value: ast.NewIdent("selections"),
-									},
-									&ast.KeyValueExpr{
-										Key:   ast.NewIdent("variables"),
-										Value: ast.NewIdent("variables"),
-									},
-								},
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("executor"),
+								Sel: ast.NewIdent("Stream"),
+							},
+							Args: []ast.Expr{
+								ast.NewIdent("req.Context()"),
+								ast.NewIdent("source"),
+								encoder,
 							},
 						},
+						ast.NewIdent("nil"),
 					},
 				},
 			},
-		},
-		Doc: &ast.CommentGroup{
-			List: []*ast.Comment{
-				{
-					Text: "// *********** AUTO GENERATED CODE ***********",
-				},
-				{
-					Text: "// *********** DON'T EDIT ***********",
-				},
-			},
-		},
-	}
-}
-
-func extractWillDeclTypeDefinition(typeIndex map[string]*schema.TypeDefinition, fieldType *schema.FieldType) *schema.TypeDefinition {
-	if fieldType.IsList {
-		return extractWillDeclTypeDefinition(typeIndex, fieldType.ListType)
-	}
-
-	typeDefinition := typeIndex[string(fieldType.Name)]
-	if typeDefinition == nil {
-		return nil
-	}
-
-	return typeDefinition
-}
-
-func generateResponseStructForWrapResponseWriter(typeIndex map[string]*schema.TypeDefinition, operation *schema.OperationDefinition) []ast.Decl {
-	if operation == nil {
-		return nil
+		})
 	}
 
-	decls := make([]ast.Decl, 0)
-
-	for _, field := range operation.Fields {
-		if field == nil {
-			continue
-		}
-
-		t := extractWillDeclTypeDefinition(typeIndex, field.Type)
+	body = append(body,
+		&ast.SwitchStmt{
+			Tag: ast.NewIdent("string(node.Name)"),
+			Body: &ast.BlockStmt{
+				List: cases,
+			},
+		},
+		&ast.ExprStmt{X: &ast.BasicLit{}},
+		generateReturnNilWithError(ast.NewIdent(`fmt.Errorf("unknown subscription field %s", node.Name)`)),
+	)
 
-		decls = append(decls, generateResponseStructDeclsForWrapResponseWriter(string(field.Name), field, t, typeIndex)...)
+	return &ast.BlockStmt{
+		List: body,
 	}
-
-	return decls
 }
 
-func generateResponseStructArrayType(nestCount int, responseStructName, prefix string) ast.Expr {
-	if nestCount > 0 {
-		return &ast.ArrayType{
-			Elt: generateResponseStructArrayType(nestCount-1, responseStructName, prefix),
-		}
+func generateSubscribe(subscription *schema.OperationDefinition) *ast.FuncDecl {
+	execute := []ast.Stmt{
+		generateReturnNilWithError(ast.NewIdent(`fmt.Errorf("schema does not have a subscription operation")`)),
 	}
-
-	if prefix == "" {
-		return &ast.ArrayType{
-			Elt: &ast.StarExpr{
-				X: ast.NewIdent(responseStructName),
+	if subscription != nil {
+		execute = []ast.Stmt{
+			&ast.AssignStmt{
+				Tok: token.DEFINE,
+				Lhs: []ast.Expr{ast.NewIdent("rootSelectionSet")},
+				Rhs: []ast.Expr{ast.NewIdent("utils.ExtractExecuteSelector(operation)")},
 			},
-		}
-	}
-
-	return &ast.ArrayType{
-		Elt: &ast.StarExpr{
-			X: &ast.SelectorExpr{
-				X:   ast.NewIdent(prefix),
-				Sel: ast.NewIdent(responseStructName),
+			&ast.AssignStmt{
+				Tok: token.DEFINE,
+				Lhs: []ast.Expr{ast.NewIdent("variables"), ast.NewIdent("err")},
+				Rhs: []ast.Expr{ast.NewIdent("executor.CoerceVariableValues(r.schema, operation, request.Variables)")},
+			},
+			generateIfErrReturn(ast.NewIdent("nil"), ast.NewIdent("err")),
+			&ast.ReturnStmt{
+				Results: []ast.Expr{
+					ast.NewIdent("r.subscriptionExecutor(req, executor.PlanExecution(rootSelectionSet, parsedQuery.FragmentDefinitions, variables), parsedQuery, variables)"),
+				},
 			},
-		},
-	}
-}
-
-// TODO: refactor
-func generateWrapResponseWriterNestedTypeInitializerForFieldType(fieldType *schema.FieldType, responseStructName string, maxNestCount, nestCount int) []ast.Stmt {
-	nestedItr := ""
-	respNestedItr := ""
-
-	arrayType := generateResponseStructArrayType(maxNestCount-nestCount-1, responseStructName, "")
-	if nestCount >= 0 {
-		for i := 0; i < nestCount+1; i++ {
-			nestedItr += fmt.Sprintf("[k%d]", i)
-		}
-
-		for i := 0; i < nestCount; i++ {
-			respNestedItr += fmt.Sprintf("[k%d]", i)
 		}
 	}
 
-	stmts := make([]ast.Stmt, 0)
-	if maxNestCount != nestCount {
-		stmts = append(stmts, &ast.AssignStmt{
-			Tok: token.ASSIGN,
-			Lhs: []ast.Expr{
-				ast.NewIdent(fmt.Sprintf("resp%s", respNestedItr)),
+	return &ast.FuncDecl{
+		Name: ast.NewIdent("subscribe"),
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{ast.NewIdent("r")},
+					Type:  &ast.StarExpr{X: ast.NewIdent("resolver")},
+				},
 			},
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: ast.NewIdent("append"),
-					Args: []ast.Expr{
-						ast.NewIdent(fmt.Sprintf("resp%s", respNestedItr)),
-						&ast.CallExpr{
-							Fun: ast.NewIdent("make"),
-							Args: []ast.Expr{
-								arrayType,
-								&ast.CallExpr{
-									Fun: ast.NewIdent("len"),
-									Args: []ast.Expr{
-										ast.NewIdent("baseResp" + nestedItr),
-									},
-								},
+		},
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("req")},
+						Type: &ast.StarExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent("http"),
+								Sel: ast.NewIdent("Request"),
+							},
+						},
+					},
+					{
+						Names: []*ast.Ident{ast.NewIdent("request")},
+						Type: &ast.StarExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent("executor"),
+								Sel: ast.NewIdent("GraphQLRequest"),
 							},
 						},
 					},
 				},
 			},
-		})
-	}
-
-	if fieldType.IsList {
-		stmts = append(stmts, generateWrapResponseWriterNestedTypeInitializerForFieldType(fieldType.ListType, responseStructName, maxNestCount, nestCount+1)...)
-		body := &ast.BlockStmt{
-			List: stmts,
-		}
-
-		return []ast.Stmt{
-			&ast.RangeStmt{
-				Key:  ast.NewIdent(fmt.Sprintf("k%d", nestCount)),
-				Tok:  token.DEFINE,
-				X:    ast.NewIdent("baseResp"),
-				Body: body,
-			},
-		}
-	}
-
-	if !fieldType.IsList {
-		return stmts
-	}
-
-	panic("unknown type")
-}
-
-func generateWrapResponseWriterNestedTypeInitializer(responseStructName string, field *schema.FieldDefinition) []ast.Stmt {
-	nestCount := getFieldSliceNestLevel(field.Type)
-	if nestCount < 2 {
-		return []ast.Stmt{
-			&ast.ExprStmt{
-				X: &ast.BasicLit{},
-			},
-		}
-	}
-
-	return generateWrapResponseWriterNestedTypeInitializerForFieldType(field.Type.ListType, responseStructName, nestCount-1, 0)
-}
-
-func generateApplySkipDirective() ast.Stmt {
-	return &ast.IfStmt{
-		Cond: &ast.CallExpr{
-			Fun: &ast.SelectorExpr{
-				X:   ast.NewIdent("executor"),
-				Sel: ast.NewIdent("IsSkipped"),
-			},
-			Args: []ast.Expr{
-				&ast.SelectorExpr{
-					X:   ast.NewIdent("sel"),
-					Sel: ast.NewIdent("Directives"),
+			Results: generateSubscribeResults(),
+		},
+		Doc: &ast.CommentGroup{
+			List: []*ast.Comment{
+				{
+					Text: "// *********** AUTO GENERATED CODE ***********",
 				},
-				&ast.SelectorExpr{
-					X:   ast.NewIdent("w"),
-					Sel: ast.NewIdent("variables"),
+				{
+					Text: "// *********** DON'T EDIT ***********",
 				},
 			},
 		},
 		Body: &ast.BlockStmt{
-			List: []ast.Stmt{
-				&ast.ExprStmt{
-					X: &ast.BasicLit{
-						Kind:  token.CONTINUE,
-						Value: "continue",
-					},
-				},
-			},
-		},
-	}
-}
-
-func generateApplyIncludeDirective() ast.Stmt {
-	return &ast.IfStmt{
-		Cond: &ast.UnaryExpr{
-			Op: token.NOT,
-			X: &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   ast.NewIdent("executor"),
-					Sel: ast.NewIdent("IsIncluded"),
+			List: append([]ast.Stmt{
+				&ast.AssignStmt{
+					Tok: token.DEFINE,
+					Lhs: []ast.Expr{ast.NewIdent("parsedQuery"), ast.NewIdent("err")},
+					Rhs: []ast.Expr{ast.NewIdent("r.parser.Parse([]byte(request.Query))")},
 				},
-				Args: []ast.Expr{
-					&ast.SelectorExpr{
-						X:   ast.NewIdent("sel"),
-						Sel: ast.NewIdent("Directives"),
+				generateIfErrReturn(ast.NewIdent("nil"), ast.NewIdent("err")),
+				&ast.ExprStmt{X: &ast.BasicLit{}},
+				&ast.IfStmt{
+					Init: &ast.AssignStmt{
+						Tok: token.DEFINE,
+						Lhs: []ast.Expr{ast.NewIdent("errs")},
+						Rhs: []ast.Expr{ast.NewIdent("validator.ValidateDocument(r.schema, parsedQuery)")},
 					},
-					&ast.SelectorExpr{
-						X:   ast.NewIdent("w"),
-						Sel: ast.NewIdent("variables"),
+					Cond: ast.NewIdent("len(errs) > 0"),
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{ast.NewIdent("nil"), ast.NewIdent("executor.ValidationErrors(errs)")},
+							},
+						},
 					},
 				},
-			},
-		},
-		Body: &ast.BlockStmt{
-			List: []ast.Stmt{
+				&ast.ExprStmt{X: &ast.BasicLit{}},
+				&ast.AssignStmt{
+					Tok: token.DEFINE,
+					Lhs: []ast.Expr{ast.NewIdent("operation"), ast.NewIdent("err")},
+					Rhs: []ast.Expr{ast.NewIdent("parsedQuery.Operations.GetOperation(request.OperationName)")},
+				},
+				generateIfErrReturn(ast.NewIdent("nil"), ast.NewIdent("err")),
+				&ast.ExprStmt{X: &ast.BasicLit{}},
 				&ast.ExprStmt{
 					X: &ast.BasicLit{
-						Kind:  token.CONTINUE,
-						Value: "continue",
+						Kind:  token.STRING,
+						Value: `// queries and mutations sent over a streaming transport are answered with a single result`,
 					},
 				},
-			},
+				&ast.IfStmt{
+					Cond: &ast.BinaryExpr{
+						X:  ast.NewIdent("operation.OperationType"),
+						Op: token.NEQ,
+						Y:  ast.NewIdent(operationTypeConst("subscription")),
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									ast.NewIdent("executor.ExecuteOnce(r, req, request)"),
+								},
+							},
+						},
+					},
+				},
+				&ast.ExprStmt{X: &ast.BasicLit{}},
+			}, execute...),
 		},
 	}
 }
 
-func generateWrapResponseWriterReponseFieldWalkerValidationStmts(fieldType *schema.FieldType, typeDefinition *schema.TypeDefinition, nestCount int) []ast.Stmt {
-	xName := "baseResp"
-
-	if fieldType.IsList {
-		if nestCount > 0 {
-			xName = fmt.Sprintf("v%d", nestCount-1)
-		}
-
-		valueName := ast.NewIdent(fmt.Sprintf("v%d", nestCount))
-		if !fieldType.ListType.IsList {
-			valueName = ast.NewIdent("_")
-		}
+func (g *Generator) generateSubscriptionResolverFuncType(field *schema.FieldDefinition) *ast.FuncType {
+	params := generateServeHTTPArgs()
+	params.List = params.List[1:]
 
-		return []ast.Stmt{
-			&ast.RangeStmt{
-				Key:   ast.NewIdent(fmt.Sprintf("k%d", nestCount)),
-				Tok:   token.DEFINE,
-				Value: valueName,
-				X:     ast.NewIdent(xName),
-				Body: &ast.BlockStmt{
-					List: generateWrapResponseWriterReponseFieldWalkerValidationStmts(fieldType.ListType, typeDefinition, nestCount+1),
+	return &ast.FuncType{
+		Params: params,
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.ChanType{
+						Dir:   ast.RECV,
+						Value: g.generateTypeExprFromFieldType(field.Type),
+					},
+				},
+				{
+					Type: ast.NewIdent("error"),
 				},
 			},
-		}
-	}
-
-	nestItr := ""
-	for i := 0; i < nestCount; i++ {
-		nestItr += fmt.Sprintf("[k%d]", i)
+		},
 	}
+}
 
-	validationTargetName := "baseResp" + nestItr
-	responseTargetName := "resp" + nestItr
-
-	stmts := make([]ast.Stmt, 0)
-	for _, field := range typeDefinition.Fields {
-		fieldName := string(field.Name)
-		if isLowerCase(string(field.Name)) {
-			fieldName = toUpperCase(fieldName)
-		}
-
-		var assignExpr ast.Expr = &ast.UnaryExpr{
-			Op: token.AND,
-			X: &ast.SelectorExpr{
-				X:   ast.NewIdent(validationTargetName),
-				Sel: ast.NewIdent(fieldName),
-			},
-		}
-
-		if field.Type.Nullable {
-			assignExpr = &ast.SelectorExpr{
-				X:   ast.NewIdent(validationTargetName),
-				Sel: ast.NewIdent(fieldName),
-			}
-		}
+func (g *Generator) generateSubscriptionResolverImplementation(fields schema.FieldDefinitions) []ast.Decl {
+	decls := make([]ast.Decl, 0, len(fields))
 
-		if field.IsPremitive() {
-			stmts = append(stmts, &ast.IfStmt{
-				Cond: &ast.BinaryExpr{
-					X: &ast.CallExpr{
-						Fun: ast.NewIdent("string"),
-						Args: []ast.Expr{
-							ast.NewIdent("sel.Name"),
-						},
+	for _, f := range fields {
+		decls = append(decls, g.generateResponseGraphQLResponseStruct(string(f.Name), f))
+		decls = append(decls, &ast.FuncDecl{
+			Doc: &ast.CommentGroup{
+				List: []*ast.Comment{
+					{
+						Text: fmt.Sprintf("// Read request body for %sArgs", toUpperCase(string(f.Name))),
 					},
-					Op: token.EQL,
-					Y: &ast.BasicLit{
-						Kind:  token.STRING,
-						Value: fmt.Sprintf("\"%s\"", string(field.Name)),
+					{
+						Text: "// Send results on the returned channel and close it when the subscription ends.",
 					},
-				},
-				Body: &ast.BlockStmt{
-					List: []ast.Stmt{
-						generateApplySkipDirective(),
-						generateApplyIncludeDirective(),
-						&ast.AssignStmt{
-							Tok: token.ASSIGN,
-							Lhs: []ast.Expr{
-								&ast.SelectorExpr{
-									X:   ast.NewIdent(responseTargetName),
-									Sel: ast.NewIdent(fieldName),
-								},
-							},
-							Rhs: []ast.Expr{
-								assignExpr,
-							},
-						},
+					{
+						Text: "// req.Context() is cancelled when the client unsubscribes.",
 					},
 				},
-			})
-
-			continue
-		}
-
-		var argExpr ast.Expr = &ast.SelectorExpr{
-			X:   ast.NewIdent(validationTargetName),
-			Sel: ast.NewIdent(fieldName),
-		}
-
-		if !field.Type.Nullable && !field.Type.IsList {
-			argExpr = &ast.SelectorExpr{
-				X:   ast.NewIdent(validationTargetName),
-				Sel: ast.NewIdent(fieldName),
-			}
-		}
-
-		if field.Type.IsList {
-			argExpr = &ast.SelectorExpr{
-				X:   ast.NewIdent(validationTargetName),
-				Sel: ast.NewIdent(fieldName),
-			}
-		}
-
-		if field.Type.IsList && field.Type.Nullable {
-			argExpr = &ast.StarExpr{
-				X: &ast.SelectorExpr{
-					X:   ast.NewIdent(validationTargetName),
-					Sel: ast.NewIdent(fieldName),
-				},
-			}
-		}
-
-		stmts = append(stmts, &ast.IfStmt{
-			Cond: &ast.BinaryExpr{
-				X: &ast.CallExpr{
-					Fun: ast.NewIdent("string"),
-					Args: []ast.Expr{
-						ast.NewIdent("sel.Name"),
+			},
+			Name: ast.NewIdent(toUpperCase(string(f.Name))),
+			Recv: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("r")},
+						Type: &ast.StarExpr{
+							X: ast.NewIdent("resolver"),
+						},
 					},
 				},
-				Op: token.EQL,
-				Y: &ast.BasicLit{
-					Kind:  token.STRING,
-					Value: fmt.Sprintf("\"%s\"", string(field.Name)),
-				},
 			},
+			Type: g.generateSubscriptionResolverFuncType(f),
 			Body: &ast.BlockStmt{
 				List: []ast.Stmt{
-					generateApplySkipDirective(),
-					generateApplyIncludeDirective(),
-					&ast.AssignStmt{
-						Tok: token.ASSIGN,
-						Lhs: []ast.Expr{
-							&ast.SelectorExpr{
-								X:   ast.NewIdent(responseTargetName),
-								Sel: ast.NewIdent(fieldName),
-							},
-						},
-						Rhs: []ast.Expr{
-							&ast.CallExpr{
-								Fun: &ast.SelectorExpr{
-									X:   ast.NewIdent("w"),
-									Sel: ast.NewIdent("walk" + string(field.Name)),
-								},
-								Args: []ast.Expr{
-									&ast.SelectorExpr{
-										X:   ast.NewIdent("sel"),
-										Sel: ast.NewIdent("GetSelections()"),
-									},
-									argExpr,
-								},
-							},
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							ast.NewIdent("nil"),
+							ast.NewIdent("nil"),
 						},
 					},
 				},
@@ -824,164 +721,141 @@ func generateWrapResponseWriterReponseFieldWalkerValidationStmts(fieldType *sche
 		})
 	}
 
-	return stmts
-}
-
-func generateWrapResponseWriterResponseFieldWalkerStmts(field *schema.FieldDefinition, typeDefinition *schema.TypeDefinition) ast.Stmt {
-	stmts := make([]ast.Stmt, 0)
-	stmts = append(stmts, &ast.AssignStmt{
-		Tok: token.DEFINE,
-		Lhs: []ast.Expr{
-			ast.NewIdent("sel"),
-		},
-		Rhs: []ast.Expr{
-			&ast.TypeAssertExpr{
-				X: ast.NewIdent("selection"),
-				Type: &ast.StarExpr{
-					X: &ast.SelectorExpr{
-						X:   ast.NewIdent("query"),
-						Sel: ast.NewIdent("Field"),
-					},
-				},
-			},
-		},
-	})
-
-	stmts = append(stmts, generateWrapResponseWriterReponseFieldWalkerValidationStmts(field.Type, typeDefinition, 0)...)
-
-	return &ast.RangeStmt{
-		Key:   ast.NewIdent("_"),
-		Tok:   token.DEFINE,
-		Value: ast.NewIdent("selection"),
-		X:     ast.NewIdent("selections"),
-		Body: &ast.BlockStmt{
-			List: stmts,
-		},
-	}
+	return decls
 }
 
-func getFieldSliceNestLevel(fieldType *schema.FieldType) int {
-	nestLevel := 0
-
-	for fieldType.IsList {
-		fieldType = fieldType.ListType
-		nestLevel++
-	}
+func (g *Generator) generateWrapResponseWriter(op *schema.OperationDefinition) []ast.Decl {
+	res := make([]ast.Decl, 0, len(op.Fields))
 
-	return nestLevel
-}
+	for _, field := range op.Fields {
+		res = append(res, generateWrapResponseWriterStruct(field))
+		res = append(res, generateWrapResponseWriterFunc(field))
+		res = append(res, generateWrapResponseWriterWriteHeader(field))
+		res = append(res, g.generateWrapResponseWriterWrite(string(field.Name), field))
 
-func generateWrapResponseWriterResponseInitializeStmt(responseStructName string, field *schema.FieldDefinition) ast.Stmt {
-	if field.Type.IsList {
-		return &ast.AssignStmt{
-			Tok: token.DEFINE,
-			Lhs: []ast.Expr{
-				ast.NewIdent("resp"),
-			},
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: ast.NewIdent("make"),
-					Args: []ast.Expr{
-						generateWrapResponseWriterResponseFieldArgType(responseStructName, field.Type),
-						&ast.CallExpr{
-							Fun: ast.NewIdent("len"),
-							Args: []ast.Expr{
-								ast.NewIdent("baseResp"),
-							},
-						},
-					},
-				},
-			},
+		if g.isAbstract(GraphQLType(field.Type.GetPremitiveType().Name)) {
+			res = append(res, g.generateAbstractResponseUnmarshalJSON(field))
 		}
 	}
 
-	return &ast.AssignStmt{
-		Tok: token.DEFINE,
-		Lhs: []ast.Expr{
-			ast.NewIdent("resp"),
-		},
-		Rhs: []ast.Expr{
-			&ast.CallExpr{
-				Fun: ast.NewIdent("new"),
-				Args: []ast.Expr{
-					ast.NewIdent(responseStructName),
-				},
-			},
-		},
-	}
-}
-
-func generateWrapResponseWriterResponseFieldWalkerBody(responseStructName string, field *schema.FieldDefinition, typeDefinition *schema.TypeDefinition) *ast.BlockStmt {
-	stmts := []ast.Stmt{
-		generateWrapResponseWriterResponseInitializeStmt(responseStructName, field),
-		&ast.ExprStmt{X: &ast.BasicLit{}},
-	}
-	stmts = append(stmts, generateWrapResponseWriterNestedTypeInitializer(responseStructName, field)...)
-	stmts = append(stmts, generateWrapResponseWriterResponseFieldWalkerStmts(field, typeDefinition))
-	stmts = append(stmts, &ast.ReturnStmt{
-		Results: []ast.Expr{
-			ast.NewIdent("resp"),
-		},
-	})
-
-	return &ast.BlockStmt{
-		List: stmts,
-	}
+	return res
 }
 
-func generateWrapResponseWriterResponseFieldArgType(structName string, fieldType *schema.FieldType) ast.Expr {
-	if fieldType.IsList {
-		return &ast.ArrayType{
-			Elt: generateWrapResponseWriterResponseFieldArgType(structName, fieldType.ListType),
-		}
-	}
-
-	if fieldType.Nullable {
-		return &ast.StarExpr{
-			X: &ast.Ident{
-				Name: structName,
-			},
-		}
-	}
-
-	return &ast.Ident{
-		Name: structName,
-	}
-}
-
-func generateWrapResponseWriterResponseFieldWalker(operationName string, field *schema.FieldDefinition, typeDefinition *schema.TypeDefinition) ast.Decl {
-	methodSufix := string(field.Name)
-
-	responseStructName := operationName + string(typeDefinition.Name) + "Response"
-
-	var resultType ast.Expr = &ast.StarExpr{
-		X: ast.NewIdent(responseStructName),
-	}
-
-	if field.Type.IsList {
-		resultType = generateWrapResponseWriterResponseFieldArgType(responseStructName, field.Type)
-	}
-
-	return &ast.FuncDecl{
-		Name: ast.NewIdent("walk" + methodSufix),
-		Recv: &ast.FieldList{
-			List: []*ast.Field{
-				{
-					Names: []*ast.Ident{ast.NewIdent("w")},
-					Type: &ast.StarExpr{
-						X: &ast.Ident{
-							Name: "Wrap" + string(operationName) + "ResponseWriter",
+func generateWrapResponseWriterStruct(field *schema.FieldDefinition) *ast.GenDecl {
+	return &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: ast.NewIdent("Wrap" + string(field.Name) + "ResponseWriter"),
+				Type: &ast.StructType{
+					Fields: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Type: &ast.SelectorExpr{
+									X:   ast.NewIdent("http"),
+									Sel: ast.NewIdent("ResponseWriter"),
+								},
+							},
+							{
+								Names: []*ast.Ident{ast.NewIdent("ctx")},
+								Type: &ast.SelectorExpr{
+									X:   ast.NewIdent("context"),
+									Sel: ast.NewIdent("Context"),
+								},
+							},
+							{
+								Names: []*ast.Ident{ast.NewIdent("r")},
+								Type: &ast.StarExpr{
+									X: ast.NewIdent("resolver"),
+								},
+							},
+							{
+								Names: []*ast.Ident{ast.NewIdent("key")},
+								Type:  ast.NewIdent("string"),
+							},
+							{
+								Names: []*ast.Ident{ast.NewIdent("selections")},
+								Type: &ast.ArrayType{
+									Len: nil,
+									Elt: &ast.SelectorExpr{
+										X:   ast.NewIdent("query"),
+										Sel: ast.NewIdent("Selection"),
+									},
+								},
+							},
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("variables"),
+								},
+								Type: &ast.SelectorExpr{
+									X:   ast.NewIdent("json"),
+									Sel: ast.NewIdent("RawMessage"),
+								},
+							},
+							{
+								Names: []*ast.Ident{ast.NewIdent("loc")},
+								Type: &ast.StarExpr{
+									X: &ast.SelectorExpr{
+										X:   ast.NewIdent("query"),
+										Sel: ast.NewIdent("Loc"),
+									},
+								},
+							},
+							{
+								Names: []*ast.Ident{ast.NewIdent("statusCode")},
+								Type:  ast.NewIdent("int"),
+							},
+							{
+								Names: []*ast.Ident{ast.NewIdent("result")},
+								Type: &ast.StarExpr{
+									X: &ast.SelectorExpr{
+										X:   ast.NewIdent("executor"),
+										Sel: ast.NewIdent("RootFieldResult"),
+									},
+								},
+							},
 						},
 					},
 				},
 			},
 		},
+	}
+}
+
+func generateWrapResponseWriterFunc(field *schema.FieldDefinition) *ast.FuncDecl {
+	return &ast.FuncDecl{
+		Name: ast.NewIdent("new" + string(field.Name) + "Writer"),
 		Type: &ast.FuncType{
 			Params: &ast.FieldList{
 				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("ctx")},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("context"),
+							Sel: ast.NewIdent("Context"),
+						},
+					},
+					{
+						Names: []*ast.Ident{ast.NewIdent("r")},
+						Type: &ast.StarExpr{
+							X: ast.NewIdent("resolver"),
+						},
+					},
+					{
+						Names: []*ast.Ident{ast.NewIdent("w")},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("http"),
+							Sel: ast.NewIdent("ResponseWriter"),
+						},
+					},
+					{
+						Names: []*ast.Ident{ast.NewIdent("key")},
+						Type:  ast.NewIdent("string"),
+					},
 					{
 						Names: []*ast.Ident{ast.NewIdent("selections")},
 						Type: &ast.ArrayType{
+							Len: nil,
 							Elt: &ast.SelectorExpr{
 								X:   ast.NewIdent("query"),
 								Sel: ast.NewIdent("Selection"),
@@ -989,250 +863,146 @@ func generateWrapResponseWriterResponseFieldWalker(operationName string, field *
 						},
 					},
 					{
-						Names: []*ast.Ident{ast.NewIdent("baseResp")},
-						Type:  generateTypeExprFromFieldType(field.Type),
+						Names: []*ast.Ident{
+							ast.NewIdent("variables"),
+						},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("json"),
+							Sel: ast.NewIdent("RawMessage"),
+						},
+					},
+					{
+						Names: []*ast.Ident{ast.NewIdent("loc")},
+						Type: &ast.StarExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent("query"),
+								Sel: ast.NewIdent("Loc"),
+							},
+						},
 					},
 				},
 			},
 			Results: &ast.FieldList{
 				List: []*ast.Field{
 					{
-						Type: resultType,
-					},
-				},
-			},
-		},
-		Body: generateWrapResponseWriterResponseFieldWalkerBody(responseStructName, field, typeDefinition),
-	}
-}
-
-func generateResponseStructDeclsForWrapResponseWriter(rootFieldName string, field *schema.FieldDefinition, typeDefinition *schema.TypeDefinition, index map[string]*schema.TypeDefinition) []ast.Decl {
-	fields := make([]*ast.Field, 0, len(typeDefinition.Fields))
-	operationName := rootFieldName
-	structPrefix := operationName + string(typeDefinition.Name)
-
-	ret := make([]ast.Decl, 0)
-	for _, field := range typeDefinition.Fields {
-		graphqlType := GraphQLType(field.Type.Name)
-
-		var typeExpr ast.Expr
-		typeExpr = &ast.StarExpr{
-			X: ast.NewIdent(string(graphqlType.golangType())),
-		}
-
-		targetField := field
-		if !graphqlType.IsPrimitive() && field.Type.IsList {
-			ft := field.Type
-			for ft.IsList {
-				ft = ft.ListType
-			}
-			td := index[string(ft.Name)]
-
-			typeExpr = generateWrapResponseWriterResponseFieldArgType(rootFieldName+string(td.Name)+"Response", field.Type)
-
-			graphqlType = GraphQLType(td.TypeName())
-			if graphqlType == "" {
-				panic("unknown type")
-			}
-
-			ret = append(ret, generateResponseStructDeclsForWrapResponseWriter(rootFieldName, field, td, index)...)
-		}
-
-		if !graphqlType.IsPrimitive() && !field.Type.IsList {
-			graphqlType = GraphQLType(field.Type.Name)
-			if isLowerCase(string(graphqlType.golangType())) {
-				graphqlType = GraphQLType(toUpperCase(string(graphqlType.golangType())))
-			}
-
-			typeExpr = &ast.StarExpr{
-				X: ast.NewIdent(rootFieldName + graphqlType.golangType() + "Response"),
-			}
-		}
-
-		fieldName := FieldName(targetField.Name)
-		tag := fmt.Sprintf("`json:\"%s,omitempty\"`", string(targetField.Name))
-		// TODO: implment validation
-		// if !targetField.Type.Nullable {
-		// 	tag = fmt.Sprintf("`json:\"%s\"`", string(targetField.Name))
-		// }
-		fields = append(fields, &ast.Field{
-			Tag: &ast.BasicLit{
-				Kind:  token.STRING,
-				Value: tag,
-			},
-			Names: []*ast.Ident{ast.NewIdent(fieldName.ExportedGolangFieldName())},
-			Type:  typeExpr,
-		})
-	}
-
-	structDecl := &ast.GenDecl{
-		Tok: token.TYPE,
-		Specs: []ast.Spec{
-			&ast.TypeSpec{
-				Name: ast.NewIdent(structPrefix + "Response"),
-				Type: &ast.StructType{
-					Fields: &ast.FieldList{
-						List: fields,
+						Type: &ast.StarExpr{
+							X: &ast.Ident{
+								Name: "Wrap" + string(field.Name) + "ResponseWriter",
+							},
+						},
 					},
 				},
 			},
 		},
-	}
-
-	typeFieldName := FieldName(typeDefinition.TypeName())
-	fieldName := FieldName(string(rootFieldName) + string(typeFieldName))
-
-	typeExpr := generateWrapResponseWriterResponseTypeExprBasedTypeName(rootFieldName+typeFieldName.ExportedGolangFieldName()+"Response", field.Type)
-	if !field.Type.IsList && !field.IsPremitive() {
-		typeExpr = &ast.StarExpr{
-			X: typeExpr,
-		}
-	}
-
-	wrapStructDecl := &ast.GenDecl{
-		Tok: token.TYPE,
-		Specs: []ast.Spec{
-			&ast.TypeSpec{
-				Name: ast.NewIdent("wrap" + fieldName.ExportedGolangFieldName() + "Response"),
-				Type: &ast.StructType{
-					Fields: &ast.FieldList{
-						List: []*ast.Field{
-							{
-								Names: []*ast.Ident{ast.NewIdent(fieldName.ExportedGolangFieldName() + "Response")},
-								Tag: &ast.BasicLit{
-									Kind:  token.STRING,
-									Value: fmt.Sprintf("`json:\"%s\"`", string(rootFieldName)),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.UnaryExpr{
+							Op: token.AND,
+							X: &ast.CompositeLit{
+								Type: ast.NewIdent("Wrap" + string(field.Name) + "ResponseWriter"),
+								Elts: []ast.Expr{
+									&ast.KeyValueExpr{
+										Key:   ast.NewIdent("ResponseWriter"),
+										Value: ast.NewIdent("w"),
+									},
+									&ast.KeyValueExpr{
+										Key:   ast.NewIdent("ctx"),
+										Value: ast.NewIdent("ctx"),
+									},
+									&ast.KeyValueExpr{
+										Key:   ast.NewIdent("r"),
+										Value: ast.NewIdent("r"),
+									},
+									&ast.KeyValueExpr{
+										Key:   ast.NewIdent("key"),
+										Value: ast.NewIdent("key"),
+									},
+									&ast.KeyValueExpr{
+										Key:   ast.NewIdent("selections"),
+										Value: ast.NewIdent("selections"),
+									},
+									&ast.KeyValueExpr{
+										Key:   ast.NewIdent("variables"),
+										Value: ast.NewIdent("variables"),
+									},
+									&ast.KeyValueExpr{
+										Key:   ast.NewIdent("loc"),
+										Value: ast.NewIdent("loc"),
+									},
 								},
-								Type: typeExpr,
 							},
 						},
 					},
 				},
 			},
 		},
+		Doc: &ast.CommentGroup{
+			List: []*ast.Comment{
+				{
+					Text: "// *********** AUTO GENERATED CODE ***********",
+				},
+				{
+					Text: "// *********** DON'T EDIT ***********",
+				},
+			},
+		},
 	}
-
-	ret = append(ret, structDecl)
-	ret = append(ret, wrapStructDecl)
-	ret = append(ret, generateWrapResponseWriterResponseFieldWalker(rootFieldName, field, typeDefinition))
-
-	for _, field := range typeDefinition.Fields {
-		if field.IsPremitive() {
-			continue
-		}
-
-		typeDefinition := index[string(field.Type.Name)]
-		if typeDefinition == nil {
-			continue
-		}
-
-		ret = append(ret, generateResponseStructDeclsForWrapResponseWriter(rootFieldName, field, typeDefinition, index)...)
-	}
-
-	return ret
-}
-
-func generateWrapResponseWriterResponseTypeExprBasedTypeName(basedTypeName string, fieldType *schema.FieldType) ast.Expr {
-	graphqlType := GraphQLType(fieldType.Name)
-
-	if fieldType.IsList {
-		return &ast.ArrayType{
-			Elt: generateWrapResponseWriterResponseTypeExprBasedTypeName(basedTypeName, fieldType.ListType),
-		}
-	}
-
-	if graphqlType == "" {
-		return &ast.Ident{
-			Name: "interface{}",
-		}
-	}
-
-	return &ast.Ident{
-		Name: basedTypeName,
-	}
-}
-
-func extractRootGraphQLType(fieldType *schema.FieldType) GraphQLType {
-	if fieldType.IsList {
-		return extractRootGraphQLType(fieldType.ListType)
-	}
-
-	return GraphQLType(fieldType.Name)
 }
 
-func generateWrapResponseWriterWrite(rootFieldName string, field *schema.FieldDefinition) *ast.FuncDecl {
-	graphqlType := GraphQLType(field.Type.Name)
-
-	if field.Type.IsList {
-		ft := field.Type
-		for ft.IsList {
-			ft = ft.ListType
-		}
-		graphqlType = GraphQLType(ft.Name)
-	}
-
-	if graphqlType == "" {
-		return &ast.FuncDecl{
-			Name: ast.NewIdent("Write"),
-			Recv: &ast.FieldList{
+func generateWrapResponseWriterWriteHeader(field *schema.FieldDefinition) *ast.FuncDecl {
+	return &ast.FuncDecl{
+		Name: ast.NewIdent("WriteHeader"),
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{ast.NewIdent("w")},
+					Type: &ast.StarExpr{
+						X: ast.NewIdent("Wrap" + string(field.Name) + "ResponseWriter"),
+					},
+				},
+			},
+		},
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
 				List: []*ast.Field{
 					{
-						Names: []*ast.Ident{ast.NewIdent("w")},
-						Type: &ast.StarExpr{
-							X: &ast.Ident{
-								Name: "Wrap" + string(field.Name) + "ResponseWriter",
-							},
-						},
+						Names: []*ast.Ident{ast.NewIdent("statusCode")},
+						Type:  ast.NewIdent("int"),
 					},
 				},
 			},
-			Type: &ast.FuncType{
-				Params: &ast.FieldList{
-					List: []*ast.Field{
-						{
-							Names: []*ast.Ident{ast.NewIdent("b")},
-							Type: &ast.ArrayType{
-								Elt: &ast.Ident{
-									Name: "byte",
-								},
-								Len: nil,
-							},
-						},
-					},
+		},
+		Doc: &ast.CommentGroup{
+			List: []*ast.Comment{
+				{
+					Text: "// *********** AUTO GENERATED CODE ***********",
 				},
-				Results: &ast.FieldList{
-					List: []*ast.Field{
-						{
-							Type: &ast.Ident{
-								Name: "int",
-							},
-						},
-						{
-							Type: &ast.Ident{
-								Name: "error",
-							},
-						},
-					},
+				{
+					Text: "// *********** DON'T EDIT ***********",
 				},
 			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ReturnStmt{
-						Results: []ast.Expr{
-							ast.NewIdent("0"),
-							ast.NewIdent("nil"),
-						},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.BasicLit{
+						Kind:  token.STRING,
+						Value: `// the status of a failing resolver is written to the errors of the response instead`,
 					},
 				},
+				&ast.AssignStmt{
+					Tok: token.ASSIGN,
+					Lhs: []ast.Expr{ast.NewIdent("w.statusCode")},
+					Rhs: []ast.Expr{ast.NewIdent("statusCode")},
+				},
 			},
-		}
-	}
-
-	var argExpr ast.Expr = &ast.SelectorExpr{
-		X:   ast.NewIdent("resp"),
-		Sel: ast.NewIdent("Data"),
+		},
 	}
+}
 
+func (g *Generator) generateWrapResponseWriterWrite(rootFieldName string, field *schema.FieldDefinition) *ast.FuncDecl {
 	return &ast.FuncDecl{
 		Name: ast.NewIdent("Write"),
 		Recv: &ast.FieldList{
@@ -1306,6 +1076,12 @@ func generateWrapResponseWriterWrite(rootFieldName string, field *schema.FieldDe
 				&ast.ExprStmt{
 					X: &ast.BasicLit{},
 				},
+				&ast.ExprStmt{
+					X: &ast.BasicLit{
+						Kind:  token.STRING,
+						Value: `// a resolver which doesn't write a GraphQL response, such as with http.Error, fails the root field`,
+					},
+				},
 				&ast.IfStmt{
 					Init: &ast.AssignStmt{
 						Lhs: []ast.Expr{
@@ -1326,22 +1102,28 @@ func generateWrapResponseWriterWrite(rootFieldName string, field *schema.FieldDe
 					},
 					Body: &ast.BlockStmt{
 						List: []ast.Stmt{
-							&ast.ReturnStmt{
-								Results: []ast.Expr{
-									&ast.CallExpr{
-										Fun: &ast.SelectorExpr{
-											X: &ast.SelectorExpr{
-												X:   ast.NewIdent("w"),
-												Sel: ast.NewIdent("ResponseWriter"),
-											},
-											Sel: ast.NewIdent("Write"),
-										},
-										Args: []ast.Expr{
-											ast.NewIdent(`[]byte(fmt.Sprintf("failed to Unmarshal\nuse \"executor.GraphQLResponse\" for response: %s", err.Error()))`),
+							&ast.AssignStmt{
+								Tok: token.DEFINE,
+								Lhs: []ast.Expr{ast.NewIdent("message")},
+								Rhs: []ast.Expr{ast.NewIdent(`fmt.Sprintf("failed to Unmarshal\nuse \"executor.GraphQLResponse\" for response: %s", err.Error())`)},
+							},
+							&ast.IfStmt{
+								Cond: ast.NewIdent("w.statusCode >= http.StatusBadRequest"),
+								Body: &ast.BlockStmt{
+									List: []ast.Stmt{
+										&ast.AssignStmt{
+											Tok: token.ASSIGN,
+											Lhs: []ast.Expr{ast.NewIdent("message")},
+											Rhs: []ast.Expr{ast.NewIdent("strings.TrimSpace(string(b))")},
 										},
 									},
 								},
 							},
+							&ast.AssignStmt{
+								Tok: token.ASSIGN,
+								Lhs: []ast.Expr{ast.NewIdent("resp.Errors")},
+								Rhs: []ast.Expr{ast.NewIdent("executor.GraphQLErrors{executor.NewGraphQLError(executor.ErrorCodeInternal, message).WithLocations(w.loc)}")},
+							},
 						},
 					},
 				},
@@ -1349,143 +1131,53 @@ func generateWrapResponseWriterWrite(rootFieldName string, field *schema.FieldDe
 					X: &ast.BasicLit{},
 				},
 
-				&ast.AssignStmt{
-					Tok: token.DEFINE,
-					Lhs: []ast.Expr{
-						ast.NewIdent("selected"),
-					},
-					Rhs: []ast.Expr{
-						&ast.UnaryExpr{
-							Op: token.AND,
-							X: &ast.CompositeLit{
-								Type: ast.NewIdent("wrap" + FieldName(rootFieldName).ExportedGolangFieldName() + extractRootGraphQLType(field.Type).golangType() + "Response"),
-								Elts: []ast.Expr{
-									&ast.KeyValueExpr{
-										Key: ast.NewIdent(FieldName(rootFieldName).ExportedGolangFieldName() + extractRootGraphQLType(field.Type).golangType() + "Response"),
-										Value: &ast.CallExpr{
-											Fun: &ast.SelectorExpr{
-												X:   ast.NewIdent("w"),
-												Sel: ast.NewIdent("walk" + string(rootFieldName)),
-											},
-											Args: []ast.Expr{
-												&ast.SelectorExpr{
-													X:   ast.NewIdent("w"),
-													Sel: ast.NewIdent("selections"),
-												},
-												argExpr,
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-
-				&ast.AssignStmt{
-					Lhs: []ast.Expr{
-						ast.NewIdent("selectedResp"),
-					},
-					Tok: token.DEFINE,
-					Rhs: []ast.Expr{
-						&ast.CallExpr{
-							Fun: ast.NewIdent("make"),
-							Args: []ast.Expr{
-								&ast.MapType{
-									Key:   &ast.Ident{Name: "string"},
-									Value: ast.NewIdent("any"),
-								},
-							},
-						},
-					},
-				},
-
-				&ast.AssignStmt{
-					Tok: token.ASSIGN,
-					Lhs: []ast.Expr{
-						&ast.IndexExpr{
-							X:     ast.NewIdent("selectedResp"),
-							Index: ast.NewIdent("\"data\""),
-						},
-					},
-					Rhs: []ast.Expr{
-						ast.NewIdent("selected"),
+				&ast.ExprStmt{
+					X: &ast.BasicLit{
+						Kind:  token.STRING,
+						Value: `// the errors of the resolver null the fields at their paths from the root field`,
 					},
 				},
-
 				&ast.AssignStmt{
 					Tok: token.ASSIGN,
-					Lhs: []ast.Expr{
-						&ast.IndexExpr{
-							X:     ast.NewIdent("selectedResp"),
-							Index: ast.NewIdent("\"errors\""),
-						},
-					},
-					Rhs: []ast.Expr{
-						&ast.SelectorExpr{
-							X:   ast.NewIdent("resp"),
-							Sel: ast.NewIdent("Errors"),
-						},
-					},
-				},
-
-				&ast.ExprStmt{
-					X: &ast.BasicLit{},
-				},
-
-				&ast.AssignStmt{
-					Tok: token.DEFINE,
-					Lhs: []ast.Expr{
-						ast.NewIdent("respByte"),
-						ast.NewIdent("err"),
-					},
+					Lhs: []ast.Expr{ast.NewIdent("w.result")},
 					Rhs: []ast.Expr{
 						&ast.CallExpr{
 							Fun: &ast.SelectorExpr{
-								X:   ast.NewIdent("json"),
-								Sel: ast.NewIdent("Marshal"),
+								X:   ast.NewIdent("executor"),
+								Sel: ast.NewIdent("CompleteRootField"),
 							},
 							Args: []ast.Expr{
-								ast.NewIdent("selectedResp"),
-							},
-						},
-					},
-				},
-
-				&ast.IfStmt{
-					Cond: &ast.BinaryExpr{
-						X:  ast.NewIdent("err"),
-						Op: token.NEQ,
-						Y:  ast.NewIdent("nil"),
-					},
-					Body: &ast.BlockStmt{
-						List: []ast.Stmt{
-							&ast.ExprStmt{
-								X: &ast.CallExpr{
-									Fun: &ast.SelectorExpr{
-										X: &ast.SelectorExpr{
-											X:   ast.NewIdent("w"),
-											Sel: ast.NewIdent("ResponseWriter"),
+								ast.NewIdent("w.key"),
+								ast.NewIdent(strconv.FormatBool(!field.Type.Nullable)),
+								ast.NewIdent("w.loc"),
+								ast.NewIdent("resp.Errors"),
+								&ast.FuncLit{
+									Type: &ast.FuncType{
+										Params: &ast.FieldList{
+											List: []*ast.Field{
+												{
+													Names: []*ast.Ident{ast.NewIdent("errs")},
+													Type:  ast.NewIdent("*executor.FieldErrors"),
+												},
+												{
+													Names: []*ast.Ident{ast.NewIdent("path")},
+													Type:  ast.NewIdent("executor.Path"),
+												},
+											},
 										},
-										Sel: ast.NewIdent("WriteHeader"),
-									},
-									Args: []ast.Expr{
-										ast.NewIdent("http.StatusInternalServerError"),
-									},
-								},
-							},
-							&ast.ReturnStmt{
-								Results: []ast.Expr{
-									&ast.CallExpr{
-										Fun: &ast.SelectorExpr{
-											X: &ast.SelectorExpr{
-												X:   ast.NewIdent("w"),
-												Sel: ast.NewIdent("ResponseWriter"),
+										Results: &ast.FieldList{
+											List: []*ast.Field{
+												{Type: ast.NewIdent("any")},
 											},
-											Sel: ast.NewIdent("Write"),
 										},
-										Args: []ast.Expr{
-											ast.NewIdent("[]byte(err.Error())"),
+									},
+									Body: &ast.BlockStmt{
+										List: []ast.Stmt{
+											&ast.ReturnStmt{
+												Results: []ast.Expr{
+													ast.NewIdent(g.generateWalkExpr(field.Type, "resp.Data", "w.r", "w.ctx", "w.selections", "w.variables", "w.loc", false)),
+												},
+											},
 										},
 									},
 								},
@@ -1493,20 +1185,13 @@ func generateWrapResponseWriterWrite(rootFieldName string, field *schema.FieldDe
 						},
 					},
 				},
+				&ast.ExprStmt{
+					X: &ast.BasicLit{},
+				},
 				&ast.ReturnStmt{
 					Results: []ast.Expr{
-						&ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X: &ast.SelectorExpr{
-									X:   ast.NewIdent("w"),
-									Sel: ast.NewIdent("ResponseWriter"),
-								},
-								Sel: ast.NewIdent("Write"),
-							},
-							Args: []ast.Expr{
-								ast.NewIdent("respByte"),
-							},
-						},
+						ast.NewIdent("len(b)"),
+						ast.NewIdent("nil"),
 					},
 				},
 			},
@@ -1514,97 +1199,221 @@ func generateWrapResponseWriterWrite(rootFieldName string, field *schema.FieldDe
 	}
 }
 
-func generateExecutorBody(op *schema.OperationDefinition, operationType string) *ast.BlockStmt {
-	body := []ast.Stmt{}
+// generateCompleteFuncLit generates the function passed to executor.FieldErrors.Complete,
+// which returns value completed at path.
+func generateCompleteFuncLit(value ast.Expr) *ast.FuncLit {
+	return &ast.FuncLit{
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("path")},
+						Type:  ast.NewIdent("executor.Path"),
+					},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{Type: ast.NewIdent("any")},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{value},
+				},
+			},
+		},
+	}
+}
 
-	if op == nil {
-		return &ast.BlockStmt{
-			List: body,
+// generateWalkExpr returns the expression which completes value of fieldType at path with selections.
+// Scalar values are returned as they are, objects, unions and interfaces are walked by the walkers of resolver with ctx, and
+// the items of lists are completed at their indexes, where loc is the location of the field for the errors of null items.
+// The items of concurrent types are completed with the workers of ctx.
+func (g *Generator) generateWalkExpr(fieldType *schema.FieldType, value, resolver, ctx, selections, variables, loc string, nullableListIsPointer bool) string {
+	if fieldType.IsList {
+		// lists of leaves are completed as well, so that every item is checked at its own path.
+		graphQLType := GraphQLType(fieldType.GetPremitiveType().Name)
+		completeList, args := "executor.CompleteList", "errs"
+		if fieldType.Nullable && nullableListIsPointer {
+			completeList = "executor.CompleteNullableList"
+		}
+
+		if g.isConcurrent(graphQLType) {
+			// the items are walked concurrently, so that the loads of their field resolvers are batched together.
+			completeList, args = completeList+"Concurrently", ctx+", errs"
 		}
+
+		return fmt.Sprintf("%s(%s, path, %s, %t, %s, func(path executor.Path, v %s) any { return %s })", completeList, args, loc, !fieldType.ListType.Nullable, value, g.goTypeString(fieldType.ListType, "model.", nullableListIsPointer), g.generateWalkExpr(fieldType.ListType, "v", resolver, ctx, selections, variables, loc, nullableListIsPointer))
 	}
 
-	var methodName, executorName string
-	if operationType == "query" {
-		methodName = "GetQuery"
-		executorName = "queryExecutor"
+	graphQLType := GraphQLType(fieldType.Name)
+	if !g.isObject(graphQLType) && !g.isAbstract(graphQLType) {
+		return value
 	}
 
-	if operationType == "mutation" {
-		methodName = "GetMutation"
-		executorName = "mutationExecutor"
+	if !fieldType.Nullable && !g.isAbstract(graphQLType) {
+		value = "&" + value
 	}
 
-	if operationType == "subscription" {
-		methodName = "GetSubscription"
-		executorName = "subscriptionExecutor"
+	return fmt.Sprintf("%s.walk%s(%s, errs, path, %s, %s, %s)", resolver, g.golangType(graphQLType), ctx, selections, variables, value)
+}
+
+// generateWalkers generates the walkers of the types of s, where fieldResolvers are the fields resolved by field resolvers.
+func (g *Generator) generateWalkers(s *schema.Schema, fieldResolvers map[*schema.FieldDefinition]struct{}) []ast.Decl {
+	decls := make([]ast.Decl, 0, len(s.Types)+len(s.Unions)+len(s.Interfaces))
+
+	for _, t := range s.Types {
+		decls = append(decls, g.generateObjectWalker(t, abstractTypeNames(s, t), fieldResolvers))
 	}
 
-	bodyStmt := make([]ast.Stmt, 0)
-	for _, field := range op.Fields {
-		caseBody := make([]ast.Stmt, 0)
-		fieldName := fmt.Sprintf("\"%s\"", field.Name)
-		caseBody = append(caseBody, generateBodyForArgument(methodName, string(fieldName))...)
-		caseBody = append(caseBody, &ast.AssignStmt{
-			Tok: token.ASSIGN,
-			Lhs: []ast.Expr{
-				ast.NewIdent("w"),
+	for _, u := range s.Unions {
+		decls = append(decls, generateAbstractWalker(string(u.Name), possibleTypes(s, string(u.Name))))
+	}
+
+	for _, i := range s.Interfaces {
+		decls = append(decls, generateAbstractWalker(string(i.Name), possibleTypes(s, string(i.Name))))
+	}
+
+	return decls
+}
+
+func generateResolverRecv() *ast.FieldList {
+	return &ast.FieldList{
+		List: []*ast.Field{
+			{
+				Names: []*ast.Ident{ast.NewIdent("r")},
+				Type: &ast.StarExpr{
+					X: ast.NewIdent("resolver"),
+				},
 			},
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: ast.NewIdent("new" + string(field.Name) + "Writer"),
-					Args: []ast.Expr{
-						ast.NewIdent("w"),
-						&ast.SelectorExpr{
-							X:   ast.NewIdent("node"),
-							Sel: ast.NewIdent("SelectSets"),
+		},
+	}
+}
+
+func generateWalkerFuncType(valueType ast.Expr) *ast.FuncType {
+	return &ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{ast.NewIdent("ctx")},
+					Type: &ast.SelectorExpr{
+						X:   ast.NewIdent("context"),
+						Sel: ast.NewIdent("Context"),
+					},
+				},
+				{
+					Names: []*ast.Ident{ast.NewIdent("errs")},
+					Type: &ast.StarExpr{
+						X: &ast.SelectorExpr{
+							X:   ast.NewIdent("executor"),
+							Sel: ast.NewIdent("FieldErrors"),
 						},
-						ast.NewIdent("variables"),
 					},
 				},
-			},
-		})
-		caseBody = append(caseBody,
-			&ast.ExprStmt{X: &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   ast.NewIdent("r"),
-					Sel: ast.NewIdent(toUpperCase(string(field.Name))),
+				{
+					Names: []*ast.Ident{ast.NewIdent("path")},
+					Type: &ast.SelectorExpr{
+						X:   ast.NewIdent("executor"),
+						Sel: ast.NewIdent("Path"),
+					},
 				},
-				Args: []ast.Expr{
-					ast.NewIdent("w"),
-					ast.NewIdent("req"),
+				{
+					Names: []*ast.Ident{ast.NewIdent("selections")},
+					Type: &ast.ArrayType{
+						Elt: &ast.SelectorExpr{
+							X:   ast.NewIdent("query"),
+							Sel: ast.NewIdent("Selection"),
+						},
+					},
 				},
-			}},
-			&ast.RangeStmt{
-				Value: ast.NewIdent("child"),
-				Tok:   token.DEFINE,
-				Key:   ast.NewIdent("_"),
-				X: &ast.SelectorExpr{
-					X:   ast.NewIdent("node"),
-					Sel: ast.NewIdent("Children"),
+				{
+					Names: []*ast.Ident{ast.NewIdent("variables")},
+					Type: &ast.SelectorExpr{
+						X:   ast.NewIdent("json"),
+						Sel: ast.NewIdent("RawMessage"),
+					},
 				},
-				Body: &ast.BlockStmt{
-					List: []ast.Stmt{
-						&ast.IfStmt{
-							Cond: &ast.BinaryExpr{
-								X:  ast.NewIdent("child.SelectSets"),
-								Op: token.NEQ,
-								Y:  ast.NewIdent("nil"),
-							},
-							Body: &ast.BlockStmt{
-								List: []ast.Stmt{
-									&ast.ExprStmt{X: &ast.CallExpr{
-										Fun: &ast.SelectorExpr{
-											X:   ast.NewIdent("r"),
-											Sel: ast.NewIdent(executorName),
-										},
-										Args: []ast.Expr{
-											ast.NewIdent("w"),
-											ast.NewIdent("req"),
-											ast.NewIdent("child"),
-											ast.NewIdent("parsedQuery"),
-											ast.NewIdent("variables"),
-										},
+				{
+					Names: []*ast.Ident{ast.NewIdent("v")},
+					Type:  valueType,
+				},
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: &ast.SelectorExpr{
+							X:   ast.NewIdent("executor"),
+							Sel: ast.NewIdent("OrderedMap"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateResponseSet(value ast.Expr) ast.Stmt {
+	return &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("resp"),
+				Sel: ast.NewIdent("Set"),
+			},
+			Args: []ast.Expr{
+				ast.NewIdent("string(sel.ResponseKey())"),
+				value,
+			},
+		},
+	}
+}
+
+// generateCompleteField generates the statements which complete the value of f of t at its path,
+// returning null from the walker when a null non-null field nulls the object.
+// The value of a field resolved by a field resolver is returned by the resolver instead of the field of the model.
+// In a walker with a field group, the fields resolved by field resolvers or of concurrent types are completed by the group,
+// and the walker waits for the group before it returns null.
+func (g *Generator) generateCompleteField(t *schema.TypeDefinition, f *schema.FieldDefinition, resolved, grouped bool) []ast.Stmt {
+	complete := generateCompleteFuncLit(ast.NewIdent(g.generateWalkExpr(f.Type, "v."+toUpperCase(string(f.Name)), "r", "ctx", "sel.Selections", "variables", "sel.Loc", true)))
+	if resolved {
+		complete.Body = g.generateFieldResolverCall(t, f)
+	}
+
+	completeCall := &ast.CallExpr{
+		Fun: ast.NewIdent("errs.Complete"),
+		Args: []ast.Expr{
+			ast.NewIdent("path.Append(executor.PathKey(sel.ResponseKey()))"),
+			ast.NewIdent(strconv.FormatBool(!f.Type.Nullable)),
+			ast.NewIdent("sel.Loc"),
+			complete,
+		},
+	}
+
+	if grouped && (resolved || g.isConcurrent(GraphQLType(f.Type.GetPremitiveType().Name))) {
+		return []ast.Stmt{
+			&ast.ExprStmt{
+				X: &ast.CallExpr{
+					Fun: ast.NewIdent("fields.Go"),
+					Args: []ast.Expr{
+						ast.NewIdent("string(sel.ResponseKey())"),
+						&ast.FuncLit{
+							Type: &ast.FuncType{
+								Params: &ast.FieldList{},
+								Results: &ast.FieldList{
+									List: []*ast.Field{
+										{Type: ast.NewIdent("any")},
+										{Type: ast.NewIdent("bool")},
 									},
+								},
+							},
+							Body: &ast.BlockStmt{
+								List: []ast.Stmt{
+									&ast.ReturnStmt{
+										Results: []ast.Expr{completeCall},
 									},
 								},
 							},
@@ -1612,225 +1421,835 @@ func generateExecutorBody(op *schema.OperationDefinition, operationType string)
 					},
 				},
 			},
-		)
-
-		bodyStmt = append(bodyStmt, &ast.CaseClause{
-			List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: fieldName}},
-			Body: caseBody,
-		})
+		}
 	}
-	body = append(body, &ast.SwitchStmt{
-		Tag: ast.NewIdent("string(node.Name)"),
-		Body: &ast.BlockStmt{
-			List: bodyStmt,
-		},
-	})
 
-	return &ast.BlockStmt{
-		List: body,
+	nulled := []ast.Stmt{
+		&ast.ReturnStmt{
+			Results: []ast.Expr{ast.NewIdent("nil")},
+		},
+	}
+	if grouped {
+		nulled = append([]ast.Stmt{&ast.ExprStmt{X: ast.NewIdent("fields.Wait()")}}, nulled...)
 	}
-}
 
-func generateBodyForArgument(operationMethodName, fieldName string) []ast.Stmt {
 	return []ast.Stmt{
 		&ast.AssignStmt{
 			Tok: token.DEFINE,
-			Lhs: []ast.Expr{
-				ast.NewIdent("args"),
+			Lhs: []ast.Expr{ast.NewIdent("value"), ast.NewIdent("ok")},
+			Rhs: []ast.Expr{completeCall},
+		},
+		&ast.IfStmt{
+			Cond: ast.NewIdent("!ok"),
+			Body: &ast.BlockStmt{
+				List: nulled,
 			},
-			Rhs: []ast.Expr{
-				&ast.SelectorExpr{
-					X:   ast.NewIdent("utils"),
-					Sel: ast.NewIdent(fmt.Sprintf("ExtractSelectorArgs(parsedQuery.Operations.%s(), %s)", operationMethodName, fieldName)),
+		},
+		generateResponseSet(ast.NewIdent("value")),
+	}
+}
+
+// generateObjectWalker generates the function which applies a selection set to a value of t.
+// abstractTypeNames are the interfaces and unions t belongs to, whose inline fragments are applied as well.
+// The fields in fieldResolvers are resolved by their field resolvers only when they are selected,
+// concurrently with the other fields of a concurrent type in the order of the selection set.
+func (g *Generator) generateObjectWalker(t *schema.TypeDefinition, abstractTypeNames []string, fieldResolvers map[*schema.FieldDefinition]struct{}) ast.Decl {
+	typeNames := []ast.Expr{
+		ast.NewIdent("selections"),
+		ast.NewIdent("variables"),
+		&ast.BasicLit{
+			Kind:  token.STRING,
+			Value: fmt.Sprintf("%q", string(t.Name)),
+		},
+	}
+	for _, name := range abstractTypeNames {
+		typeNames = append(typeNames, &ast.BasicLit{
+			Kind:  token.STRING,
+			Value: fmt.Sprintf("%q", name),
+		})
+	}
+
+	cases := []ast.Stmt{
+		&ast.CaseClause{
+			List: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: `"__typename"`,
 				},
 			},
-		},
-		&ast.AssignStmt{
-			Tok: token.DEFINE,
-			Lhs: []ast.Expr{
-				ast.NewIdent("body"),
-				ast.NewIdent("err"),
+			Body: []ast.Stmt{
+				generateResponseSet(&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf("%q", string(t.Name)),
+				}),
 			},
-			Rhs: []ast.Expr{
-				&ast.SelectorExpr{
-					X:   ast.NewIdent("utils"),
-					Sel: ast.NewIdent("ConvRequestBodyFromVariables(variables, args)"),
+		},
+	}
+
+	grouped := g.isConcurrent(GraphQLType(t.Name))
+	for _, f := range t.Fields {
+		_, resolved := fieldResolvers[f]
+		cases = append(cases, &ast.CaseClause{
+			List: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf("%q", string(f.Name)),
 				},
 			},
-		},
+			Body: g.generateCompleteField(t, f, resolved, grouped),
+		})
+	}
+
+	stmts := []ast.Stmt{
 		&ast.IfStmt{
-			Cond: &ast.BinaryExpr{
-				X:  ast.NewIdent("err"),
-				Op: token.NEQ,
-				Y:  ast.NewIdent("nil"),
-			},
+			Cond: ast.NewIdent("v == nil"),
 			Body: &ast.BlockStmt{
 				List: []ast.Stmt{
-					&ast.ExprStmt{X: &ast.CallExpr{
-						Fun: ast.NewIdent("http.Error"),
-						Args: []ast.Expr{
-							ast.NewIdent("w"),
-							&ast.BasicLit{Kind: token.STRING, Value: "\"Unknown arguments\""},
-							ast.NewIdent("http.StatusUnprocessableEntity"),
-						},
-					}},
-					&ast.ReturnStmt{},
+					&ast.ReturnStmt{
+						Results: []ast.Expr{ast.NewIdent("nil")},
+					},
 				},
 			},
 		},
+		&ast.ExprStmt{X: &ast.BasicLit{}},
 		&ast.AssignStmt{
-			Lhs: []ast.Expr{ast.NewIdent("req.Body")},
-			Tok: token.ASSIGN,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X:   ast.NewIdent("io"),
-						Sel: ast.NewIdent("NopCloser"),
-					},
-					Args: []ast.Expr{
-						&ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X:   ast.NewIdent("strings"),
-								Sel: ast.NewIdent("NewReader"),
-							},
-							Args: []ast.Expr{
-								&ast.CallExpr{
-									Fun: ast.NewIdent("string"),
-									Args: []ast.Expr{
-										ast.NewIdent("body"),
-									},
-								},
-							},
-						},
-					},
-				},
-			},
+			Lhs: []ast.Expr{ast.NewIdent("resp")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{ast.NewIdent("executor.NewOrderedMap()")},
 		},
 	}
-}
 
-func generateServeHTTPBody(query, mutation, subscription *schema.OperationDefinition) *ast.BlockStmt {
-	querySwitchCases := []ast.Stmt{}
-	// req.Body = io.NopCloser(strings.NewReader(string(request.Variables)))
+	if grouped {
+		stmts = append(stmts, &ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent("fields")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{ast.NewIdent("executor.NewFieldGroup(ctx, resp)")},
+		})
+	}
 
-	if query != nil {
-		querySwitchCases = append(querySwitchCases, &ast.ExprStmt{
+	stmts = append(stmts,
+		&ast.RangeStmt{
+			Key:   ast.NewIdent("_"),
+			Value: ast.NewIdent("sel"),
+			Tok:   token.DEFINE,
 			X: &ast.CallExpr{
 				Fun: &ast.SelectorExpr{
-					X:   ast.NewIdent("r"),
-					Sel: ast.NewIdent("queryExecutor"),
+					X:   ast.NewIdent("executor"),
+					Sel: ast.NewIdent("CollectFields"),
 				},
-				Args: []ast.Expr{
-					ast.NewIdent("w"),
-					ast.NewIdent("req"),
-					ast.NewIdent("node"),
-					ast.NewIdent("parsedQuery"),
-					ast.NewIdent("variables"),
+				Args: typeNames,
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.SwitchStmt{
+						Tag: ast.NewIdent("string(sel.Name)"),
+						Body: &ast.BlockStmt{
+							List: cases,
+						},
+					},
+				},
+			},
+		},
+		&ast.ExprStmt{X: &ast.BasicLit{}},
+	)
+
+	if grouped {
+		stmts = append(stmts, &ast.IfStmt{
+			Cond: ast.NewIdent("!fields.Wait()"),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{ast.NewIdent("nil")},
+					},
 				},
 			},
 		})
 	}
 
-	mutationSwitchCases := []ast.Stmt{}
-	if mutation != nil {
-		mutationSwitchCases = append(mutationSwitchCases, &ast.ExprStmt{
-			X: &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   ast.NewIdent("r"),
-					Sel: ast.NewIdent("mutationExecutor"),
+	stmts = append(stmts,
+		&ast.ReturnStmt{
+			Results: []ast.Expr{ast.NewIdent("resp")},
+		},
+	)
+
+	return &ast.FuncDecl{
+		Doc: &ast.CommentGroup{
+			List: []*ast.Comment{
+				{
+					Text: "// *********** AUTO GENERATED CODE ***********",
 				},
-				Args: []ast.Expr{
-					ast.NewIdent("w"),
-					ast.NewIdent("req"),
-					ast.NewIdent("node"),
-					ast.NewIdent("parsedQuery"),
-					ast.NewIdent("variables"),
+				{
+					Text: "// *********** DON'T EDIT ***********",
 				},
 			},
-		})
+		},
+		Name: ast.NewIdent("walk" + string(t.Name)),
+		Recv: generateResolverRecv(),
+		Type: generateWalkerFuncType(&ast.StarExpr{
+			X: &ast.SelectorExpr{
+				X:   ast.NewIdent("model"),
+				Sel: ast.NewIdent(string(t.Name)),
+			},
+		}),
+		Body: &ast.BlockStmt{
+			List: stmts,
+		},
 	}
+}
 
-	subscriptionSwitchCases := []ast.Stmt{}
-	if subscription != nil {
-		subscriptionSwitchCases = append(subscriptionSwitchCases, &ast.ExprStmt{
-			X: &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   ast.NewIdent("r"),
-					Sel: ast.NewIdent("subscriptionExecutor"),
+// generateAbstractWalker generates the function which walks a value of a union or interface
+// as the concrete type it holds.
+func generateAbstractWalker(name string, members []*schema.TypeDefinition) ast.Decl {
+	cases := make([]ast.Stmt, 0, len(members)*2)
+	for _, member := range members {
+		memberType := &ast.SelectorExpr{
+			X:   ast.NewIdent("model"),
+			Sel: ast.NewIdent(string(member.Name)),
+		}
+
+		cases = append(cases, &ast.CaseClause{
+			List: []ast.Expr{memberType},
+			Body: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{ast.NewIdent(fmt.Sprintf("r.walk%s(ctx, errs, path, selections, variables, &v)", string(member.Name)))},
 				},
-				Args: []ast.Expr{
-					ast.NewIdent("w"),
-					ast.NewIdent("req"),
-					ast.NewIdent("node"),
-					ast.NewIdent("parsedQuery"),
-					ast.NewIdent("variables"),
+			},
+		}, &ast.CaseClause{
+			List: []ast.Expr{&ast.StarExpr{X: memberType}},
+			Body: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{ast.NewIdent(fmt.Sprintf("r.walk%s(ctx, errs, path, selections, variables, v)", string(member.Name)))},
 				},
 			},
 		})
 	}
 
-	return &ast.BlockStmt{
-		List: []ast.Stmt{
-			&ast.AssignStmt{
-				Lhs: []ast.Expr{ast.NewIdent("detectOperationType")},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.FuncLit{
-						Type: generateDetectOperationType().Type,
-						Body: generateDetectOperationType().Body,
-					},
+	return &ast.FuncDecl{
+		Doc: &ast.CommentGroup{
+			List: []*ast.Comment{
+				{
+					Text: "// *********** AUTO GENERATED CODE ***********",
 				},
-			},
-
-			&ast.ExprStmt{X: &ast.BasicLit{}},
-			&ast.DeclStmt{Decl: &ast.GenDecl{
-				Tok: token.VAR,
-				Specs: []ast.Spec{
-					&ast.ValueSpec{
-						Names: []*ast.Ident{ast.NewIdent("request")},
-						Type: &ast.StructType{
-							Fields: &ast.FieldList{
-								List: []*ast.Field{
-									{Names: []*ast.Ident{ast.NewIdent("OperationName")}, Type: ast.NewIdent("string")},
-									{Names: []*ast.Ident{ast.NewIdent("Query")}, Type: ast.NewIdent("string")},
-									{Names: []*ast.Ident{ast.NewIdent("Variables")}, Type: ast.NewIdent("json.RawMessage")},
-								},
-							},
-						},
-					},
+				{
+					Text: "// *********** DON'T EDIT ***********",
 				},
-			}},
-
-			&ast.ExprStmt{X: &ast.BasicLit{}},
-			&ast.IfStmt{
-				Init: &ast.AssignStmt{
-					Lhs: []ast.Expr{
-						&ast.BasicLit{
-							Value: "err",
-							Kind:  token.ASSIGN,
-						},
+			},
+		},
+		Name: ast.NewIdent("walk" + name),
+		Recv: generateResolverRecv(),
+		Type: generateWalkerFuncType(&ast.SelectorExpr{
+			X:   ast.NewIdent("model"),
+			Sel: ast.NewIdent(name),
+		}),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.TypeSwitchStmt{
+					Assign: &ast.AssignStmt{
+						Lhs: []ast.Expr{ast.NewIdent("v")},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{ast.NewIdent("v.(type)")},
 					},
-					Tok: token.DEFINE,
-					Rhs: []ast.Expr{
-						&ast.SelectorExpr{
-							X:   ast.NewIdent("json"),
-							Sel: ast.NewIdent("NewDecoder(req.Body).Decode(&request)"),
-						},
+					Body: &ast.BlockStmt{
+						List: cases,
 					},
 				},
-				Cond: &ast.BinaryExpr{
-					X:  ast.NewIdent("err"),
-					Op: token.NEQ,
-					Y:  ast.NewIdent("nil"),
+				&ast.ExprStmt{X: &ast.BasicLit{}},
+				&ast.ReturnStmt{
+					Results: []ast.Expr{ast.NewIdent("nil")},
 				},
+			},
+		},
+	}
+}
+
+// generateAbstractResponseUnmarshalJSON generates UnmarshalJSON for the response of a root field
+// returning unions or interfaces, which encoding/json can not decode on its own.
+func (g *Generator) generateAbstractResponseUnmarshalJSON(field *schema.FieldDefinition) ast.Decl {
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{ast.NewIdent("t")},
+					Type:  ast.NewIdent(fmt.Sprintf("*%sGraphQLResponse", string(field.Name))),
+				},
+			},
+		},
+		Name: ast.NewIdent("UnmarshalJSON"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("b")},
+						Type:  ast.NewIdent("[]byte"),
+					},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{Type: ast.NewIdent("error")},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.DeclStmt{
+					Decl: &ast.GenDecl{
+						Tok: token.VAR,
+						Specs: []ast.Spec{
+							&ast.ValueSpec{
+								Names: []*ast.Ident{ast.NewIdent("mapper")},
+								Type: &ast.StructType{
+									Fields: &ast.FieldList{
+										List: []*ast.Field{
+											{
+												Names: []*ast.Ident{ast.NewIdent("Data")},
+												Type:  ast.NewIdent("json.RawMessage"),
+											},
+											{
+												Names: []*ast.Ident{ast.NewIdent("Errors")},
+												Type:  ast.NewIdent("executor.GraphQLErrors"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				&ast.IfStmt{
+					Init: &ast.AssignStmt{
+						Lhs: []ast.Expr{ast.NewIdent("err")},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{ast.NewIdent("json.Unmarshal(b, &mapper)")},
+					},
+					Cond: ast.NewIdent("err != nil"),
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{ast.NewIdent("err")},
+							},
+						},
+					},
+				},
+				&ast.ExprStmt{X: &ast.BasicLit{}},
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{ast.NewIdent("data"), ast.NewIdent("err")},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{ast.NewIdent(g.generateUnmarshalCall(field.Type, "mapper.Data", "model.", false))},
+				},
+				&ast.IfStmt{
+					Cond: ast.NewIdent("err != nil"),
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{ast.NewIdent("err")},
+							},
+						},
+					},
+				},
+				&ast.ExprStmt{X: &ast.BasicLit{}},
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{ast.NewIdent("t.Data"), ast.NewIdent("t.Errors")},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{ast.NewIdent("data"), ast.NewIdent("mapper.Errors")},
+				},
+				&ast.ReturnStmt{
+					Results: []ast.Expr{ast.NewIdent("nil")},
+				},
+			},
+		},
+	}
+}
+
+// generateExecutorBody generates the body of the executor of the root fields of op.
+// Typed resolvers are called with the arguments of the fields, and the others with the request and a response writer.
+func (g *Generator) generateExecutorBody(op *schema.OperationDefinition, operationType string, typed bool) *ast.BlockStmt {
+	body := []ast.Stmt{}
+
+	if op == nil {
+		return &ast.BlockStmt{
+			List: body,
+		}
+	}
+
+	// introspection root fields are resolved against the schema with the other root fields
+	introspectionFields := []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: `"__typename"`}}
+	if operationType == "query" {
+		introspectionFields = append(introspectionFields,
+			&ast.BasicLit{Kind: token.STRING, Value: `"__schema"`},
+			&ast.BasicLit{Kind: token.STRING, Value: `"__type"`},
+		)
+	}
+
+	bodyStmt := []ast.Stmt{
+		&ast.CaseClause{
+			List: introspectionFields,
+			Body: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent(fmt.Sprintf("executor.IntrospectRootField(r.schema, %s, node, parsedQuery.FragmentDefinitions, variables)", operationTypeConst(operationType))),
+					},
+				},
+			},
+		},
+	}
+	for _, field := range op.Fields {
+		fieldName := fmt.Sprintf("\"%s\"", field.Name)
+		if typed {
+			bodyStmt = append(bodyStmt, &ast.CaseClause{
+				List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: fieldName}},
+				Body: g.generateTypedRootFieldBody(field, operationType),
+			})
+			continue
+		}
+
+		caseBody := make([]ast.Stmt, 0)
+		caseBody = append(caseBody, generateBodyForArgument(field, argumentDefinitionsExpr(operationType, string(field.Name)))...)
+		caseBody = append(caseBody, &ast.AssignStmt{
+			Tok: token.DEFINE,
+			Lhs: []ast.Expr{
+				ast.NewIdent("rw"),
+			},
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: ast.NewIdent("new" + string(field.Name) + "Writer"),
+					Args: []ast.Expr{
+						ast.NewIdent("req.Context()"),
+						ast.NewIdent("r"),
+						ast.NewIdent("w"),
+						ast.NewIdent("string(node.ResponseKey())"),
+						&ast.SelectorExpr{
+							X:   ast.NewIdent("node"),
+							Sel: ast.NewIdent("SelectSets"),
+						},
+						ast.NewIdent("variables"),
+						ast.NewIdent("node.Loc"),
+					},
+				},
+			},
+		})
+		caseBody = append(caseBody,
+			&ast.ExprStmt{X: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("r"),
+					Sel: ast.NewIdent(toUpperCase(string(field.Name))),
+				},
+				Args: []ast.Expr{
+					ast.NewIdent("rw"),
+					ast.NewIdent("req"),
+				},
+			}},
+			&ast.ExprStmt{X: &ast.BasicLit{}},
+			&ast.ExprStmt{
+				X: &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: `// a resolver which doesn't write a response fails the root field`,
+				},
+			},
+			&ast.IfStmt{
+				Cond: ast.NewIdent("rw.result == nil"),
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{X: ast.NewIdent("rw.Write(nil)")},
+					},
+				},
+			},
+			&ast.ReturnStmt{
+				Results: []ast.Expr{ast.NewIdent("rw.result")},
+			},
+		)
+
+		bodyStmt = append(bodyStmt, &ast.CaseClause{
+			List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: fieldName}},
+			Body: caseBody,
+		})
+	}
+	body = append(body,
+		&ast.SwitchStmt{
+			Tag: ast.NewIdent("string(node.Name)"),
+			Body: &ast.BlockStmt{
+				List: bodyStmt,
+			},
+		},
+		&ast.ExprStmt{X: &ast.BasicLit{}},
+		&ast.ReturnStmt{
+			Results: []ast.Expr{
+				ast.NewIdent(`executor.FailRootField(node, false, fmt.Errorf("unknown field %s", node.Name))`),
+			},
+		},
+	)
+
+	return &ast.BlockStmt{
+		List: body,
+	}
+}
+
+// argumentDefinitionsExpr returns the expression of the argument definitions of the root field named fieldName
+// in the schema of the generated resolver.
+func argumentDefinitionsExpr(operationType, fieldName string) string {
+	return fmt.Sprintf("r.schema.Get%s().GetFieldByName([]byte(%q)).Arguments", toUpperCase(operationType), fieldName)
+}
+
+// generateBodyForArgument generates the statements which pass the arguments of field planned by node
+// to its resolver through the body of the request, coerced by the argument definitions of definitions.
+// Arguments which can't be coerced fail the field, nulling the data if the field is non-null.
+func generateBodyForArgument(field *schema.FieldDefinition, definitions string) []ast.Stmt {
+	return []ast.Stmt{
+		&ast.AssignStmt{
+			Tok: token.DEFINE,
+			Lhs: []ast.Expr{
+				ast.NewIdent("body"),
+				ast.NewIdent("err"),
+			},
+			Rhs: []ast.Expr{
+				&ast.SelectorExpr{
+					X:   ast.NewIdent("executor"),
+					Sel: ast.NewIdent(fmt.Sprintf("CoerceArgumentValues(r.schema, %s, node.Arguments, variables)", definitions)),
+				},
+			},
+		},
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent("err"),
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							ast.NewIdent(fmt.Sprintf("executor.FailRootField(node, %t, executor.Errorf(executor.ErrorCodeBadUserInput, \"%%s\", err))", !field.Type.Nullable)),
+						},
+					},
+				},
+			},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent("req.Body")},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent("io"),
+						Sel: ast.NewIdent("NopCloser"),
+					},
+					Args: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("strings"),
+								Sel: ast.NewIdent("NewReader"),
+							},
+							Args: []ast.Expr{
+								&ast.CallExpr{
+									Fun: ast.NewIdent("string"),
+									Args: []ast.Expr{
+										ast.NewIdent("body"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateServeHTTPBody(query, mutation, subscription *schema.OperationDefinition) *ast.BlockStmt {
+	querySwitchCases := []ast.Stmt{}
+	// req.Body = io.NopCloser(strings.NewReader(string(request.Variables)))
+
+	if query != nil {
+		querySwitchCases = append(querySwitchCases, generateServeRootFields("queryExecutor", false))
+	}
+
+	// a schema without a mutation type has no mutation case, as validation rejects mutations against it.
+	mutationCases := []ast.Stmt{}
+	if mutation != nil {
+		mutationCases = append(mutationCases,
+			&ast.CaseClause{
+				List: []ast.Expr{ast.NewIdent(operationTypeConst("mutation"))},
+				Body: []ast.Stmt{
+					&ast.AssignStmt{
+						Tok: token.DEFINE,
+						Lhs: []ast.Expr{
+							ast.NewIdent("rootSelectionSet"),
+						},
+						Rhs: []ast.Expr{
+							&ast.SelectorExpr{
+								X:   ast.NewIdent("utils"),
+								Sel: ast.NewIdent("ExtractExecuteSelector(operation)"),
+							},
+						},
+					},
+
+					&ast.AssignStmt{
+						Tok: token.DEFINE,
+						Lhs: []ast.Expr{
+							ast.NewIdent("nodes"),
+						},
+						Rhs: []ast.Expr{
+							&ast.SelectorExpr{
+								X:   ast.NewIdent("executor"),
+								Sel: ast.NewIdent("PlanRootFields(rootSelectionSet, parsedQuery.FragmentDefinitions, variables)"),
+							},
+						},
+					},
+					generateServeRootFields("mutationExecutor", true),
+				},
+			},
+		)
+	}
+
+	return &ast.BlockStmt{
+		List: []ast.Stmt{
+			&ast.IfStmt{
+				Cond: &ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent("executor"),
+						Sel: ast.NewIdent("IsWebSocketUpgrade"),
+					},
+					Args: []ast.Expr{
+						ast.NewIdent("req"),
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{
+							X: &ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("executor"),
+									Sel: ast.NewIdent("ServeGraphQLTransportWS"),
+								},
+								Args: []ast.Expr{
+									ast.NewIdent("w"),
+									ast.NewIdent("req"),
+									ast.NewIdent("r.subscribe"),
+								},
+							},
+						},
+						&ast.ReturnStmt{},
+					},
+				},
+			},
+			&ast.IfStmt{
+				Cond: &ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent("executor"),
+						Sel: ast.NewIdent("IsEventStreamRequest"),
+					},
+					Args: []ast.Expr{
+						ast.NewIdent("req"),
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{
+							X: &ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("executor"),
+									Sel: ast.NewIdent("ServeGraphQLSSE"),
+								},
+								Args: []ast.Expr{
+									ast.NewIdent("w"),
+									ast.NewIdent("req"),
+									ast.NewIdent("r.subscribe"),
+								},
+							},
+						},
+						&ast.ReturnStmt{},
+					},
+				},
+			},
+
+			&ast.ExprStmt{X: &ast.BasicLit{}},
+			&ast.DeclStmt{Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{ast.NewIdent("request")},
+						Type: &ast.StructType{
+							Fields: &ast.FieldList{
+								List: []*ast.Field{
+									{Names: []*ast.Ident{ast.NewIdent("OperationName")}, Type: ast.NewIdent("string")},
+									{Names: []*ast.Ident{ast.NewIdent("Query")}, Type: ast.NewIdent("string")},
+									{Names: []*ast.Ident{ast.NewIdent("Variables")}, Type: ast.NewIdent("json.RawMessage")},
+								},
+							},
+						},
+					},
+				},
+			}},
+
+			&ast.ExprStmt{X: &ast.BasicLit{}},
+			&ast.IfStmt{
+				Init: &ast.AssignStmt{
+					Lhs: []ast.Expr{
+						&ast.BasicLit{
+							Value: "err",
+							Kind:  token.ASSIGN,
+						},
+					},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						&ast.SelectorExpr{
+							X:   ast.NewIdent("json"),
+							Sel: ast.NewIdent("NewDecoder(req.Body).Decode(&request)"),
+						},
+					},
+				},
+				Cond: &ast.BinaryExpr{
+					X:  ast.NewIdent("err"),
+					Op: token.NEQ,
+					Y:  ast.NewIdent("nil"),
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{X: &ast.CallExpr{
+							Fun: ast.NewIdent("executor.WriteErrorResponse"),
+							Args: []ast.Expr{
+								ast.NewIdent("w"),
+								ast.NewIdent("http.StatusUnprocessableEntity"),
+								ast.NewIdent("executor.Errorf(executor.ErrorCodeBadUserInput, \"invalid JSON: %s\", err)"),
+							},
+						}},
+						&ast.ReturnStmt{},
+					},
+				},
+			},
+
+			&ast.ExprStmt{X: &ast.BasicLit{}},
+
+			&ast.ExprStmt{
+				X: &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: `// replacing req.Body is in order to use variables instinctly in each resolvers from model package`,
+				},
+			},
+
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{
+					ast.NewIdent("parsedQuery"),
+					ast.NewIdent("err"),
+				},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.SelectorExpr{
+						X:   ast.NewIdent("r.parser"),
+						Sel: ast.NewIdent("Parse([]byte(request.Query))"),
+					},
+				},
+			},
+
+			&ast.IfStmt{
+				Cond: &ast.BinaryExpr{
+					X:  ast.NewIdent("err"),
+					Op: token.NEQ,
+					Y:  ast.NewIdent("nil"),
+				},
+
 				Body: &ast.BlockStmt{
 					List: []ast.Stmt{
 						&ast.ExprStmt{X: &ast.CallExpr{
-							Fun: ast.NewIdent("http.Error"),
+							Fun: ast.NewIdent("executor.WriteErrorResponse"),
 							Args: []ast.Expr{
 								ast.NewIdent("w"),
-								&ast.BasicLit{Kind: token.STRING, Value: "\"Invalid JSON\""},
-								ast.NewIdent("http.StatusUnprocessableEntity"),
+								ast.NewIdent("http.StatusBadRequest"),
+								ast.NewIdent("executor.Errorf(executor.ErrorCodeParseFailed, \"failed to parse query: %s\", err)"),
+							},
+						}},
+						&ast.ReturnStmt{},
+					},
+				},
+			},
+
+			&ast.ExprStmt{X: &ast.BasicLit{}},
+
+			&ast.IfStmt{
+				Init: &ast.AssignStmt{
+					Lhs: []ast.Expr{ast.NewIdent("errs")},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{ast.NewIdent("validator.ValidateDocument(r.schema, parsedQuery)")},
+				},
+				Cond: ast.NewIdent("len(errs) > 0"),
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{X: &ast.CallExpr{
+							Fun: ast.NewIdent("executor.WriteErrorResponse"),
+							Args: []ast.Expr{
+								ast.NewIdent("w"),
+								ast.NewIdent("http.StatusBadRequest"),
+								ast.NewIdent("executor.ValidationErrors(errs)"),
+							},
+						}},
+						&ast.ReturnStmt{},
+					},
+				},
+			},
+
+			&ast.ExprStmt{X: &ast.BasicLit{}},
+
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{
+					ast.NewIdent("operation"),
+					ast.NewIdent("err"),
+				},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					ast.NewIdent("parsedQuery.Operations.GetOperation(request.OperationName)"),
+				},
+			},
+
+			&ast.IfStmt{
+				Cond: &ast.BinaryExpr{
+					X:  ast.NewIdent("err"),
+					Op: token.NEQ,
+					Y:  ast.NewIdent("nil"),
+				},
+
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{X: &ast.CallExpr{
+							Fun: ast.NewIdent("executor.WriteErrorResponse"),
+							Args: []ast.Expr{
+								ast.NewIdent("w"),
+								ast.NewIdent("http.StatusBadRequest"),
+								ast.NewIdent("executor.Errorf(executor.ErrorCodeOperationResolutionFailure, \"%s\", err)"),
+							},
+						}},
+						&ast.ReturnStmt{},
+					},
+				},
+			},
+
+			&ast.ExprStmt{X: &ast.BasicLit{}},
+
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{
+					ast.NewIdent("variables"),
+					ast.NewIdent("err"),
+				},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					ast.NewIdent("executor.CoerceVariableValues(r.schema, operation, request.Variables)"),
+				},
+			},
+
+			&ast.IfStmt{
+				Cond: &ast.BinaryExpr{
+					X:  ast.NewIdent("err"),
+					Op: token.NEQ,
+					Y:  ast.NewIdent("nil"),
+				},
+
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{X: &ast.CallExpr{
+							Fun: ast.NewIdent("executor.WriteErrorResponse"),
+							Args: []ast.Expr{
+								ast.NewIdent("w"),
+								ast.NewIdent("http.StatusBadRequest"),
+								ast.NewIdent("executor.Errorf(executor.ErrorCodeBadUserInput, \"%s\", err)"),
 							},
 						}},
 						&ast.ReturnStmt{},
@@ -1838,541 +2257,1101 @@ func generateServeHTTPBody(query, mutation, subscription *schema.OperationDefini
 				},
 			},
 
-			&ast.ExprStmt{X: &ast.BasicLit{}},
+			&ast.ExprStmt{X: &ast.BasicLit{}},
+			&ast.ExprStmt{
+				X: &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: "// the loaders of dataloader.Define are shared by the resolvers of the request, which resolve its fields with its workers",
+				},
+			},
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent("req")},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{ast.NewIdent("req.WithContext(executor.WithWorkerLimit(dataloader.NewContext(req.Context()), r.workerLimit))")},
+			},
+
+			&ast.SwitchStmt{
+				Tag: ast.NewIdent("operation.OperationType"),
+				Body: &ast.BlockStmt{
+					List: append(append([]ast.Stmt{
+						&ast.CaseClause{
+							List: []ast.Expr{ast.NewIdent(operationTypeConst("query"))},
+							Body: append([]ast.Stmt{
+								&ast.IfStmt{
+									Cond: &ast.CallExpr{
+										Fun: &ast.SelectorExpr{
+											X:   ast.NewIdent("executor"),
+											Sel: ast.NewIdent("IsIntrospectionQuery"),
+										},
+										Args: []ast.Expr{
+											ast.NewIdent("operation"),
+										},
+									},
+									Body: &ast.BlockStmt{
+										List: []ast.Stmt{
+											&ast.ExprStmt{
+												X: &ast.CallExpr{
+													Fun: &ast.SelectorExpr{
+														X:   ast.NewIdent("executor"),
+														Sel: ast.NewIdent("ServeIntrospection"),
+													},
+													Args: []ast.Expr{
+														ast.NewIdent("w"),
+														ast.NewIdent("r.schema"),
+														ast.NewIdent("operation"),
+														ast.NewIdent("parsedQuery.FragmentDefinitions"),
+														ast.NewIdent("variables"),
+													},
+												},
+											},
+											&ast.ReturnStmt{},
+										},
+									},
+								},
+
+								&ast.AssignStmt{
+									Tok: token.DEFINE,
+									Lhs: []ast.Expr{
+										ast.NewIdent("rootSelectionSet"),
+									},
+									Rhs: []ast.Expr{
+										&ast.SelectorExpr{
+											X:   ast.NewIdent("utils"),
+											Sel: ast.NewIdent("ExtractExecuteSelector(operation)"),
+										},
+									},
+								},
+
+								&ast.AssignStmt{
+									Tok: token.DEFINE,
+									Lhs: []ast.Expr{
+										ast.NewIdent("nodes"),
+									},
+									Rhs: []ast.Expr{
+										&ast.SelectorExpr{
+											X:   ast.NewIdent("executor"),
+											Sel: ast.NewIdent("PlanRootFields(rootSelectionSet, parsedQuery.FragmentDefinitions, variables)"),
+										},
+									},
+								},
+							}, querySwitchCases...),
+						},
+					}, mutationCases...),
+						&ast.CaseClause{
+							List: []ast.Expr{ast.NewIdent(operationTypeConst("subscription"))},
+							Body: []ast.Stmt{
+								&ast.ExprStmt{X: &ast.CallExpr{
+									Fun: ast.NewIdent("executor.WriteErrorResponse"),
+									Args: []ast.Expr{
+										ast.NewIdent("w"),
+										ast.NewIdent("http.StatusBadRequest"),
+										ast.NewIdent("executor.Errorf(executor.ErrorCodeBadUserInput, \"subscription requires a streaming transport\")"),
+									},
+								}},
+							},
+						},
+					),
+				},
+			},
+		},
+	}
+}
+
+// generateServeRootFields generates the statement which executes every root field with executorName
+// and writes their results, with the workers of the request or serially if serial is true as mutations require.
+// Every root field has a request of its own, as its arguments are passed through the body of the request.
+func generateServeRootFields(executorName string, serial bool) ast.Stmt {
+	return &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("executor"),
+				Sel: ast.NewIdent("ServeRootFields"),
+			},
+			Args: []ast.Expr{
+				ast.NewIdent("req.Context()"),
+				ast.NewIdent("w"),
+				ast.NewIdent("nodes"),
+				ast.NewIdent(strconv.FormatBool(serial)),
+				&ast.FuncLit{
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Names: []*ast.Ident{ast.NewIdent("w")},
+									Type:  ast.NewIdent("http.ResponseWriter"),
+								},
+								{
+									Names: []*ast.Ident{ast.NewIdent("node")},
+									Type:  ast.NewIdent("*executor.Node"),
+								},
+							},
+						},
+						Results: generateRootFieldResultType(),
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									&ast.CallExpr{
+										Fun: &ast.SelectorExpr{
+											X:   ast.NewIdent("r"),
+											Sel: ast.NewIdent(executorName),
+										},
+										Args: []ast.Expr{
+											ast.NewIdent("w"),
+											ast.NewIdent("req.Clone(req.Context())"),
+											ast.NewIdent("node"),
+											ast.NewIdent("parsedQuery"),
+											ast.NewIdent("variables"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// operationTypeConst returns the query.OperationType constant of operationType in the generated code.
+func operationTypeConst(operationType string) string {
+	return "query." + toUpperCase(operationType) + "Operation"
+}
+
+func generateServeHTTPArgs() *ast.FieldList {
+	return &ast.FieldList{
+		List: []*ast.Field{
+			{
+				Names: []*ast.Ident{
+					{
+						Name: "w",
+					},
+				},
+				Type: &ast.ParenExpr{
+					X: &ast.SelectorExpr{
+						X:   ast.NewIdent("http"),
+						Sel: ast.NewIdent("ResponseWriter"),
+					},
+				},
+			},
+			{
+				Names: []*ast.Ident{
+					{
+						Name: "req",
+					},
+				},
+				Type: &ast.StarExpr{
+					X: &ast.SelectorExpr{
+						X:   ast.NewIdent("http"),
+						Sel: ast.NewIdent("Request"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateOperationExecutorArgs() *ast.FieldList {
+	ExecutorArgs := generateServeHTTPArgs()
+
+	additionalFields := []*ast.Field{
+		{
+			Names: []*ast.Ident{
+				{
+					Name: "node",
+				},
+			},
+			Type: &ast.StarExpr{
+				X: &ast.SelectorExpr{
+					X:   ast.NewIdent("executor"),
+					Sel: ast.NewIdent("Node"),
+				},
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				{
+					Name: "parsedQuery",
+				},
+			},
+			Type: &ast.StarExpr{
+				X: &ast.SelectorExpr{
+					X:   ast.NewIdent("query"),
+					Sel: ast.NewIdent("Document"),
+				},
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				{
+					Name: "variables",
+				},
+			},
+			Type: &ast.SelectorExpr{
+				X:   ast.NewIdent("json"),
+				Sel: ast.NewIdent("RawMessage"),
+			},
+		},
+	}
+
+	ExecutorArgs.List = append(ExecutorArgs.List, additionalFields...)
+	return ExecutorArgs
+}
 
-			&ast.ExprStmt{
-				X: &ast.BasicLit{
-					Kind:  token.STRING,
-					Value: `// replacing req.Body is in order to use variables instinctly in each resolvers from model package`,
-				},
-			},
+// generateInterfaceField generates the resolver interface of operation, whose methods are typed resolvers if typed is true.
+// Subscriptions always return a channel of their results.
+func (g *Generator) generateInterfaceField(operation *schema.OperationDefinition, typed bool) *ast.GenDecl {
+	generateField := func(field schema.FieldDefinitions) *ast.FieldList {
+		fields := make([]*ast.Field, 0, len(field))
 
-			&ast.AssignStmt{
-				Lhs: []ast.Expr{ast.NewIdent("operationType")},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: ast.NewIdent("detectOperationType"),
-						Args: []ast.Expr{
-							ast.NewIdent("request.Query"),
-						},
+		for _, f := range field {
+			funcType := &ast.FuncType{
+				Params:  generateServeHTTPArgs(),
+				Results: &ast.FieldList{},
+			}
+
+			if typed {
+				funcType = g.generateTypedResolverFuncType(f)
+			}
+
+			if operation.OperationType.IsSubscription() {
+				funcType = g.generateSubscriptionResolverFuncType(f)
+			}
+
+			fields = append(fields, &ast.Field{
+				Doc: descriptionDoc(f.Description),
+				Names: []*ast.Ident{
+					{
+						Name: toUpperCase(string(f.Name)),
 					},
 				},
-			},
+				Type: funcType,
+			})
+		}
 
-			&ast.AssignStmt{
-				Lhs: []ast.Expr{
-					ast.NewIdent("parsedQuery"),
-					ast.NewIdent("err"),
-				},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.SelectorExpr{
-						X:   ast.NewIdent("r.parser"),
-						Sel: ast.NewIdent("Parse([]byte(request.Query))"),
-					},
+		return &ast.FieldList{
+			List: fields,
+		}
+	}
+
+	var ident *ast.Ident
+	if operation.OperationType.IsQuery() {
+		ident = newQueryIdent(operation)
+	}
+
+	if operation.OperationType.IsMutation() {
+		ident = newMutationIdent(operation)
+	}
+
+	if operation.OperationType.IsSubscription() {
+		ident = newSubscriptionIdent(operation)
+	}
+
+	return g.positionDocs(&ast.GenDecl{
+		Doc: descriptionDoc(operation.Description),
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: ident,
+				Type: &ast.InterfaceType{
+					Methods: generateField(operation.Fields),
 				},
 			},
+		},
+	})
+}
 
-			&ast.IfStmt{
-				Cond: &ast.BinaryExpr{
-					X:  ast.NewIdent("err"),
-					Op: token.NEQ,
-					Y:  ast.NewIdent("nil"),
-				},
+func (g *Generator) isUsedDefinedType(operation *schema.OperationDefinition) bool {
+	if operation != nil {
+		for _, field := range operation.Fields {
+			if t := GraphQLType(field.Type.Name); !t.IsPrimitive() && !g.isScalar(t) {
+				return true
+			}
 
-				Body: &ast.BlockStmt{
-					List: []ast.Stmt{
-						&ast.ExprStmt{X: &ast.CallExpr{
-							Fun: ast.NewIdent("http.Error"),
-							Args: []ast.Expr{
-								ast.NewIdent("w"),
-								&ast.BasicLit{Kind: token.STRING, Value: "\"failed to parse query\""},
-								ast.NewIdent("http.StatusInternalServerError"),
+			for _, arg := range field.Arguments {
+				if t := GraphQLType(arg.Type.Name); !t.IsPrimitive() && !g.isScalar(t) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// generateResolverImplementationStruct generates the resolver struct and its constructor,
+// which registers the custom scalars of the model to the schema if registersScalars is true.
+func generateResolverImplementationStruct(registersScalars bool) []ast.Decl {
+	newResolverBody := []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent("r")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CompositeLit{
+					Type: ast.NewIdent("&resolver"),
+					Elts: []ast.Expr{
+						&ast.KeyValueExpr{
+							Key: ast.NewIdent("parser"),
+							Value: &ast.SelectorExpr{
+								Sel: ast.NewIdent("NewParserWithLexer()"),
+								X:   ast.NewIdent("query"),
 							},
-						}},
-						&ast.ReturnStmt{},
+						},
+						&ast.KeyValueExpr{
+							Key: ast.NewIdent("schema"),
+							Value: &ast.SelectorExpr{
+								Sel: ast.NewIdent("MustParse([]byte(schemaSource))"),
+								X:   ast.NewIdent("schema"),
+							},
+						},
+						&ast.KeyValueExpr{
+							Key: ast.NewIdent("workerLimit"),
+							Value: &ast.SelectorExpr{
+								Sel: ast.NewIdent("DefaultWorkerLimit"),
+								X:   ast.NewIdent("executor"),
+							},
+						},
 					},
 				},
 			},
+		},
+	}
 
-			&ast.ExprStmt{X: &ast.BasicLit{}},
-
-			&ast.AssignStmt{
-				Lhs: []ast.Expr{
-					ast.NewIdent("variables"),
-				},
+	if registersScalars {
+		newResolverBody = append(newResolverBody, &ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent("err")},
 				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.SelectorExpr{
-						X:   ast.NewIdent("request"),
-						Sel: ast.NewIdent("Variables"),
+				Rhs: []ast.Expr{ast.NewIdent("model.RegisterScalars(r.schema)")},
+			},
+			Cond: ast.NewIdent("err != nil"),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{X: ast.NewIdent("panic(err)")},
+				},
+			},
+		})
+	}
+
+	newResolverBody = append(newResolverBody,
+		&ast.RangeStmt{
+			Key:   ast.NewIdent("_"),
+			Value: ast.NewIdent("opt"),
+			Tok:   token.DEFINE,
+			X:     ast.NewIdent("opts"),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{
+						X: ast.NewIdent("opt(r)"),
 					},
 				},
 			},
+		},
+		&ast.ExprStmt{X: &ast.BasicLit{}},
+		&ast.ReturnStmt{
+			Results: []ast.Expr{ast.NewIdent("r")},
+		},
+	)
 
-			&ast.SwitchStmt{
-				Tag: ast.NewIdent("operationType"),
-				Body: &ast.BlockStmt{
-					List: []ast.Stmt{
-						&ast.CaseClause{
-							List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: "\"query\""}},
-							Body: append([]ast.Stmt{
-								&ast.AssignStmt{
-									Tok: token.DEFINE,
-									Lhs: []ast.Expr{
-										ast.NewIdent("rootSelectionSet"),
+	return []ast.Decl{
+		&ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: &ast.Ident{
+						Name: "resolver",
+					},
+					Type: &ast.StructType{
+						Fields: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("parser"),
 									},
-									Rhs: []ast.Expr{
-										&ast.SelectorExpr{
-											X:   ast.NewIdent("utils"),
-											Sel: ast.NewIdent("ExtractExecuteSelector(parsedQuery.Operations.GetQuery(), request.OperationName)"),
+									Type: &ast.StarExpr{
+										X: &ast.SelectorExpr{
+											X:   ast.NewIdent("query"),
+											Sel: ast.NewIdent("Parser"),
 										},
 									},
 								},
-
-								&ast.AssignStmt{
-									Tok: token.DEFINE,
-									Lhs: []ast.Expr{
-										ast.NewIdent("node"),
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("schema"),
 									},
-									Rhs: []ast.Expr{
-										&ast.SelectorExpr{
-											X:   ast.NewIdent("executor"),
-											Sel: ast.NewIdent("PlanExecution(rootSelectionSet)"),
+									Type: &ast.StarExpr{
+										X: &ast.SelectorExpr{
+											X:   ast.NewIdent("schema"),
+											Sel: ast.NewIdent("Schema"),
 										},
 									},
 								},
-							}, querySwitchCases...),
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("workerLimit"),
+									},
+									Type: ast.NewIdent("int"),
+								},
+							},
 						},
-
-						&ast.CaseClause{
-							List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: "\"mutation\""}},
-							Body: append([]ast.Stmt{
-								&ast.AssignStmt{
-									Tok: token.DEFINE,
-									Lhs: []ast.Expr{
-										ast.NewIdent("rootSelectionSet"),
+					},
+				},
+			},
+		},
+		&ast.GenDecl{
+			Tok: token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names: []*ast.Ident{
+						{
+							Name: "_",
+						},
+					},
+					Type: ast.NewIdent("Resolver"),
+					Values: []ast.Expr{
+						&ast.UnaryExpr{
+							Op: token.AND,
+							X: &ast.Ident{
+								Name: "resolver{}",
+							},
+						},
+					},
+				},
+			},
+		},
+		&ast.GenDecl{
+			Doc: &ast.CommentGroup{
+				List: []*ast.Comment{
+					{
+						Text: "// Option configures the resolver returned by NewResolver.",
+					},
+				},
+			},
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: ast.NewIdent("Option"),
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Type: &ast.StarExpr{
+										X: ast.NewIdent("resolver"),
 									},
-									Rhs: []ast.Expr{
-										&ast.SelectorExpr{
-											X:   ast.NewIdent("utils"),
-											Sel: ast.NewIdent("ExtractExecuteSelector(parsedQuery.Operations.GetMutation(), request.OperationName)"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		&ast.FuncDecl{
+			Doc: &ast.CommentGroup{
+				List: []*ast.Comment{
+					{
+						Text: "// WithWorkerLimit limits the goroutines which resolve the fields of a request concurrently to n, where n <= 0 resolves them serially.",
+					},
+					{
+						Text: "// The fields of mutations are resolved serially regardless of the limit.",
+					},
+				},
+			},
+			Name: ast.NewIdent("WithWorkerLimit"),
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{ast.NewIdent("n")},
+							Type:  ast.NewIdent("int"),
+						},
+					},
+				},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Type: ast.NewIdent("Option"),
+						},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							&ast.FuncLit{
+								Type: &ast.FuncType{
+									Params: &ast.FieldList{
+										List: []*ast.Field{
+											{
+												Names: []*ast.Ident{ast.NewIdent("r")},
+												Type: &ast.StarExpr{
+													X: ast.NewIdent("resolver"),
+												},
+											},
 										},
 									},
 								},
-
-								&ast.AssignStmt{
-									Tok: token.DEFINE,
-									Lhs: []ast.Expr{
-										ast.NewIdent("node"),
-									},
-									Rhs: []ast.Expr{
-										&ast.SelectorExpr{
-											X:   ast.NewIdent("executor"),
-											Sel: ast.NewIdent("PlanExecution(rootSelectionSet)"),
+								Body: &ast.BlockStmt{
+									List: []ast.Stmt{
+										&ast.AssignStmt{
+											Lhs: []ast.Expr{ast.NewIdent("r.workerLimit")},
+											Tok: token.ASSIGN,
+											Rhs: []ast.Expr{ast.NewIdent("n")},
 										},
 									},
 								},
-							}, mutationSwitchCases...),
+							},
 						},
-
-						&ast.CaseClause{
-							List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: "\"subscription\""}},
-							Body: []ast.Stmt{
-								&ast.AssignStmt{
-									Tok: token.DEFINE,
-									Lhs: []ast.Expr{
-										ast.NewIdent("operationName"),
-									},
-									Rhs: []ast.Expr{
-										&ast.SelectorExpr{
-											X:   ast.NewIdent("utils"),
-											Sel: ast.NewIdent("ExtractSelectorName(parsedQuery.Operations.GetSubscription(), request.OperationName)"),
-										},
-									},
-								},
-								&ast.SwitchStmt{
-									Tag: ast.NewIdent("operationName"),
-									Body: &ast.BlockStmt{
-										List: subscriptionSwitchCases,
-									},
-								},
+					},
+				},
+			},
+		},
+		&ast.FuncDecl{
+			Name: ast.NewIdent("NewResolver"),
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{ast.NewIdent("opts")},
+							Type: &ast.Ellipsis{
+								Elt: ast.NewIdent("Option"),
+							},
+						},
+					},
+				},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Type: &ast.StarExpr{
+								X: ast.NewIdent("resolver"),
 							},
 						},
 					},
 				},
 			},
+			Body: &ast.BlockStmt{
+				List: newResolverBody,
+			},
 		},
 	}
 }
 
-func generateServeHTTPArgs() *ast.FieldList {
-	return &ast.FieldList{
-		List: []*ast.Field{
-			{
+func generateSchemaSource(source []byte) ast.Decl {
+	value := "`" + string(source) + "`"
+	if bytes.ContainsRune(source, '`') {
+		value = strconv.Quote(string(source))
+	}
+
+	return &ast.GenDecl{
+		Tok: token.CONST,
+		Specs: []ast.Spec{
+			&ast.ValueSpec{
 				Names: []*ast.Ident{
-					{
-						Name: "w",
-					},
+					ast.NewIdent("schemaSource"),
 				},
-				Type: &ast.ParenExpr{
-					X: &ast.SelectorExpr{
-						X:   ast.NewIdent("http"),
-						Sel: ast.NewIdent("ResponseWriter"),
+				Values: []ast.Expr{
+					&ast.BasicLit{
+						Kind:  token.STRING,
+						Value: value,
 					},
 				},
 			},
-			{
-				Names: []*ast.Ident{
+		},
+	}
+}
+
+func (g *Generator) generateResolverImplementation(fields schema.FieldDefinitions) []ast.Decl {
+	decls := make([]ast.Decl, 0, len(fields))
+
+	recv := func(t *schema.FieldType) string {
+		if t.IsList {
+			return fmt.Sprintf("[]%s", t.ListType.Name)
+		}
+
+		graphQLType := GraphQLType(t.Name)
+		return g.golangType(graphQLType)
+	}
+
+	for _, f := range fields {
+		returnsStr := recv(f.Type)
+
+		if f.Type != nil {
+			decls = append(decls, g.generateResponseGraphQLResponseStruct(string(f.Name), f))
+		}
+
+		decls = append(decls, &ast.FuncDecl{
+			Doc: &ast.CommentGroup{
+				List: []*ast.Comment{
 					{
-						Name: "req",
+						Text: fmt.Sprintf("// Read request body for %sArgs", toUpperCase(string(f.Name))),
+					},
+					{
+						Text: fmt.Sprintf("// Write response body for %s", returnsStr),
 					},
 				},
-				Type: &ast.StarExpr{
-					X: &ast.SelectorExpr{
-						X:   ast.NewIdent("http"),
-						Sel: ast.NewIdent("Request"),
+			},
+			Name: ast.NewIdent(toUpperCase(string(f.Name))),
+			Recv: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{
+							{
+								Name: "r",
+							},
+						},
+						Type: &ast.StarExpr{
+							X: &ast.Ident{
+								Name: "resolver",
+							},
+						},
 					},
 				},
 			},
-		},
+			Type: &ast.FuncType{
+				Params:  generateServeHTTPArgs(),
+				Results: &ast.FieldList{},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{},
+			},
+		})
 	}
+
+	return decls
 }
 
-func generateOperationExecutorArgs() *ast.FieldList {
-	ExecutorArgs := generateServeHTTPArgs()
+// generateTypedResolverImport generates the imports of the file of the typed resolvers of op,
+// which imports the model package only if the resolvers refer to it.
+func (g *Generator) generateTypedResolverImport(modelPackagePath string, op *schema.OperationDefinition) *ast.GenDecl {
+	importDecl := &ast.GenDecl{
+		Tok: token.IMPORT,
+		Specs: []ast.Spec{
+			&ast.ImportSpec{
+				Path: &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: `"context"`,
+				},
+			},
+		},
+	}
 
-	additionalFields := []*ast.Field{
+	usesModel := g.isUsedDefinedType(op)
+	for _, f := range op.Fields {
+		if len(f.Arguments) > 0 {
+			usesModel = true
+		}
+	}
+
+	if usesModel {
+		importDecl.Specs = append(importDecl.Specs, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: fmt.Sprintf(`"%s"`, modelPackagePath),
+			},
+		})
+	}
+
+	return g.withScalarImports(importDecl, op)
+}
+
+// generateTypedResolverFuncType generates the type of the typed resolver of field,
+// which takes the arguments of field as its Args model if it has arguments, and returns its result with an error.
+func (g *Generator) generateTypedResolverFuncType(field *schema.FieldDefinition) *ast.FuncType {
+	params := []*ast.Field{
 		{
-			Names: []*ast.Ident{
-				{
-					Name: "node",
-				},
+			Names: []*ast.Ident{ast.NewIdent("ctx")},
+			Type: &ast.SelectorExpr{
+				X:   ast.NewIdent("context"),
+				Sel: ast.NewIdent("Context"),
 			},
-			Type: &ast.StarExpr{
-				X: &ast.SelectorExpr{
-					X:   ast.NewIdent("executor"),
-					Sel: ast.NewIdent("Node"),
-				},
+		},
+	}
+
+	if len(field.Arguments) > 0 {
+		params = append(params, &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent("args")},
+			Type: &ast.SelectorExpr{
+				X:   ast.NewIdent("model"),
+				Sel: ast.NewIdent(toUpperCase(string(field.Name)) + "Args"),
 			},
+		})
+	}
+
+	return &ast.FuncType{
+		Params: &ast.FieldList{
+			List: params,
 		},
-		{
-			Names: []*ast.Ident{
-				{
-					Name: "parsedQuery",
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{Type: g.generateTypeExprFromFieldType(g.typedResultType(field.Type))},
+				{Type: ast.NewIdent("error")},
+			},
+		},
+	}
+}
+
+// typedResultType returns the type of the result of a typed resolver of a field of fieldType.
+// Non-null objects are returned through pointers, so that a resolver can return nil with an error.
+func (g *Generator) typedResultType(fieldType *schema.FieldType) *schema.FieldType {
+	if fieldType.IsList || fieldType.Nullable || !g.isObject(GraphQLType(fieldType.Name)) {
+		return fieldType
+	}
+
+	res := *fieldType
+	res.Nullable = true
+	return &res
+}
+
+// generateTypedRootFieldBody generates the statements which call the typed resolver of the root field planned by node,
+// decoding its arguments and completing its result with the errors it returns.
+func (g *Generator) generateTypedRootFieldBody(field *schema.FieldDefinition, operationType string) []ast.Stmt {
+	stmts := make([]ast.Stmt, 0)
+	args := []ast.Expr{ast.NewIdent("req.Context()")}
+
+	if len(field.Arguments) > 0 {
+		stmts = append(stmts,
+			&ast.AssignStmt{
+				Tok: token.DEFINE,
+				Lhs: []ast.Expr{
+					ast.NewIdent("args"),
+					ast.NewIdent("err"),
+				},
+				Rhs: []ast.Expr{
+					ast.NewIdent(fmt.Sprintf("executor.DecodeArgumentValues[model.%sArgs](r.schema, %s, node.Arguments, variables)", toUpperCase(string(field.Name)), argumentDefinitionsExpr(operationType, string(field.Name)))),
 				},
 			},
-			Type: &ast.StarExpr{
-				X: &ast.SelectorExpr{
-					X:   ast.NewIdent("query"),
-					Sel: ast.NewIdent("Document"),
+			&ast.IfStmt{
+				Cond: ast.NewIdent("err != nil"),
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ReturnStmt{
+							Results: []ast.Expr{
+								ast.NewIdent(fmt.Sprintf("executor.FailRootField(node, %t, executor.Errorf(executor.ErrorCodeBadUserInput, \"%%s\", err))", !field.Type.Nullable)),
+							},
+						},
+					},
 				},
 			},
-		},
-		{
-			Names: []*ast.Ident{
-				{
-					Name: "variables",
+		)
+		args = append(args, ast.NewIdent("args"))
+	}
+
+	stmts = append(stmts,
+		&ast.AssignStmt{
+			Tok: token.DEFINE,
+			Lhs: []ast.Expr{
+				ast.NewIdent("data"),
+				ast.NewIdent("err"),
+			},
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent("r"),
+						Sel: ast.NewIdent(toUpperCase(string(field.Name))),
+					},
+					Args: args,
 				},
 			},
-			Type: &ast.SelectorExpr{
-				X:   ast.NewIdent("json"),
-				Sel: ast.NewIdent("RawMessage"),
+		},
+		&ast.ReturnStmt{
+			Results: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent("executor"),
+						Sel: ast.NewIdent("CompleteRootField"),
+					},
+					Args: []ast.Expr{
+						ast.NewIdent("string(node.ResponseKey())"),
+						ast.NewIdent(strconv.FormatBool(!field.Type.Nullable)),
+						ast.NewIdent("node.Loc"),
+						ast.NewIdent("executor.ResolverErrors(err, node.Loc)"),
+						&ast.FuncLit{
+							Type: &ast.FuncType{
+								Params: &ast.FieldList{
+									List: []*ast.Field{
+										{
+											Names: []*ast.Ident{ast.NewIdent("errs")},
+											Type:  ast.NewIdent("*executor.FieldErrors"),
+										},
+										{
+											Names: []*ast.Ident{ast.NewIdent("path")},
+											Type:  ast.NewIdent("executor.Path"),
+										},
+									},
+								},
+								Results: &ast.FieldList{
+									List: []*ast.Field{
+										{Type: ast.NewIdent("any")},
+									},
+								},
+							},
+							Body: &ast.BlockStmt{
+								List: []ast.Stmt{
+									&ast.ReturnStmt{
+										Results: []ast.Expr{
+											ast.NewIdent(g.generateWalkExpr(g.typedResultType(field.Type), "data", "r", "req.Context()", "node.SelectSets", "variables", "node.Loc", false)),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
 			},
 		},
-	}
+	)
 
-	ExecutorArgs.List = append(ExecutorArgs.List, additionalFields...)
-	return ExecutorArgs
+	return stmts
 }
 
-func generateDetectOperationType() *ast.FuncDecl {
-	return &ast.FuncDecl{
-		Name: ast.NewIdent("detectOperationType"),
-		Type: &ast.FuncType{
-			Params: &ast.FieldList{
-				List: []*ast.Field{
+// generateTypedResolverImplementation generates the typed resolvers of fields to be implemented,
+// which return the zero values of their results.
+func (g *Generator) generateTypedResolverImplementation(fields schema.FieldDefinitions) []ast.Decl {
+	decls := make([]ast.Decl, 0, len(fields))
+
+	for _, f := range fields {
+		decls = append(decls, &ast.FuncDecl{
+			Doc: &ast.CommentGroup{
+				List: []*ast.Comment{
 					{
-						Names: []*ast.Ident{ast.NewIdent("query")},
-						Type:  ast.NewIdent("string"),
+						Text: fmt.Sprintf("// Return the result of %s, or an error which is reported at the field", f.Name),
 					},
 				},
 			},
-			Results: &ast.FieldList{
+			Name: ast.NewIdent(toUpperCase(string(f.Name))),
+			Recv: &ast.FieldList{
 				List: []*ast.Field{
 					{
-						Type: ast.NewIdent("string"),
+						Names: []*ast.Ident{ast.NewIdent("r")},
+						Type: &ast.StarExpr{
+							X: ast.NewIdent("resolver"),
+						},
 					},
 				},
 			},
-		},
-		Body: &ast.BlockStmt{
-			List: []ast.Stmt{
-				// query = strings.TrimSpace(query)
-				&ast.AssignStmt{
-					Lhs: []ast.Expr{ast.NewIdent("query")},
-					Tok: token.ASSIGN,
-					Rhs: []ast.Expr{
-						&ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X:   ast.NewIdent("strings"),
-								Sel: ast.NewIdent("TrimSpace"),
-							},
-							Args: []ast.Expr{ast.NewIdent("query")},
+			Type: g.generateTypedResolverFuncType(f),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							g.generateZeroValueExpr(g.typedResultType(f.Type)),
+							ast.NewIdent("nil"),
 						},
 					},
 				},
-				// if strings.HasPrefix(query, "query") { return "query" }
-				generatePrefixCheck("query"),
-				// if strings.HasPrefix(query, "mutation") { return "mutation" }
-				generatePrefixCheck("mutation"),
-				// if strings.HasPrefix(query, "subscription") { return "subscription" }
-				generatePrefixCheck("subscription"),
-				// return ""
-				&ast.ReturnStmt{
-					Results: []ast.Expr{&ast.BasicLit{
-						Kind:  token.STRING,
-						Value: `""`,
-					}},
-				},
 			},
-		},
+		})
 	}
-}
 
-func generatePrefixCheck(operation string) *ast.IfStmt {
-	return &ast.IfStmt{
-		Cond: &ast.CallExpr{
-			Fun: &ast.SelectorExpr{
-				X:   ast.NewIdent("strings"),
-				Sel: ast.NewIdent("HasPrefix"),
-			},
-			Args: []ast.Expr{
-				ast.NewIdent("query"),
-				&ast.BasicLit{Kind: token.STRING, Value: `"` + operation + `"`},
-			},
-		},
-		Body: &ast.BlockStmt{
-			List: []ast.Stmt{
-				&ast.ReturnStmt{
-					Results: []ast.Expr{
-						&ast.BasicLit{Kind: token.STRING, Value: `"` + operation + `"`},
-					},
-				},
-			},
-		},
-	}
+	return decls
 }
 
-func generateInterfaceField(operation *schema.OperationDefinition) *ast.GenDecl {
-	generateField := func(field schema.FieldDefinitions) *ast.FieldList {
-		fields := make([]*ast.Field, 0, len(field))
-
-		for _, f := range field {
-			fields = append(fields, &ast.Field{
-				Names: []*ast.Ident{
-					{
-						Name: toUpperCase(string(f.Name)),
-					},
-				},
-				Type: &ast.FuncType{
-					Params:  generateServeHTTPArgs(),
-					Results: &ast.FieldList{},
-				},
-			})
-		}
-
-		return &ast.FieldList{
-			List: fields,
-		}
+// generateZeroValueExpr generates the zero value of the Go type of fieldType.
+func (g *Generator) generateZeroValueExpr(fieldType *schema.FieldType) ast.Expr {
+	graphQLType := GraphQLType(fieldType.Name)
+	if fieldType.IsList || fieldType.Nullable || g.isAbstract(graphQLType) || g.isObject(graphQLType) {
+		return ast.NewIdent("nil")
 	}
 
-	var ident *ast.Ident
-	if operation.OperationType.IsQuery() {
-		ident = newQueryIdent(operation)
+	switch graphQLType {
+	case "Int", "Float":
+		return &ast.BasicLit{Kind: token.INT, Value: "0"}
+	case "Boolean":
+		return ast.NewIdent("false")
+	case "String", "ID":
+		return &ast.BasicLit{Kind: token.STRING, Value: `""`}
 	}
 
-	if operation.OperationType.IsMutation() {
-		ident = newMutationIdent(operation)
+	// enums and custom scalars
+	return &ast.StarExpr{
+		X: &ast.CallExpr{
+			Fun:  ast.NewIdent("new"),
+			Args: []ast.Expr{g.generateTypeExprFromFieldType(fieldType)},
+		},
 	}
+}
 
-	if operation.OperationType.IsSubscription() {
-		ident = newSubscriptionIdent(operation)
-	}
+// fieldResolverName returns the name of the method which resolves f of t, such as PostAuthor.
+func fieldResolverName(t *schema.TypeDefinition, f *schema.FieldDefinition) string {
+	return string(t.Name) + toUpperCase(string(f.Name))
+}
 
-	return &ast.GenDecl{
-		Tok: token.TYPE,
-		Specs: []ast.Spec{
-			&ast.TypeSpec{
-				Name: ident,
-				Type: &ast.InterfaceType{
-					Methods: generateField(operation.Fields),
+// generateFieldResolverFuncType generates the type of the field resolver of f of t,
+// which takes the object the field belongs to and the arguments of the field if it has arguments,
+// and returns the value of the field with an error.
+func (g *Generator) generateFieldResolverFuncType(t *schema.TypeDefinition, f *schema.FieldDefinition) *ast.FuncType {
+	params := []*ast.Field{
+		{
+			Names: []*ast.Ident{ast.NewIdent("ctx")},
+			Type: &ast.SelectorExpr{
+				X:   ast.NewIdent("context"),
+				Sel: ast.NewIdent("Context"),
+			},
+		},
+		{
+			Names: []*ast.Ident{ast.NewIdent("obj")},
+			Type: &ast.StarExpr{
+				X: &ast.SelectorExpr{
+					X:   ast.NewIdent("model"),
+					Sel: ast.NewIdent(string(t.Name)),
 				},
 			},
 		},
 	}
-}
-
-func isUsedDefinedType(operation *schema.OperationDefinition) bool {
-	if operation != nil {
-		for _, field := range operation.Fields {
-			if !GraphQLType(field.Type.Name).IsPrimitive() {
-				return true
-			}
 
-			for _, arg := range field.Arguments {
-				if !GraphQLType(arg.Type.Name).IsPrimitive() {
-					return true
-				}
-			}
-		}
+	if len(f.Arguments) > 0 {
+		params = append(params, &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent("args")},
+			Type: &ast.SelectorExpr{
+				X:   ast.NewIdent("model"),
+				Sel: ast.NewIdent(fieldResolverName(t, f) + "Args"),
+			},
+		})
 	}
 
-	return false
+	return &ast.FuncType{
+		Params: &ast.FieldList{
+			List: params,
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{Type: g.generateTypeExprFromFieldType(g.typedResultType(f.Type))},
+				{Type: ast.NewIdent("error")},
+			},
+		},
+	}
 }
 
-func generateResolverImplementationStruct() []ast.Decl {
-	return []ast.Decl{
-		&ast.GenDecl{
-			Tok: token.TYPE,
-			Specs: []ast.Spec{
-				&ast.TypeSpec{
-					Name: &ast.Ident{
-						Name: "resolver",
-					},
-					Type: &ast.StructType{
-						Fields: &ast.FieldList{
-							List: []*ast.Field{
-								{
-									Names: []*ast.Ident{
-										ast.NewIdent("parser"),
-									},
-									Type: &ast.StarExpr{
-										X: &ast.SelectorExpr{
-											X:   ast.NewIdent("query"),
-											Sel: ast.NewIdent("Parser"),
-										},
-									},
-								},
-							},
-						},
-					},
+// generateFieldResolverCall generates the body of the completion of f of t in its walker,
+// which calls the field resolver of f with the object being walked and completes its result.
+// The arguments of the field are coerced from the selection, and a field whose arguments can't be coerced is null.
+func (g *Generator) generateFieldResolverCall(t *schema.TypeDefinition, f *schema.FieldDefinition) *ast.BlockStmt {
+	stmts := make([]ast.Stmt, 0)
+	args := []ast.Expr{
+		ast.NewIdent("ctx"),
+		ast.NewIdent("v"),
+	}
+
+	if len(f.Arguments) > 0 {
+		stmts = append(stmts,
+			&ast.AssignStmt{
+				Tok: token.DEFINE,
+				Lhs: []ast.Expr{
+					ast.NewIdent("args"),
+					ast.NewIdent("err"),
+				},
+				Rhs: []ast.Expr{
+					ast.NewIdent(fmt.Sprintf("executor.DecodeArgumentValues[model.%sArgs](r.schema, r.schema.Indexes.GetTypeDefinition(%q).GetFieldByName([]byte(%q)).Arguments, sel.Arguments, variables)", fieldResolverName(t, f), t.Name, f.Name)),
 				},
 			},
-		},
-		&ast.GenDecl{
-			Tok: token.VAR,
-			Specs: []ast.Spec{
-				&ast.ValueSpec{
-					Names: []*ast.Ident{
-						{
-							Name: "_",
-						},
-					},
-					Type: ast.NewIdent("Resolver"),
-					Values: []ast.Expr{
-						&ast.UnaryExpr{
-							Op: token.AND,
-							X: &ast.Ident{
-								Name: "resolver{}",
-							},
+			&ast.IfStmt{
+				Cond: ast.NewIdent("err != nil"),
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{X: ast.NewIdent("errs.Raise(path, sel.Loc, executor.Errorf(executor.ErrorCodeBadUserInput, \"%s\", err))")},
+						&ast.ReturnStmt{
+							Results: []ast.Expr{ast.NewIdent("nil")},
 						},
 					},
 				},
 			},
-		},
-		&ast.FuncDecl{
-			Name: ast.NewIdent("NewResolver"),
-			Type: &ast.FuncType{
-				Results: &ast.FieldList{
-					List: []*ast.Field{
-						{
-							Type: &ast.StarExpr{
-								X: ast.NewIdent("resolver"),
-							},
+		)
+		args = append(args, ast.NewIdent("args"))
+	}
+
+	return &ast.BlockStmt{
+		List: append(stmts,
+			&ast.AssignStmt{
+				Tok: token.DEFINE,
+				Lhs: []ast.Expr{
+					ast.NewIdent("data"),
+					ast.NewIdent("err"),
+				},
+				Rhs: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("r"),
+							Sel: ast.NewIdent(fieldResolverName(t, f)),
 						},
+						Args: args,
 					},
 				},
 			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ReturnStmt{
-						Results: []ast.Expr{
-							&ast.CompositeLit{
-								Type: ast.NewIdent("&resolver"),
-								Elts: []ast.Expr{
-									&ast.KeyValueExpr{
-										Key: ast.NewIdent("parser"),
-										Value: &ast.SelectorExpr{
-											Sel: ast.NewIdent("NewParserWithLexer()"),
-											X:   ast.NewIdent("query"),
-										},
-									},
-								},
-							},
+			&ast.IfStmt{
+				Cond: ast.NewIdent("err != nil"),
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{X: ast.NewIdent("errs.Raise(path, sel.Loc, err)")},
+						&ast.ReturnStmt{
+							Results: []ast.Expr{ast.NewIdent("nil")},
 						},
 					},
 				},
 			},
-		},
+			&ast.ReturnStmt{
+				Results: []ast.Expr{
+					ast.NewIdent(g.generateWalkExpr(g.typedResultType(f.Type), "data", "r", "ctx", "sel.Selections", "variables", "sel.Loc", false)),
+				},
+			},
+		),
 	}
 }
 
-func generateResolverImplementation(fields schema.FieldDefinitions) []ast.Decl {
-	decls := make([]ast.Decl, 0, len(fields))
-
-	recv := func(t *schema.FieldType) string {
-		if t.IsList {
-			return fmt.Sprintf("[]%s", t.ListType.Name)
-		}
-
-		graphQLType := GraphQLType(t.Name)
-		return graphQLType.golangType()
+// generateFieldResolverInterface generates the interface of the field resolvers of the fields of t, which the resolver implements.
+func (g *Generator) generateFieldResolverInterface(t *schema.TypeDefinition, fields schema.FieldDefinitions) ast.Decl {
+	methods := make([]*ast.Field, 0, len(fields))
+	for _, f := range fields {
+		methods = append(methods, &ast.Field{
+			Doc:   descriptionDoc(f.Description),
+			Names: []*ast.Ident{ast.NewIdent(fieldResolverName(t, f))},
+			Type:  g.generateFieldResolverFuncType(t, f),
+		})
 	}
 
-	for _, f := range fields {
-		returnsStr := recv(f.Type)
+	return g.positionDocs(&ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: ast.NewIdent(string(t.Name) + "Resolver"),
+				Type: &ast.InterfaceType{
+					Methods: &ast.FieldList{
+						List: methods,
+					},
+				},
+			},
+		},
+	})
+}
 
-		if f.Type != nil {
-			decls = append(decls, generateResponseGraphQLResponseStruct(string(f.Name), f))
-		}
+// generateFieldResolverImplementation generates the field resolvers of the fields of t to be implemented,
+// which return the zero values of the fields.
+func (g *Generator) generateFieldResolverImplementation(t *schema.TypeDefinition, fields schema.FieldDefinitions) []ast.Decl {
+	decls := make([]ast.Decl, 0, len(fields))
 
+	for _, f := range fields {
 		decls = append(decls, &ast.FuncDecl{
 			Doc: &ast.CommentGroup{
 				List: []*ast.Comment{
 					{
-						Text: fmt.Sprintf("// Read request body for %sArgs", toUpperCase(string(f.Name))),
-					},
-					{
-						Text: fmt.Sprintf("// Write response body for %s", returnsStr),
+						Text: fmt.Sprintf("// Return the %s of obj, or an error which is reported at the field", f.Name),
 					},
 				},
 			},
-			Name: ast.NewIdent(toUpperCase(string(f.Name))),
-			Recv: &ast.FieldList{
-				List: []*ast.Field{
-					{
-						Names: []*ast.Ident{
-							{
-								Name: "r",
-							},
-						},
-						Type: &ast.StarExpr{
-							X: &ast.Ident{
-								Name: "resolver",
-							},
+			Name: ast.NewIdent(fieldResolverName(t, f)),
+			Recv: generateResolverRecv(),
+			Type: g.generateFieldResolverFuncType(t, f),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							g.generateZeroValueExpr(g.typedResultType(f.Type)),
+							ast.NewIdent("nil"),
 						},
 					},
 				},
 			},
-			Type: &ast.FuncType{
-				Params:  generateServeHTTPArgs(),
-				Results: &ast.FieldList{},
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{},
-			},
 		})
 	}
 
@@ -0,0 +1,54 @@
+package resolver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"context"
+	"example/graphql/model"
+	"github.com/n9te9/goliteql/executor"
+)
+
+type QueryResolver interface {
+	Posts(w http.ResponseWriter, req *http.Request)
+	Node(w http.ResponseWriter, req *http.Request)
+	Search(w http.ResponseWriter, req *http.Request)
+}
+
+type postsGraphQLResponse struct {
+	Data   []model.Post
+	Errors executor.GraphQLErrors
+}
+
+// Posts returns a post by an author whose role isn't a value of Role.
+func (r *resolver) Posts(w http.ResponseWriter, req *http.Request) {
+	json.NewEncoder(w).Encode(executor.GraphQLResponse{
+		Data: []model.Post{
+			{Id: "1", Title: "a", Author: &model.User{Id: "2", Name: "b", Role: "OWNER"}},
+		},
+	})
+}
+
+type nodeGraphQLResponse struct {
+	Data   model.Node
+	Errors executor.GraphQLErrors
+}
+
+func (r *resolver) Node(w http.ResponseWriter, req *http.Request) {
+}
+
+type searchGraphQLResponse struct {
+	Data   []model.SearchResult
+	Errors executor.GraphQLErrors
+}
+
+func (r *resolver) Search(w http.ResponseWriter, req *http.Request) {
+}
+
+type UserResolver interface {
+	UserPosts(ctx context.Context, obj *model.User, args model.UserPostsArgs) ([]model.Post, error)
+}
+
+func (r *resolver) UserPosts(ctx context.Context, obj *model.User, args model.UserPostsArgs) ([]model.Post, error) {
+	return []model.Post{}, nil
+}
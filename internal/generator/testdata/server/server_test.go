@@ -0,0 +1,91 @@
+package resolver_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example/graphql/resolver"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResolver_ServeHTTP_InvalidEnumValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "an invalid enum value which isn't selected is ignored",
+			query: `{ posts { id title } }`,
+			want:  `{"data":{"posts":[{"id":"1","title":"a"}]}}`,
+		},
+		{
+			name:  "an invalid enum value is a field error at its path",
+			query: `{ posts { id author { name role } } }`,
+			want:  `{"data":{"posts":[{"id":"1","author":null}]},"errors":[{"message":"\"OWNER\" is not a valid Role","locations":[{"line":1,"column":28}],"path":["posts",0,"author","role"],"extensions":{"code":"INTERNAL_SERVER_ERROR"}}]}`,
+		},
+	}
+
+	r := resolver.NewResolver()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(map[string]any{"query": tt.query})
+			if err != nil {
+				t.Fatalf("error encoding request: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest("POST", "/", bytes.NewReader(body)))
+
+			if diff := cmp.Diff(tt.want, strings.TrimSpace(w.Body.String())); diff != "" {
+				t.Errorf("response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolver_ServeHTTP_CustomScalarVariable(t *testing.T) {
+	tests := []struct {
+		name      string
+		variables map[string]any
+		wantCode  int
+		want      string
+	}{
+		{
+			name:      "a value of the Go type of the scalar is given to the resolver",
+			variables: map[string]any{"a": "2024-01-01T00:00:00Z"},
+			wantCode:  200,
+			want:      `{"data":{"posts":[{"author":{"posts":[]}}]}}`,
+		},
+		{
+			name:      "a value which can't be decoded into the Go type of the scalar is a request error",
+			variables: map[string]any{"a": "nope"},
+			wantCode:  400,
+			want:      `{"errors":[{"message":"variable $a: expected DateTime value, got \"nope\"","extensions":{"code":"BAD_USER_INPUT"}}]}`,
+		},
+	}
+
+	r := resolver.NewResolver()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(map[string]any{"query": `query ($a: DateTime) { posts { author { posts(after: $a) { id } } } }`, "variables": tt.variables})
+			if err != nil {
+				t.Fatalf("error encoding request: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest("POST", "/", bytes.NewReader(body)))
+
+			if diff := cmp.Diff(tt.wantCode, w.Code); diff != "" {
+				t.Errorf("status code mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.want, strings.TrimSpace(w.Body.String())); diff != "" {
+				t.Errorf("response mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
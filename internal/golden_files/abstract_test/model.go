@@ -0,0 +1,202 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/n9te9/goliteql/executor"
+)
+
+type Media interface {
+	IsMedia()
+}
+
+func UnmarshalMedia(data []byte) (Media, error) {
+	if executor.IsNullJSON(data) {
+		return nil, nil
+	}
+	
+	var typename struct {
+		Typename string `json:"__typename"`
+	}
+	if err := json.Unmarshal(data, &typename); err != nil {
+		return nil, err
+	}
+	
+	switch typename.Typename {
+	case "Image":
+		var v Image
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case "Video":
+		var v Video
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	
+	return nil, fmt.Errorf("unknown __typename %q for Media", typename.Typename)
+}
+
+type SearchResult interface {
+	IsSearchResult()
+}
+
+func UnmarshalSearchResult(data []byte) (SearchResult, error) {
+	if executor.IsNullJSON(data) {
+		return nil, nil
+	}
+	
+	var typename struct {
+		Typename string `json:"__typename"`
+	}
+	if err := json.Unmarshal(data, &typename); err != nil {
+		return nil, err
+	}
+	
+	switch typename.Typename {
+	case "Post":
+		var v Post
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case "User":
+		var v User
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	
+	return nil, fmt.Errorf("unknown __typename %q for SearchResult", typename.Typename)
+}
+
+type Node interface {
+	IsNode()
+}
+
+func UnmarshalNode(data []byte) (Node, error) {
+	if executor.IsNullJSON(data) {
+		return nil, nil
+	}
+	
+	var typename struct {
+		Typename string `json:"__typename"`
+	}
+	if err := json.Unmarshal(data, &typename); err != nil {
+		return nil, err
+	}
+	
+	switch typename.Typename {
+	case "Post":
+		var v Post
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case "User":
+		var v User
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	
+	return nil, fmt.Errorf("unknown __typename %q for Node", typename.Typename)
+}
+
+type Post struct {
+	Id          string   `json:"id"`
+	Title       string   `json:"title"`
+	Attachments *[]Media `json:"attachments"`
+}
+
+func (Post) IsNode() {
+}
+func (Post) IsSearchResult() {
+}
+func (t Post) MarshalJSON() ([]byte, error) {
+	type alias Post
+	
+	return json.Marshal(struct {
+		Typename string `json:"__typename"`
+		alias
+	}{Typename: "Post", alias: alias(t)})
+}
+func (t *Post) UnmarshalJSON(data []byte) error {
+	type alias Post
+	var mapper struct {
+		*alias
+		Attachments json.RawMessage `json:"attachments"`
+	}
+	mapper.alias = (*alias)(t)
+	if err := json.Unmarshal(data, &mapper); err != nil {
+		return err
+	}
+	
+	var err error
+	if t.Attachments, err = executor.UnmarshalNullable(mapper.Attachments, func(data []byte) ([]Media, error) { return executor.UnmarshalList(data, UnmarshalMedia) }); err != nil {
+		return err
+	}
+	
+	return nil
+}
+
+type User struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func (User) IsNode() {
+}
+func (User) IsSearchResult() {
+}
+func (t User) MarshalJSON() ([]byte, error) {
+	type alias User
+	
+	return json.Marshal(struct {
+		Typename string `json:"__typename"`
+		alias
+	}{Typename: "User", alias: alias(t)})
+}
+
+type Image struct {
+	Url string `json:"url"`
+}
+
+func (Image) IsMedia() {
+}
+func (t Image) MarshalJSON() ([]byte, error) {
+	type alias Image
+	
+	return json.Marshal(struct {
+		Typename string `json:"__typename"`
+		alias
+	}{Typename: "Image", alias: alias(t)})
+}
+
+type Video struct {
+	Url    string `json:"url"`
+	Length int    `json:"length"`
+}
+
+func (Video) IsMedia() {
+}
+func (t Video) MarshalJSON() ([]byte, error) {
+	type alias Video
+	
+	return json.Marshal(struct {
+		Typename string `json:"__typename"`
+		alias
+	}{Typename: "Video", alias: alias(t)})
+}
+
+type SearchArgs struct {
+	Text string `json:"text"`
+}
+type NodeArgs struct {
+	Id string `json:"id"`
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Progress of a task.
+type Status string
+
+const (
+	// Not started yet
+	StatusTodo       Status = "TODO"
+	StatusInProgress Status = "IN_PROGRESS"
+	StatusDone       Status = "DONE"
+)
+
+func (e Status) IsValid() bool {
+	switch e {
+	case StatusTodo, StatusInProgress, StatusDone:
+		return true
+	}
+	
+	return false
+}
+
+type UpdateTaskInput struct {
+	Id string `json:"id"`
+	// New status of the task.
+	// Moving back to TODO is allowed.
+	Status Status `json:"status"`
+}
+
+func (t *UpdateTaskInput) UnmarshalJSON(data []byte) error {
+	var mapper struct {
+		Id     *string `json:"id"`
+		Status *Status `json:"status"`
+	}
+	if err := json.Unmarshal(data, &mapper); err != nil {
+		return err
+	}
+	if mapper.Id == nil {
+		return fmt.Errorf(`id is required`)
+	}
+	if mapper.Status == nil {
+		return fmt.Errorf(`status is required`)
+	}
+	if mapper.Status != nil && !mapper.Status.IsValid() {
+		return fmt.Errorf("%s is not a valid Status", *mapper.Status)
+	}
+	t.Id = *mapper.Id
+	t.Status = *mapper.Status
+	return nil
+}
+
+// A task to be done
+type Task struct {
+	Id string `json:"id"`
+	// Title of the task
+	Title          string  `json:"title"`
+	Status         Status  `json:"status"`
+	PreviousStatus *Status `json:"previousStatus"`
+}
+type TasksArgs struct {
+	Status *Status `json:"status"`
+}
+type UpdateTaskArgs struct {
+	Input UpdateTaskInput `json:"input"`
+}
@@ -1,4 +1,4 @@
-package generated
+package model
 
 type Post struct {
 	Id        string `json:"id"`
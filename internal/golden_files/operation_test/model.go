@@ -1,6 +1,74 @@
-package generated
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Hoge struct {
+	Id string `json:"id"`
+}
+
+func (t *Hoge) UnmarshalJSON(data []byte) error {
+	var mapper struct {
+		Id *string `json:"id"`
+	}
+	if err := json.Unmarshal(data, &mapper); err != nil {
+		return err
+	}
+	if mapper.Id == nil {
+		return fmt.Errorf(`id is required`)
+	}
+	t.Id = *mapper.Id
+	return nil
+}
 
 type NewPost struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Title       string  `json:"title"`
+	Content     string  `json:"content"`
+	Description *string `json:"description"`
+	Hoges       *[]Hoge `json:"hoges"`
+}
+
+func (t *NewPost) UnmarshalJSON(data []byte) error {
+	var mapper struct {
+		Title       *string `json:"title"`
+		Content     *string `json:"content"`
+		Description *string `json:"description"`
+		Hoges       []Hoge  `json:"hoges"`
+	}
+	if err := json.Unmarshal(data, &mapper); err != nil {
+		return err
+	}
+	if mapper.Title == nil {
+		return fmt.Errorf(`title is required`)
+	}
+	if mapper.Content == nil {
+		return fmt.Errorf(`content is required`)
+	}
+	t.Title = *mapper.Title
+	t.Content = *mapper.Content
+	t.Description = mapper.Description
+	t.Hoges = &mapper.Hoges
+	return nil
+}
+
+type Post struct {
+	Id          string   `json:"id"`
+	Title       string   `json:"title"`
+	Content     string   `json:"content"`
+	Description *string  `json:"description"`
+	Tags        []string `json:"tags"`
+}
+type PostTagsArgs struct {
+	First *int `json:"first"`
+}
+type PostArgs struct {
+	Id string `json:"id"`
+}
+type PostsArgs struct {
+	Query *string `json:"query"`
+}
+type CreatePostArgs struct {
+	Data NewPost `json:"data"`
 }
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/n9te9/goliteql/schema"
+	"time"
+)
+// RegisterScalars registers the Go types of the custom scalars to s, which validate the values of the scalars in queries.
+func RegisterScalars(s *schema.Schema) error {
+	if err := schema.RegisterScalar[time.Time](s, "DateTime"); err != nil {
+		return err
+	}
+	if err := schema.RegisterScalar[json.RawMessage](s, "JSON"); err != nil {
+		return err
+	}
+	return nil
+}
+
+type NewEvent struct {
+	Name     string           `json:"name"`
+	StartsAt time.Time        `json:"startsAt"`
+	Metadata *json.RawMessage `json:"metadata"`
+}
+
+func (t *NewEvent) UnmarshalJSON(data []byte) error {
+	var mapper struct {
+		Name     *string          `json:"name"`
+		StartsAt *time.Time       `json:"startsAt"`
+		Metadata *json.RawMessage `json:"metadata"`
+	}
+	if err := json.Unmarshal(data, &mapper); err != nil {
+		return err
+	}
+	if mapper.Name == nil {
+		return fmt.Errorf(`name is required`)
+	}
+	if mapper.StartsAt == nil {
+		return fmt.Errorf(`startsAt is required`)
+	}
+	t.Name = *mapper.Name
+	t.StartsAt = *mapper.StartsAt
+	t.Metadata = mapper.Metadata
+	return nil
+}
+
+type Event struct {
+	Id       string           `json:"id"`
+	Name     string           `json:"name"`
+	StartsAt time.Time        `json:"startsAt"`
+	EndsAt   *time.Time       `json:"endsAt"`
+	Metadata *json.RawMessage `json:"metadata"`
+}
+type EventsArgs struct {
+	After *time.Time `json:"after"`
+}
+type CreateEventArgs struct {
+	Data NewEvent `json:"data"`
+}
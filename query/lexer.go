@@ -1,6 +1,7 @@
 package query
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"slices"
@@ -18,6 +19,8 @@ type Token struct {
 	Value []byte
 	Line int
 	Column int
+	// Offset is the byte offset of the token from the start of the input.
+	Offset int
 }
 
 const (
@@ -53,28 +56,46 @@ var queryKeywords = map[string]Type{
 	"fragment": Fragment,
 }
 
+func (t *Token) start() Position {
+	return Position{Line: t.Line, Column: t.Column, Offset: t.Offset}
+}
+
+// end returns the position just after the token, which is on a later line than its start
+// if the token is a block string with line breaks.
+func (t *Token) end() Position {
+	pos := Position{Line: t.Line, Column: t.Column + len(t.Value), Offset: t.Offset + len(t.Value)}
+	if i := bytes.LastIndexByte(t.Value, '\n'); i >= 0 {
+		pos.Line += bytes.Count(t.Value, []byte("\n"))
+		pos.Column = len(t.Value) - i
+	}
+
+	return pos
+}
+
 func newNameToken(input []byte, cur, col, line int) (*Token, int) {
 	start := cur
-	for cur < len(input) && unicode.IsLetter(rune(input[cur])) || unicode.IsDigit(rune(input[cur])) {
+	for cur < len(input) && (unicode.IsLetter(rune(input[cur])) || unicode.IsDigit(rune(input[cur])) || input[cur] == '_') {
 		cur++
 	}
 
 	if tokenType, ok := queryKeywords[string(input[start:cur])]; ok {
-		return &Token{Type: tokenType, Value: input[start:cur], Column: col, Line: line}, cur
+		return &Token{Type: tokenType, Value: input[start:cur], Column: col, Line: line, Offset: start}, cur
 	}
-	return &Token{Type: Name, Value: input[start:cur], Column: col, Line: line}, cur
+	return &Token{Type: Name, Value: input[start:cur], Column: col, Line: line, Offset: start}, cur
 }
 
+// newBlockStringValueToken returns the block string starting at cur with the line and column after it,
+// which can be on a later line than the start of the string.
 func newBlockStringValueToken(input []byte, cur, col, line int) (*Token, int, int, int, error) {
 	start := cur
+	startCol, startLine := col, line
 	cur += 3
+	col += 3
 
-	tokenStartLine := line
 	for cur + 2 < len(input) {
 		if input[cur] == '"' && input[cur + 1] == '"' && input[cur + 2] == '"' {
 			break
 		}
-		cur++
 
 		if input[cur] == '\n' {
 			line++
@@ -82,16 +103,19 @@ func newBlockStringValueToken(input []byte, cur, col, line int) (*Token, int, in
 		} else {
 			col++
 		}
+		cur++
 	}
 
-	if cur + 2 > len(input) {
-		return nil, -1, -1, -1, fmt.Errorf("unterminated string at line %d, column %d", tokenStartLine, col)
+	if cur + 2 >= len(input) {
+		return nil, -1, -1, -1, fmt.Errorf("unterminated string at line %d, column %d", startLine, startCol)
 	}
 	cur += 3
+	col += 3
 
-	return &Token{Type: Value, Value: input[start:cur], Column: col, Line: tokenStartLine}, cur, line, col, nil
+	return &Token{Type: Value, Value: input[start:cur], Column: startCol, Line: startLine, Offset: start}, cur, line, col, nil
 }
 
+// newStringValueToken returns the string starting at cur with the line and column after it.
 func newStringValueToken(input []byte, cur, col, line int) (*Token, int, int, int, error) {
 	if cur + 3 < len(input) && input[cur] == '"' && input[cur + 1] == '"' && input[cur + 2] == '"' {
 		return newBlockStringValueToken(input, cur, col, line)
@@ -107,32 +131,40 @@ func newStringValueToken(input []byte, cur, col, line int) (*Token, int, int, in
 			escape = false
 		}
 		cur++
-		col++
 	}
 
 	if cur >= len(input) {
 		return nil, -1, -1, -1, fmt.Errorf("unterminated string at line %d, column %d", line, col)
 	}
+	cur++
 
-	return &Token{Type: Value, Value: input[start:cur+1], Column: col, Line: line}, cur + 1, line, col, nil
+	return &Token{Type: Value, Value: input[start:cur], Column: col, Line: line, Offset: start}, cur, line, col + cur - start, nil
 }
 
 
-func newValueToken(input []byte, cur, col, line int) (*Token, int, int, int) {
+func newValueToken(input []byte, cur, col, line int) (*Token, int) {
 	start := cur
-	for cur < len(input) && unicode.IsLetter(rune(input[cur])) || unicode.IsDigit(rune(input[cur])) || input[cur] == '.' {
+	if input[cur] == '-' {
 		cur++
-		col++
+	}
+
+	for cur < len(input) && (unicode.IsLetter(rune(input[cur])) || unicode.IsDigit(rune(input[cur])) || input[cur] == '_' || input[cur] == '.') {
+		cur++
+
+		// the sign of the exponent of a float
+		if cur < len(input) && (input[cur-1] == 'e' || input[cur-1] == 'E') && (input[cur] == '-' || input[cur] == '+') && unicode.IsDigit(rune(input[start])) {
+			cur++
+		}
 	}
 
 	if tokenType, ok := queryKeywords[string(input[start:cur])]; ok {
-		return &Token{Type: tokenType, Value: input[start:cur], Column: col, Line: line}, cur, line, col
+		return &Token{Type: tokenType, Value: input[start:cur], Column: col, Line: line, Offset: start}, cur
 	}
-	return &Token{Type: Name, Value: input[start:cur], Column: col, Line: line}, cur, line, col
+	return &Token{Type: Name, Value: input[start:cur], Column: col, Line: line, Offset: start}, cur
 }
 
-func newEOFToken(col, line int) *Token {
-	return &Token{Type: EOF, Value: nil, Column: col, Line: line}
+func newEOFToken(cur, col, line int) *Token {
+	return &Token{Type: EOF, Value: nil, Column: col, Line: line, Offset: cur}
 }
 
 var queryPunctuators = map[byte]Type{
@@ -198,6 +230,11 @@ func (t Types) isArgument() bool {
 }
 
 func (l *Lexer) Lex(input []byte) (Tokens, error) {
+	return l.lex(input, false)
+}
+
+// lex returns the tokens of input, which is lexed as a value if value is true.
+func (l *Lexer) lex(input []byte, value bool) (Tokens, error) {
 	tokens := make(Tokens, 0)
 	cur := 0
 	col, line := 1, 1
@@ -207,7 +244,7 @@ func (l *Lexer) Lex(input []byte) (Tokens, error) {
 	stack := make(Types, 0)
 	for cur < len(input) {
 		switch input[cur] {
-		case ' ', '\t':
+		case ' ', '\t', '\r':
 			col++
 			cur++
 			continue
@@ -216,12 +253,19 @@ func (l *Lexer) Lex(input []byte) (Tokens, error) {
 			col = 1
 			cur++
 			continue
+		case '#':
+			// comments run to the end of the line and are ignored like white spaces
+			for cur < len(input) && input[cur] != '\n' {
+				col++
+				cur++
+			}
+			continue
 		}
 
 		switch input[cur] {
 		case '{', '(', '[':
 			stack = append(stack, queryPunctuators[input[cur]])
-			tokens = append(tokens, &Token{Type: queryPunctuators[input[cur]], Value: []byte{input[cur]}, Column: col, Line: line})
+			tokens = append(tokens, &Token{Type: queryPunctuators[input[cur]], Value: []byte{input[cur]}, Column: col, Line: line, Offset: cur})
 			cur++
 			col++
 			continue
@@ -243,7 +287,7 @@ func (l *Lexer) Lex(input []byte) (Tokens, error) {
 			}
 
 			stack = stack[:len(stack) - 1]
-			tokens = append(tokens, &Token{Type: queryPunctuators[input[cur]], Value: []byte{input[cur]}, Column: col, Line: line})
+			tokens = append(tokens, &Token{Type: queryPunctuators[input[cur]], Value: []byte{input[cur]}, Column: col, Line: line, Offset: cur})
 			cur++
 			col++
 			continue
@@ -251,15 +295,15 @@ func (l *Lexer) Lex(input []byte) (Tokens, error) {
 
 		switch input[cur] {
 		case ':', '@', ',', '=', '$', '!':
-			tokens = append(tokens, &Token{Type: queryPunctuators[input[cur]], Value: []byte{input[cur]}, Column: col, Line: line})
+			tokens = append(tokens, &Token{Type: queryPunctuators[input[cur]], Value: []byte{input[cur]}, Column: col, Line: line, Offset: cur})
 			cur++
 			col++
 			continue
 		}
 
-		if tokens.isDefaultValue() || tokens.isArgument() || stack.isArgument() {
-			if unicode.IsLetter(rune(input[cur])) || unicode.IsDigit(rune(input[cur])) {
-				token, cur, line, col = newValueToken(input, cur, col, line)
+		if value || tokens.isDefaultValue() || tokens.isArgument() || stack.isArgument() {
+			if unicode.IsLetter(rune(input[cur])) || unicode.IsDigit(rune(input[cur])) || input[cur] == '_' || input[cur] == '-' {
+				token, cur = newValueToken(input, cur, col, line)
 				tokens = append(tokens, token)
 				col += len(token.Value)
 				continue
@@ -272,7 +316,6 @@ func (l *Lexer) Lex(input []byte) (Tokens, error) {
 				}
 
 				tokens = append(tokens, token)
-				col += len(token.Value) + 2
 				continue
 			}
 		}
@@ -286,7 +329,7 @@ func (l *Lexer) Lex(input []byte) (Tokens, error) {
 
 		if input[cur] == '.' {
 			if input[cur + 1] == '.' && input[cur + 2] == '.' {
-				tokens = append(tokens, &Token{Type: Spread, Value: []byte("..."), Column: col, Line: line})
+				tokens = append(tokens, &Token{Type: Spread, Value: []byte("..."), Column: col, Line: line, Offset: cur})
 				cur += 3
 				col += 3
 				continue
@@ -301,6 +344,6 @@ func (l *Lexer) Lex(input []byte) (Tokens, error) {
 		prev = token
 	}
 
-	tokens = append(tokens, newEOFToken(col, line))
+	tokens = append(tokens, newEOFToken(cur, col, line))
 	return tokens, nil
 }
@@ -2,6 +2,7 @@ package query
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 )
 
@@ -13,22 +14,46 @@ const (
 	SubscriptionOperation OperationType = "subscription"
 )
 
+// Position is a position in a query, where Line and Column start from 1 and Offset is the byte offset from 0.
+type Position struct {
+	Line   int
+	Column int
+	Offset int
+}
+
+// Loc is the location of a node in the query it's parsed from,
+// from the start of its first token to the end of its last token.
+type Loc struct {
+	Start Position
+	End   Position
+}
+
+func newLoc(tokens Tokens, start, end int) *Loc {
+	return &Loc{
+		Start: tokens[start].start(),
+		End:   tokens[end-1].end(),
+	}
+}
+
 type FieldType struct {
 	Name     []byte
 	Nullable bool
 	IsList   bool
 	ListType *FieldType
+	Loc      *Loc
 }
 
 type Variable struct {
 	Name         []byte
 	Type         *FieldType
-	DefaultValue []byte
+	DefaultValue InputValue
+	Loc          *Loc
 }
 
 type Directive struct {
 	Name      []byte
 	Arguments []*DirectiveArgument
+	Loc       *Loc
 }
 
 type Operation struct {
@@ -37,10 +62,40 @@ type Operation struct {
 	Variables     []*Variable
 	Selections    []Selection
 	Directives    []*Directive
+	Loc           *Loc
 }
 
 type Operations []*Operation
 
+var (
+	ErrNoOperation           = errors.New("must provide an operation")
+	ErrOperationNameRequired = errors.New("must provide operation name if query contains multiple operations")
+	ErrUnknownOperation      = errors.New("unknown operation")
+)
+
+// GetOperation returns the operation to execute as described in "GetOperation" of the specification,
+// which is the operation named operationName, or the only operation if operationName is empty.
+func (o Operations) GetOperation(operationName string) (*Operation, error) {
+	if operationName == "" {
+		switch len(o) {
+		case 0:
+			return nil, ErrNoOperation
+		case 1:
+			return o[0], nil
+		default:
+			return nil, ErrOperationNameRequired
+		}
+	}
+
+	for _, op := range o {
+		if op.Name == operationName {
+			return op, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w named %q", ErrUnknownOperation, operationName)
+}
+
 func (o Operations) GetQuery() *Operation {
 	for _, op := range o {
 		if op.OperationType == QueryOperation {
@@ -74,36 +129,53 @@ func (o Operations) GetSubscription() *Operation {
 type Selection interface {
 	isSelection()
 	GetSelections() []Selection
+	GetLoc() *Loc
 }
 
 type Argument struct {
-	Name         []byte
-	Type         *FieldType
-	DefaultValue []byte
+	Name  []byte
+	Value InputValue
+	Loc   *Loc
 }
 
 type DirectiveArgument struct {
-	Name       []byte
-	Value      []byte
-	IsVariable bool
+	Name  []byte
+	Value InputValue
+	Loc   *Loc
 }
 
 type Field struct {
+	Alias      []byte
 	Name       []byte
 	Arguments  []*Argument
 	Selections []Selection
 	Directives []*Directive
+	Loc        *Loc
 }
 
 func (f *Field) isSelection() {}
 
+// ResponseKey returns the key of the field in a response, which is its alias if it has one.
+func (f *Field) ResponseKey() []byte {
+	if len(f.Alias) > 0 {
+		return f.Alias
+	}
+
+	return f.Name
+}
+
 func (f *Field) GetSelections() []Selection {
 	return f.Selections
 }
 
+func (f *Field) GetLoc() *Loc {
+	return f.Loc
+}
+
 type FragmentSpread struct {
 	Name       []byte
 	Directives []*Directive
+	Loc        *Loc
 }
 
 func (f *FragmentSpread) isSelection() {}
@@ -112,10 +184,15 @@ func (f *FragmentSpread) GetSelections() []Selection {
 	return nil
 }
 
+func (f *FragmentSpread) GetLoc() *Loc {
+	return f.Loc
+}
+
 type InlineFragment struct {
 	TypeCondition []byte
 	Selections    []Selection
 	Directives    []*Directive
+	Loc           *Loc
 }
 
 func (f *InlineFragment) isSelection() {}
@@ -124,6 +201,10 @@ func (f *InlineFragment) GetSelections() []Selection {
 	return f.Selections
 }
 
+func (f *InlineFragment) GetLoc() *Loc {
+	return f.Loc
+}
+
 type Document struct {
 	tokens              []*Token
 	Operations          Operations
@@ -147,6 +228,7 @@ type FragmentDefinition struct {
 	Name          []byte
 	BasedTypeName []byte
 	Selections    []Selection
+	Loc           *Loc
 }
 
 func (f *FragmentDefinition) isSelection() {}
@@ -155,6 +237,10 @@ func (f *FragmentDefinition) GetSelections() []Selection {
 	return f.Selections
 }
 
+func (f *FragmentDefinition) GetLoc() *Loc {
+	return f.Loc
+}
+
 type Parser struct {
 	Lexer *Lexer
 }
@@ -183,8 +269,9 @@ func (p *Parser) Parse(input []byte) (*Document, error) {
 		Operations: make([]*Operation, 0),
 	}
 
-	for cur < len(tokens) {
-		if tokens[cur].Type.IsOperation() {
+	for tokens[cur].Type != EOF {
+		switch {
+		case tokens[cur].Type.IsOperation(), tokens[cur].Type == CurlyOpen:
 			op, newCur, err := p.parseOperation(tokens, cur)
 			if err != nil {
 				return nil, err
@@ -192,9 +279,7 @@ func (p *Parser) Parse(input []byte) (*Document, error) {
 
 			cur = newCur
 			doc.Operations = append(doc.Operations, op)
-		}
-
-		if tokens[cur].Type == Fragment {
+		case tokens[cur].Type == Fragment:
 			fragmentDefinition, newCur, err := p.parseFragmentDefinition(tokens, cur)
 			if err != nil {
 				return nil, err
@@ -202,10 +287,8 @@ func (p *Parser) Parse(input []byte) (*Document, error) {
 
 			cur = newCur
 			doc.FragmentDefinitions = append(doc.FragmentDefinitions, fragmentDefinition)
-		}
-
-		if tokens[cur].Type == EOF {
-			break
+		default:
+			return nil, fmt.Errorf("unexpected %s at line %d, column %d", tokens[cur].Value, tokens[cur].Line, tokens[cur].Column)
 		}
 	}
 
@@ -213,6 +296,7 @@ func (p *Parser) Parse(input []byte) (*Document, error) {
 }
 
 func (p *Parser) parseFragmentDefinition(tokens Tokens, cur int) (*FragmentDefinition, int, error) {
+	start := cur
 	cur++
 	if tokens[cur].Type != Name {
 		return nil, cur, fmt.Errorf("expected fragment name but got %s", tokens[cur].Value)
@@ -253,22 +337,25 @@ func (p *Parser) parseFragmentDefinition(tokens Tokens, cur int) (*FragmentDefin
 		Name:          fragmentName,
 		BasedTypeName: typeName,
 		Selections:    selections,
+		Loc:           newLoc(tokens, start, cur),
 	}, cur, nil
 }
 
 func (p *Parser) parseOperation(tokens Tokens, cur int) (*Operation, int, error) {
-	operationType := OperationType(tokens[cur].Value)
-	cur++
+	start := cur
+	// a selection set without an operation type is the shorthand of an anonymous query
+	op := &Operation{
+		OperationType: QueryOperation,
+	}
 
-	operationName := ""
-	if tokens[cur].Type == Name {
-		operationName = string(tokens[cur].Value)
+	if tokens[cur].Type.IsOperation() {
+		op.OperationType = OperationType(tokens[cur].Value)
 		cur++
-	}
 
-	op := &Operation{
-		OperationType: operationType,
-		Name:          operationName,
+		if tokens[cur].Type == Name {
+			op.Name = string(tokens[cur].Value)
+			cur++
+		}
 	}
 
 	if tokens[cur].Type == ParenOpen {
@@ -306,6 +393,7 @@ func (p *Parser) parseOperation(tokens Tokens, cur int) (*Operation, int, error)
 		return nil, cur, fmt.Errorf("expected } after operation")
 	}
 	cur++
+	op.Loc = newLoc(tokens, start, cur)
 
 	return op, cur, nil
 }
@@ -339,8 +427,9 @@ func (p *Parser) parseFragment(tokens Tokens, cur int) (Selection, int, error) {
 		return p.parseInlineFragment(tokens, cur)
 	}
 
-	if tokens[cur].Type == CurlyOpen {
-		cur++
+	// an inline fragment without a type condition starts with its directives or selections
+	if tokens[cur].Type == CurlyOpen || tokens[cur].Type == At {
+		return p.parseInlineFragmentBody(tokens, cur-1, cur, nil)
 	}
 
 	return p.parseFragmentSpread(tokens, cur)
@@ -351,9 +440,11 @@ func (p *Parser) parseInlineFragment(tokens Tokens, cur int) (*InlineFragment, i
 		return nil, cur, fmt.Errorf("expected type name but got %s", tokens[cur].Value)
 	}
 
-	v := tokens[cur].Value
-	cur++
+	// the inline fragment starts with the spread before on
+	return p.parseInlineFragmentBody(tokens, cur-2, cur+1, tokens[cur].Value)
+}
 
+func (p *Parser) parseInlineFragmentBody(tokens Tokens, start, cur int, typeCondition []byte) (*InlineFragment, int, error) {
 	var directives []*Directive = nil
 	for tokens[cur].Type == At {
 		cur++
@@ -377,14 +468,19 @@ func (p *Parser) parseInlineFragment(tokens Tokens, cur int) (*InlineFragment, i
 	}
 	cur = newCur
 
+	cur++
+
 	return &InlineFragment{
-		TypeCondition: v,
+		TypeCondition: typeCondition,
 		Selections:    selections,
 		Directives:    directives,
-	}, cur + 1, nil
+		Loc:           newLoc(tokens, start, cur),
+	}, cur, nil
 }
 
 func (p *Parser) parseFragmentSpread(tokens Tokens, cur int) (*FragmentSpread, int, error) {
+	// the fragment spread starts with the spread before its name
+	start := cur - 1
 	if tokens[cur].Type != Name {
 		return nil, cur, fmt.Errorf("expected fragment name but got %s", tokens[cur].Value)
 	}
@@ -406,10 +502,13 @@ func (p *Parser) parseFragmentSpread(tokens Tokens, cur int) (*FragmentSpread, i
 	return &FragmentSpread{
 		Name:       v,
 		Directives: directives,
+		Loc:        newLoc(tokens, start, cur),
 	}, cur, nil
 }
 
 func (p *Parser) parseDirective(tokens Tokens, cur int) (*Directive, int, error) {
+	// the directive starts with the @ before its name
+	start := cur - 1
 	if tokens[cur].Type != Name {
 		return nil, cur, fmt.Errorf("expected directive name but got %s", tokens[cur].Value)
 	}
@@ -430,6 +529,7 @@ func (p *Parser) parseDirective(tokens Tokens, cur int) (*Directive, int, error)
 	return &Directive{
 		Arguments: arguments,
 		Name:      v,
+		Loc:       newLoc(tokens, start, cur),
 	}, cur, nil
 }
 
@@ -456,7 +556,8 @@ func (p *Parser) parseDirectiveArguments(tokens Tokens, cur int) ([]*DirectiveAr
 }
 
 func (p *Parser) parseDirectiveArgument(tokens Tokens, cur int) (*DirectiveArgument, int, error) {
-	if tokens[cur].Type != Name {
+	start := cur
+	if !isNameToken(tokens[cur]) {
 		return nil, cur, fmt.Errorf("expected directive argument name but got %s", tokens[cur].Value)
 	}
 
@@ -468,60 +569,20 @@ func (p *Parser) parseDirectiveArgument(tokens Tokens, cur int) (*DirectiveArgum
 	}
 	cur++
 
-	var isVariable bool
-	if tokens[cur].Type == Dollar {
-		isVariable = true
-		cur++
-
-		if tokens[cur].Type != Name {
-			return nil, cur, fmt.Errorf("expected variable name after $")
-		}
-
-		return &DirectiveArgument{
-			Name:       name,
-			Value:      tokens[cur].Value,
-			IsVariable: isVariable,
-		}, cur, nil
-	}
-
-	if tokens[cur].Type == Value || tokens[cur].Type == Name {
-		return &DirectiveArgument{
-			Name:       name,
-			Value:      tokens[cur].Value,
-			IsVariable: isVariable,
-		}, cur + 1, nil
-	}
-
-	if tokens[cur].Type == CurlyOpen {
-		newValue, newCur, err := p.parseObjectValue(tokens, cur)
-		if err != nil {
-			return nil, newCur, err
-		}
-
-		return &DirectiveArgument{
-			Name:       name,
-			Value:      newValue,
-			IsVariable: isVariable,
-		}, newCur, nil
-	}
-
-	if tokens[cur].Type == BracketOpen {
-		newValue, newCur, err := p.parseListValue(tokens, cur)
-		if err != nil {
-			return nil, newCur, err
-		}
-
-		return &DirectiveArgument{
-			Name:       name,
-			Value:      newValue,
-			IsVariable: isVariable,
-		}, newCur, nil
+	value, cur, err := p.parseValue(tokens, cur, false)
+	if err != nil {
+		return nil, cur, err
 	}
 
-	return nil, cur, fmt.Errorf("unexpected token")
+	return &DirectiveArgument{
+		Name:  name,
+		Value: value,
+		Loc:   newLoc(tokens, start, cur),
+	}, cur, nil
 }
 
 func (p *Parser) parseField(tokens Tokens, cur int) (*Field, int, error) {
+	start := cur
 	if tokens[cur].Type != Name {
 		return nil, cur, fmt.Errorf("expected field but got %s at %d row, %d col", tokens[cur].Value, tokens[cur].Line, tokens[cur].Column)
 	}
@@ -531,6 +592,17 @@ func (p *Parser) parseField(tokens Tokens, cur int) (*Field, int, error) {
 	}
 	cur++
 
+	if tokens[cur].Type == Colon {
+		cur++
+		if tokens[cur].Type != Name {
+			return nil, cur, fmt.Errorf("expected field after alias %s but got %s at %d row, %d col", field.Name, tokens[cur].Value, tokens[cur].Line, tokens[cur].Column)
+		}
+
+		field.Alias = field.Name
+		field.Name = tokens[cur].Value
+		cur++
+	}
+
 	if tokens[cur].Type == ParenOpen {
 		arguments, newCur, err := p.parseFieldArguments(tokens, cur)
 		if err != nil {
@@ -560,6 +632,7 @@ func (p *Parser) parseField(tokens Tokens, cur int) (*Field, int, error) {
 		cur = newCur + 1
 		field.Selections = selections
 	}
+	field.Loc = newLoc(tokens, start, cur)
 
 	return field, cur, nil
 }
@@ -588,11 +661,8 @@ func (p *Parser) parseFieldArguments(tokens Tokens, cur int) ([]*Argument, int,
 }
 
 func (p *Parser) parseFieldArgument(tokens Tokens, cur int) (*Argument, int, error) {
-	if tokens[cur].Type == Dollar {
-		cur++
-	}
-
-	if tokens[cur].Type != Name {
+	start := cur
+	if !isNameToken(tokens[cur]) {
 		return nil, cur, fmt.Errorf("expected argument name")
 	}
 
@@ -606,29 +676,12 @@ func (p *Parser) parseFieldArgument(tokens Tokens, cur int) (*Argument, int, err
 	}
 	cur++
 
-	if tokens[cur].Type == Dollar {
-		cur++
-	}
-
-	fieldType, newCur, err := p.parseFieldType(tokens, cur, 0)
+	value, cur, err := p.parseValue(tokens, cur, false)
 	if err != nil {
-		return nil, newCur, err
-	}
-	cur = newCur
-	argument.Type = fieldType
-
-	if tokens[cur].Type == Equal {
-		cur++
-		if tokens[cur].Type != Value {
-			return nil, cur, fmt.Errorf("expected value after =")
-		}
-		argument.DefaultValue, cur, err = p.parseDefaultValue(tokens, cur)
-		if err != nil {
-			return nil, cur, err
-		}
-
-		cur++
+		return nil, cur, err
 	}
+	argument.Value = value
+	argument.Loc = newLoc(tokens, start, cur)
 
 	return argument, cur, nil
 }
@@ -662,6 +715,7 @@ func (p *Parser) parseOperationVariables(tokens Tokens, cur int) ([]*Variable, i
 }
 
 func (p *Parser) parseOperationVariable(tokens Tokens, cur int) (*Variable, int, error) {
+	start := cur
 	if tokens[cur].Type != Dollar {
 		return nil, cur, fmt.Errorf("expected $ before variable")
 	}
@@ -685,17 +739,10 @@ func (p *Parser) parseOperationVariable(tokens Tokens, cur int) (*Variable, int,
 	}
 	cur = newCur
 
-	var defaultValue []byte
+	var defaultValue InputValue
 	if tokens[cur].Type == Equal {
 		cur++
-		if tokens[cur].Type != Value &&
-			tokens[cur].Type != CurlyOpen &&
-			tokens[cur].Type != BracketOpen &&
-			tokens[cur].Type != Name {
-			return nil, cur, fmt.Errorf("expected default value but got %s(%s)", tokens[cur].Value, tokens[cur].Type)
-		}
-
-		defaultValue, cur, err = p.parseDefaultValue(tokens, cur)
+		defaultValue, cur, err = p.parseValue(tokens, cur, true)
 		if err != nil {
 			return nil, cur, err
 		}
@@ -705,10 +752,12 @@ func (p *Parser) parseOperationVariable(tokens Tokens, cur int) (*Variable, int,
 		Name:         variableName,
 		Type:         variableType,
 		DefaultValue: defaultValue,
+		Loc:          newLoc(tokens, start, cur),
 	}, cur, nil
 }
 
 func (p *Parser) parseFieldType(tokens Tokens, cur, nestedRank int) (*FieldType, int, error) {
+	start := cur
 	fieldType := &FieldType{
 		Nullable: true,
 	}
@@ -730,6 +779,8 @@ func (p *Parser) parseFieldType(tokens Tokens, cur, nestedRank int) (*FieldType,
 			fieldType.Nullable = false
 			cur++
 		}
+		fieldType.Loc = newLoc(tokens, start, cur)
+
 		return fieldType, cur, nil
 	}
 
@@ -744,101 +795,7 @@ func (p *Parser) parseFieldType(tokens Tokens, cur, nestedRank int) (*FieldType,
 		fieldType.Nullable = false
 		cur++
 	}
+	fieldType.Loc = newLoc(tokens, start, cur)
 
 	return fieldType, cur, nil
 }
-
-func (p *Parser) parseDefaultValue(tokens Tokens, cur int) ([]byte, int, error) {
-	if tokens[cur].Type != Value &&
-		tokens[cur].Type != CurlyOpen &&
-		tokens[cur].Type != BracketOpen &&
-		tokens[cur].Type != Name {
-		return nil, cur, fmt.Errorf("expected value")
-	}
-
-	if tokens[cur].Type == Value || tokens[cur].Type == Name {
-		return tokens[cur].Value, cur + 1, nil
-	}
-
-	if tokens[cur].Type == CurlyOpen {
-		return p.parseObjectValue(tokens, cur)
-	}
-
-	if tokens[cur].Type == BracketOpen {
-		return p.parseListValue(tokens, cur)
-	}
-
-	return nil, cur, fmt.Errorf("unexpected token")
-}
-
-func (p *Parser) parseObjectValue(tokens Tokens, cur int) ([]byte, int, error) {
-	objectValue := make([]byte, 0)
-
-	nested := 0
-	for {
-		if tokens[cur].Type == BracketOpen {
-			listValue, newCur, err := p.parseListValue(tokens, cur)
-			if err != nil {
-				return nil, newCur, err
-			}
-
-			objectValue = append(objectValue, listValue...)
-			cur = newCur
-			continue
-		}
-
-		objectValue = append(objectValue, tokens[cur].Value...)
-
-		if tokens[cur].Type == CurlyOpen {
-			nested++
-		}
-
-		if tokens[cur].Type == CurlyClose {
-			nested--
-		}
-
-		if nested == 0 {
-			break
-		}
-
-		cur++
-	}
-
-	return objectValue, cur + 1, nil
-}
-
-func (p *Parser) parseListValue(tokens Tokens, cur int) ([]byte, int, error) {
-	listValue := make([]byte, 0)
-
-	nested := 0
-	for {
-		if tokens[cur].Type == CurlyOpen {
-			objectValue, newCur, err := p.parseObjectValue(tokens, cur)
-			if err != nil {
-				return nil, newCur, err
-			}
-
-			listValue = append(listValue, objectValue...)
-			cur = newCur
-			continue
-		}
-
-		listValue = append(listValue, tokens[cur].Value...)
-
-		if tokens[cur].Type == BracketOpen {
-			nested++
-		}
-
-		if tokens[cur].Type == BracketClose {
-			nested--
-		}
-
-		if nested == 0 {
-			break
-		}
-
-		cur++
-	}
-
-	return listValue, cur + 1, nil
-}
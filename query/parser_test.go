@@ -1,6 +1,7 @@
 package query_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -67,7 +68,18 @@ func TestQueryParse(t *testing.T) {
 									Nullable: true,
 									IsList:   false,
 								},
-								DefaultValue: []byte(`{theme:"dark",notifications:true}`),
+								DefaultValue: &query.ObjectValue{
+									Fields: []*query.ObjectField{
+										{
+											Name:  []byte("theme"),
+											Value: &query.StringValue{Value: []byte("dark")},
+										},
+										{
+											Name:  []byte("notifications"),
+											Value: &query.BooleanValue{Value: true},
+										},
+									},
+								},
 							},
 						},
 					},
@@ -95,7 +107,37 @@ func TestQueryParse(t *testing.T) {
 										IsList:   false,
 									},
 								},
-								DefaultValue: []byte(`[{theme:"dark",notifications:true,options:{a:1,b:2}}]`),
+								DefaultValue: &query.ListValue{
+									Values: []query.InputValue{
+										&query.ObjectValue{
+											Fields: []*query.ObjectField{
+												{
+													Name:  []byte("theme"),
+													Value: &query.StringValue{Value: []byte("dark")},
+												},
+												{
+													Name:  []byte("notifications"),
+													Value: &query.BooleanValue{Value: true},
+												},
+												{
+													Name: []byte("options"),
+													Value: &query.ObjectValue{
+														Fields: []*query.ObjectField{
+															{
+																Name:  []byte("a"),
+																Value: &query.IntValue{Value: []byte("1")},
+															},
+															{
+																Name:  []byte("b"),
+																Value: &query.IntValue{Value: []byte("2")},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
 							},
 						},
 					},
@@ -119,7 +161,7 @@ func TestQueryParse(t *testing.T) {
 									Nullable: true,
 									IsList:   false,
 								},
-								DefaultValue: []byte("42"),
+								DefaultValue: &query.IntValue{Value: []byte("42")},
 							},
 							{
 								Name: []byte("name"),
@@ -128,7 +170,7 @@ func TestQueryParse(t *testing.T) {
 									Nullable: true,
 									IsList:   false,
 								},
-								DefaultValue: []byte(`"default"`),
+								DefaultValue: &query.StringValue{Value: []byte("default")},
 							},
 						},
 					},
@@ -182,7 +224,18 @@ func TestQueryParse(t *testing.T) {
 									Nullable: true,
 									IsList:   false,
 								},
-								DefaultValue: []byte(`{retries:3,timeout:30.5}`),
+								DefaultValue: &query.ObjectValue{
+									Fields: []*query.ObjectField{
+										{
+											Name:  []byte("retries"),
+											Value: &query.IntValue{Value: []byte("3")},
+										},
+										{
+											Name:  []byte("timeout"),
+											Value: &query.FloatValue{Value: []byte("30.5")},
+										},
+									},
+								},
 							},
 						},
 					},
@@ -205,7 +258,32 @@ func TestQueryParse(t *testing.T) {
 									Nullable: true,
 									IsList:   false,
 								},
-								DefaultValue: []byte(`{user:{id:1,settings:{theme:"light"}}}`),
+								DefaultValue: &query.ObjectValue{
+									Fields: []*query.ObjectField{
+										{
+											Name: []byte("user"),
+											Value: &query.ObjectValue{
+												Fields: []*query.ObjectField{
+													{
+														Name:  []byte("id"),
+														Value: &query.IntValue{Value: []byte("1")},
+													},
+													{
+														Name: []byte("settings"),
+														Value: &query.ObjectValue{
+															Fields: []*query.ObjectField{
+																{
+																	Name:  []byte("theme"),
+																	Value: &query.StringValue{Value: []byte("light")},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
 							},
 						},
 					},
@@ -228,7 +306,7 @@ func TestQueryParse(t *testing.T) {
 									Nullable: true,
 									IsList:   false,
 								},
-								DefaultValue: []byte("ACTIVE"),
+								DefaultValue: &query.EnumValue{Value: []byte("ACTIVE")},
 							},
 						},
 					},
@@ -424,7 +502,7 @@ func TestQueryParse(t *testing.T) {
 												Arguments: []*query.DirectiveArgument{
 													{
 														Name:  []byte("if"),
-														Value: []byte("true"),
+														Value: &query.BooleanValue{Value: true},
 													},
 												},
 											},
@@ -461,7 +539,7 @@ func TestQueryParse(t *testing.T) {
 												Arguments: []*query.DirectiveArgument{
 													{
 														Name:  []byte("if"),
-														Value: []byte("true"),
+														Value: &query.BooleanValue{Value: true},
 													},
 												},
 											},
@@ -493,7 +571,7 @@ func TestQueryParse(t *testing.T) {
 										Arguments: []*query.DirectiveArgument{
 											{
 												Name:  []byte("if"),
-												Value: []byte("true"),
+												Value: &query.BooleanValue{Value: true},
 											},
 										},
 									},
@@ -523,7 +601,7 @@ func TestQueryParse(t *testing.T) {
 										Arguments: []*query.DirectiveArgument{
 											{
 												Name:  []byte("if"),
-												Value: []byte("true"),
+												Value: &query.BooleanValue{Value: true},
 											},
 										},
 									},
@@ -556,15 +634,15 @@ func TestQueryParse(t *testing.T) {
 								Name: []byte("post"),
 								Selections: []query.Selection{
 									&query.Field{
-										Name: []byte("id"),
+										Name:       []byte("id"),
 										Directives: nil,
 									},
 									&query.Field{
-										Name: []byte("title"),
+										Name:       []byte("title"),
 										Directives: nil,
 									},
 									&query.Field{
-										Name: []byte("content"),
+										Name:       []byte("content"),
 										Directives: nil,
 									},
 									&query.Field{
@@ -580,8 +658,7 @@ func TestQueryParse(t *testing.T) {
 												Arguments: []*query.DirectiveArgument{
 													{
 														Name:  []byte("if"),
-														Value: []byte("true"),
-														IsVariable: false,
+														Value: &query.BooleanValue{Value: true},
 													},
 												},
 											},
@@ -620,7 +697,7 @@ func TestQueryParse(t *testing.T) {
 												Arguments: []*query.DirectiveArgument{
 													{
 														Name:  []byte("reason"),
-														Value: []byte(`"Use newField"`),
+														Value: &query.StringValue{Value: []byte("Use newField")},
 													},
 												},
 											},
@@ -638,6 +715,114 @@ func TestQueryParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Parse inline fragment without type condition with variable directive",
+			input: []byte(`query MyQuery($withName: Boolean!) {
+				user {
+					... @include(if: $withName) {
+						name
+					}
+					id
+				}
+			}`),
+			expected: &query.Document{
+				Operations: []*query.Operation{
+					{
+						OperationType: query.QueryOperation,
+						Name:          "MyQuery",
+						Variables: []*query.Variable{
+							{
+								Name: []byte("withName"),
+								Type: &query.FieldType{
+									Name:     []byte("Boolean"),
+									Nullable: false,
+								},
+							},
+						},
+						Selections: []query.Selection{
+							&query.Field{
+								Name: []byte("user"),
+								Selections: []query.Selection{
+									&query.InlineFragment{
+										Directives: []*query.Directive{
+											{
+												Name: []byte("include"),
+												Arguments: []*query.DirectiveArgument{
+													{
+														Name:  []byte("if"),
+														Value: &query.VariableValue{Name: []byte("withName")},
+													},
+												},
+											},
+										},
+										Selections: []query.Selection{
+											&query.Field{
+												Name: []byte("name"),
+											},
+										},
+									},
+									&query.Field{
+										Name: []byte("id"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Parse query with field aliases",
+			input: []byte(`query MyQuery {
+				first: post(id: 1) {
+					postId: id
+				}
+				second: post(id: 2) {
+					id
+				}
+			}`),
+			expected: &query.Document{
+				Operations: []*query.Operation{
+					{
+						OperationType: query.QueryOperation,
+						Name:          "MyQuery",
+						Selections: []query.Selection{
+							&query.Field{
+								Alias: []byte("first"),
+								Name:  []byte("post"),
+								Arguments: []*query.Argument{
+									{
+										Name:  []byte("id"),
+										Value: &query.IntValue{Value: []byte("1")},
+									},
+								},
+								Selections: []query.Selection{
+									&query.Field{
+										Alias: []byte("postId"),
+										Name:  []byte("id"),
+									},
+								},
+							},
+							&query.Field{
+								Alias: []byte("second"),
+								Name:  []byte("post"),
+								Arguments: []*query.Argument{
+									{
+										Name:  []byte("id"),
+										Value: &query.IntValue{Value: []byte("2")},
+									},
+								},
+								Selections: []query.Selection{
+									&query.Field{
+										Name: []byte("id"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Parse query operation with simple directive",
 			input: []byte(`query MyQuery @deprecated {
@@ -678,7 +863,7 @@ func TestQueryParse(t *testing.T) {
 								Arguments: []*query.DirectiveArgument{
 									{
 										Name:  []byte("if"),
-										Value: []byte("true"),
+										Value: &query.BooleanValue{Value: true},
 									},
 								},
 							},
@@ -708,7 +893,7 @@ func TestQueryParse(t *testing.T) {
 								Arguments: []*query.DirectiveArgument{
 									{
 										Name:  []byte("if"),
-										Value: []byte("true"),
+										Value: &query.BooleanValue{Value: true},
 									},
 								},
 							},
@@ -739,8 +924,24 @@ func TestQueryParse(t *testing.T) {
 								Name: []byte("settings"),
 								Arguments: []*query.DirectiveArgument{
 									{
-										Name:  []byte("config"),
-										Value: []byte(`{theme:"dark",features:["a","b"]}`),
+										Name: []byte("config"),
+										Value: &query.ObjectValue{
+											Fields: []*query.ObjectField{
+												{
+													Name:  []byte("theme"),
+													Value: &query.StringValue{Value: []byte("dark")},
+												},
+												{
+													Name: []byte("features"),
+													Value: &query.ListValue{
+														Values: []query.InputValue{
+															&query.StringValue{Value: []byte("a")},
+															&query.StringValue{Value: []byte("b")},
+														},
+													},
+												},
+											},
+										},
 									},
 								},
 							},
@@ -754,10 +955,100 @@ func TestQueryParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Parse query with literal and variable field arguments",
+			input: []byte(`query ($includeDeprecated: Boolean) {
+				__type(name: "Post") {
+					fields(includeDeprecated: $includeDeprecated) {
+						name
+					}
+				}
+			}`),
+			expected: &query.Document{
+				Operations: []*query.Operation{
+					{
+						OperationType: query.QueryOperation,
+						Variables: []*query.Variable{
+							{
+								Name: []byte("includeDeprecated"),
+								Type: &query.FieldType{
+									Name:     []byte("Boolean"),
+									Nullable: true,
+								},
+							},
+						},
+						Selections: []query.Selection{
+							&query.Field{
+								Name: []byte("__type"),
+								Arguments: []*query.Argument{
+									{
+										Name:  []byte("name"),
+										Value: &query.StringValue{Value: []byte("Post")},
+									},
+								},
+								Selections: []query.Selection{
+									&query.Field{
+										Name: []byte("fields"),
+										Arguments: []*query.Argument{
+											{
+												Name:  []byte("includeDeprecated"),
+												Value: &query.VariableValue{Name: []byte("includeDeprecated")},
+											},
+										},
+										Selections: []query.Selection{
+											&query.Field{
+												Name: []byte("name"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "Parse shorthand query after a comment and a fragment definition",
+			input: []byte("# fetch posts\nfragment PostFields on Post {\n  id\n}\n{\n  posts {\n    ...PostFields # the fields\n  }\n}"),
+			expected: &query.Document{
+				Operations: []*query.Operation{
+					{
+						OperationType: query.QueryOperation,
+						Selections: []query.Selection{
+							&query.Field{
+								Name: []byte("posts"),
+								Selections: []query.Selection{
+									&query.FragmentSpread{
+										Name: []byte("PostFields"),
+									},
+								},
+							},
+						},
+					},
+				},
+				FragmentDefinitions: query.FragmentDefinitions{
+					{
+						Name:          []byte("PostFields"),
+						BasedTypeName: []byte("Post"),
+						Selections: []query.Selection{
+							&query.Field{
+								Name: []byte("id"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "Parse unexpected definition",
+			input:   []byte(`type Post { id }`),
+			wantErr: errors.New("unexpected type at line 1, column 1"),
+		},
 	}
 
 	opts := cmp.FilterPath(func(p cmp.Path) bool {
-		return p.Last().String() == ".tokens" || p.Last().String() == ".isVariable"
+		return p.Last().String() == ".tokens" || p.Last().String() == ".isVariable" || p.Last().String() == ".Loc"
 	}, cmp.Ignore())
 
 	for _, tt := range tests {
@@ -781,3 +1072,139 @@ func TestQueryParse(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryParse_Loc(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		loc      func(doc *query.Document) *query.Loc
+		expected *query.Loc
+	}{
+		{
+			name:  "Operation spans from its keyword to its closing brace",
+			input: []byte("query GetUser {\n  user {\n    id\n  }\n}"),
+			loc: func(doc *query.Document) *query.Loc {
+				return doc.Operations[0].Loc
+			},
+			expected: &query.Loc{
+				Start: query.Position{Line: 1, Column: 1, Offset: 0},
+				End:   query.Position{Line: 5, Column: 2, Offset: 37},
+			},
+		},
+		{
+			name:  "Field spans its alias, arguments and selections",
+			input: []byte("query {\n  me: user(id: \"1\") {\n    id\n  }\n}"),
+			loc: func(doc *query.Document) *query.Loc {
+				return doc.Operations[0].Selections[0].GetLoc()
+			},
+			expected: &query.Loc{
+				Start: query.Position{Line: 2, Column: 3, Offset: 10},
+				End:   query.Position{Line: 4, Column: 4, Offset: 40},
+			},
+		},
+		{
+			name:  "Nested field after a block string argument",
+			input: []byte("query {\n  search(text: \"\"\"a\nb\"\"\") {\n    id\n  }\n}"),
+			loc: func(doc *query.Document) *query.Loc {
+				return doc.Operations[0].Selections[0].(*query.Field).Selections[0].GetLoc()
+			},
+			expected: &query.Loc{
+				Start: query.Position{Line: 4, Column: 5, Offset: 40},
+				End:   query.Position{Line: 4, Column: 7, Offset: 42},
+			},
+		},
+		{
+			name:  "Fragment spread starts at its spread operator",
+			input: []byte("query {\n  ...UserFields\n}\nfragment UserFields on User {\n  id\n}"),
+			loc: func(doc *query.Document) *query.Loc {
+				return doc.Operations[0].Selections[0].GetLoc()
+			},
+			expected: &query.Loc{
+				Start: query.Position{Line: 2, Column: 3, Offset: 10},
+				End:   query.Position{Line: 2, Column: 16, Offset: 23},
+			},
+		},
+		{
+			name:  "Variable spans its default value",
+			input: []byte("query ($id: ID = 1) {\n  user(id: $id) {\n    id\n  }\n}"),
+			loc: func(doc *query.Document) *query.Loc {
+				return doc.Operations[0].Variables[0].Loc
+			},
+			expected: &query.Loc{
+				Start: query.Position{Line: 1, Column: 8, Offset: 7},
+				End:   query.Position{Line: 1, Column: 19, Offset: 18},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexer := query.NewLexer()
+			parser := query.NewParser(lexer)
+			got, err := parser.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse() error %v", err)
+			}
+
+			if diff := cmp.Diff(tt.loc(got), tt.expected); diff != "" {
+				t.Errorf("Parse() location mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOperations_GetOperation(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         []byte
+		operationName string
+		want          string
+		wantErr       error
+	}{
+		{
+			name:  "Only operation is selected without operation name",
+			input: []byte(`{ posts { id } }`),
+			want:  "",
+		},
+		{
+			name:          "Operation is selected by its name",
+			input:         []byte(`query GetPosts { posts { id } } mutation CreatePost { createPost { id } }`),
+			operationName: "CreatePost",
+			want:          "CreatePost",
+		},
+		{
+			name:    "Operation name is required for several operations",
+			input:   []byte(`query GetPosts { posts { id } } mutation CreatePost { createPost { id } }`),
+			wantErr: query.ErrOperationNameRequired,
+		},
+		{
+			name:          "Unknown operation name",
+			input:         []byte(`query GetPosts { posts { id } }`),
+			operationName: "GetUsers",
+			wantErr:       query.ErrUnknownOperation,
+		},
+		{
+			name:    "Document without operations",
+			input:   []byte(`fragment PostFields on Post { id }`),
+			wantErr: query.ErrNoOperation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := query.NewParserWithLexer().Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse() error %v", err)
+			}
+
+			got, err := doc.Operations.GetOperation(tt.operationName)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetOperation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && got.Name != tt.want {
+				t.Errorf("GetOperation() = %s, want %s", got.Name, tt.want)
+			}
+		})
+	}
+}
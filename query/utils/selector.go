@@ -1,9 +1,6 @@
 package utils
 
 import (
-	"encoding/json"
-	"fmt"
-
 	"github.com/n9te9/goliteql/query"
 )
 
@@ -47,47 +44,12 @@ func ExtractSelectorArgs(op *query.Operation, operationName string) []*query.Arg
 	return nil
 }
 
-func ExtractExecuteSelector(op *query.Operation, operationName string) []query.Selection {
+// ExtractExecuteSelector returns the root selections of op, which are all executed and merged into one response.
+// Root fields selected through fragments are found by the planner after expanding them.
+func ExtractExecuteSelector(op *query.Operation) []query.Selection {
 	if op == nil {
 		return nil
 	}
 
-	for _, sel := range op.Selections {
-		switch s := sel.(type) {
-		case *query.Field:
-			if string(s.Name) == operationName {
-				return []query.Selection{s}
-			}
-		}
-	}
-
-	if len(op.Selections) == 1 {
-		switch s := op.Selections[0].(type) {
-		case *query.Field:
-			return []query.Selection{s}
-		}
-	}
-
-	return nil
-}
-
-func ConvRequestBodyFromVariables(variables json.RawMessage, args []*query.Argument) ([]byte, error) {
-	if len(args) == 0 {
-		return nil, nil
-	}
-
-	mp := make(map[string]json.RawMessage)
-
-	if err := json.Unmarshal(variables, &mp); err != nil {
-		return nil, err
-	}
-
-	for i, arg := range args {
-		if _, ok := mp[string(arg.Name)]; ok {
-			mp[fmt.Sprintf("arg%d", i)] = mp[string(arg.Name)]
-			delete(mp, string(arg.Name))
-		}
-	}
-
-	return json.Marshal(mp)
+	return op.Selections
 }
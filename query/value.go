@@ -0,0 +1,432 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// InputValue is a value in a query or a schema, as described in "Input Values" of the specification.
+// String returns the value as it's written in GraphQL, and MarshalJSON encodes it as JSON,
+// where enum values are strings and variables can't be encoded.
+type InputValue interface {
+	isValue()
+	GetLoc() *Loc
+	String() string
+	MarshalJSON() ([]byte, error)
+}
+
+type IntValue struct {
+	Value []byte
+	Loc   *Loc
+}
+
+type FloatValue struct {
+	Value []byte
+	Loc   *Loc
+}
+
+// StringValue is a string or a block string, whose Value is the string after its escape sequences,
+// or the common indentation of a block string, are processed.
+type StringValue struct {
+	Value []byte
+	Block bool
+	Loc   *Loc
+}
+
+type BooleanValue struct {
+	Value bool
+	Loc   *Loc
+}
+
+type NullValue struct {
+	Loc *Loc
+}
+
+type EnumValue struct {
+	Value []byte
+	Loc   *Loc
+}
+
+type ListValue struct {
+	Values []InputValue
+	Loc    *Loc
+}
+
+type ObjectValue struct {
+	Fields []*ObjectField
+	Loc    *Loc
+}
+
+type ObjectField struct {
+	Name  []byte
+	Value InputValue
+	Loc   *Loc
+}
+
+// VariableValue is a variable used as a value, whose value is given with the request.
+type VariableValue struct {
+	Name []byte
+	Loc  *Loc
+}
+
+func (v *IntValue) isValue()      {}
+func (v *FloatValue) isValue()    {}
+func (v *StringValue) isValue()   {}
+func (v *BooleanValue) isValue()  {}
+func (v *NullValue) isValue()     {}
+func (v *EnumValue) isValue()     {}
+func (v *ListValue) isValue()     {}
+func (v *ObjectValue) isValue()   {}
+func (v *VariableValue) isValue() {}
+
+func (v *IntValue) GetLoc() *Loc      { return v.Loc }
+func (v *FloatValue) GetLoc() *Loc    { return v.Loc }
+func (v *StringValue) GetLoc() *Loc   { return v.Loc }
+func (v *BooleanValue) GetLoc() *Loc  { return v.Loc }
+func (v *NullValue) GetLoc() *Loc     { return v.Loc }
+func (v *EnumValue) GetLoc() *Loc     { return v.Loc }
+func (v *ListValue) GetLoc() *Loc     { return v.Loc }
+func (v *ObjectValue) GetLoc() *Loc   { return v.Loc }
+func (v *VariableValue) GetLoc() *Loc { return v.Loc }
+
+func (v *IntValue) String() string   { return string(v.Value) }
+func (v *FloatValue) String() string { return string(v.Value) }
+func (v *StringValue) String() string {
+	b, _ := v.MarshalJSON()
+	return string(b)
+}
+func (v *BooleanValue) String() string {
+	if v.Value {
+		return "true"
+	}
+
+	return "false"
+}
+func (v *NullValue) String() string     { return "null" }
+func (v *EnumValue) String() string     { return string(v.Value) }
+func (v *VariableValue) String() string { return "$" + string(v.Name) }
+
+func (v *ListValue) String() string {
+	values := make([]string, len(v.Values))
+	for i, value := range v.Values {
+		values[i] = value.String()
+	}
+
+	return "[" + strings.Join(values, ", ") + "]"
+}
+
+func (v *ObjectValue) String() string {
+	fields := make([]string, len(v.Fields))
+	for i, f := range v.Fields {
+		fields[i] = string(f.Name) + ": " + f.Value.String()
+	}
+
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+func (v *IntValue) MarshalJSON() ([]byte, error)   { return v.Value, nil }
+func (v *FloatValue) MarshalJSON() ([]byte, error) { return v.Value, nil }
+
+func (v *StringValue) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(string(v.Value)); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func (v *BooleanValue) MarshalJSON() ([]byte, error) { return []byte(v.String()), nil }
+func (v *NullValue) MarshalJSON() ([]byte, error)    { return []byte("null"), nil }
+func (v *EnumValue) MarshalJSON() ([]byte, error)    { return json.Marshal(string(v.Value)) }
+
+func (v *ListValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Values)
+}
+
+func (v *ObjectValue) MarshalJSON() ([]byte, error) {
+	res := []byte{'{'}
+	for i, f := range v.Fields {
+		if i > 0 {
+			res = append(res, ',')
+		}
+
+		name, err := json.Marshal(string(f.Name))
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := f.Value.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, name...)
+		res = append(res, ':')
+		res = append(res, value...)
+	}
+
+	return append(res, '}'), nil
+}
+
+func (v *VariableValue) MarshalJSON() ([]byte, error) {
+	return nil, fmt.Errorf("variable $%s doesn't have a value", v.Name)
+}
+
+// Get returns the value of the field named name, or nil if the object doesn't have the field.
+func (v *ObjectValue) Get(name []byte) InputValue {
+	for _, f := range v.Fields {
+		if bytes.Equal(f.Name, name) {
+			return f.Value
+		}
+	}
+
+	return nil
+}
+
+// Variables returns the variables used in v, including the variables in its lists and objects.
+func Variables(v InputValue) []*VariableValue {
+	switch v := v.(type) {
+	case *VariableValue:
+		return []*VariableValue{v}
+	case *ListValue:
+		var res []*VariableValue
+		for _, value := range v.Values {
+			res = append(res, Variables(value)...)
+		}
+
+		return res
+	case *ObjectValue:
+		var res []*VariableValue
+		for _, f := range v.Fields {
+			res = append(res, Variables(f.Value)...)
+		}
+
+		return res
+	}
+
+	return nil
+}
+
+// ParseValue parses input as a value without variables, such as a default value in a schema.
+// The locations of the value are relative to the start of input.
+func (p *Parser) ParseValue(input []byte) (InputValue, error) {
+	tokens, err := p.Lexer.lex(input, true)
+	if err != nil {
+		return nil, err
+	}
+
+	value, cur, err := p.parseValue(tokens, 0, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if tokens[cur].Type != EOF {
+		return nil, fmt.Errorf("unexpected %s after value at line %d, column %d", tokens[cur].Value, tokens[cur].Line, tokens[cur].Column)
+	}
+
+	return value, nil
+}
+
+// ParseValue parses input as a value without variables with a new parser.
+func ParseValue(input []byte) (InputValue, error) {
+	return NewParserWithLexer().ParseValue(input)
+}
+
+// isNameToken reports whether t is a name, including the keywords which are names in values.
+func isNameToken(t *Token) bool {
+	switch t.Type {
+	case Name, Query, Mutation, Subscription, On, Fragment:
+		return true
+	}
+
+	return false
+}
+
+// parseValue parses the value starting at cur, which can't have variables if constant is true.
+func (p *Parser) parseValue(tokens Tokens, cur int, constant bool) (InputValue, int, error) {
+	start := cur
+	switch {
+	case tokens[cur].Type == Dollar:
+		if constant {
+			return nil, cur, fmt.Errorf("unexpected variable in constant value at line %d, column %d", tokens[cur].Line, tokens[cur].Column)
+		}
+		cur++
+
+		if tokens[cur].Type != Name {
+			return nil, cur, fmt.Errorf("expected variable name after $")
+		}
+
+		return &VariableValue{Name: tokens[cur].Value, Loc: newLoc(tokens, start, cur+1)}, cur + 1, nil
+	case tokens[cur].Type == Value:
+		value, err := stringValue(tokens[cur].Value)
+		if err != nil {
+			return nil, cur, fmt.Errorf("%w at line %d, column %d", err, tokens[cur].Line, tokens[cur].Column)
+		}
+		value.Loc = newLoc(tokens, start, cur+1)
+
+		return value, cur + 1, nil
+	case tokens[cur].Type == BracketOpen:
+		return p.parseListValue(tokens, cur, constant)
+	case tokens[cur].Type == CurlyOpen:
+		return p.parseObjectValue(tokens, cur, constant)
+	case isNameToken(tokens[cur]):
+		value, err := scalarValue(tokens[cur].Value)
+		if err != nil {
+			return nil, cur, fmt.Errorf("%w at line %d, column %d", err, tokens[cur].Line, tokens[cur].Column)
+		}
+
+		return value(newLoc(tokens, start, cur+1)), cur + 1, nil
+	}
+
+	return nil, cur, fmt.Errorf("expected value but got %s at line %d, column %d", tokens[cur].Value, tokens[cur].Line, tokens[cur].Column)
+}
+
+func (p *Parser) parseListValue(tokens Tokens, cur int, constant bool) (*ListValue, int, error) {
+	start := cur
+	cur++
+
+	list := &ListValue{Values: make([]InputValue, 0)}
+	for tokens[cur].Type != BracketClose {
+		if tokens[cur].Type == Comma {
+			cur++
+			continue
+		}
+
+		value, newCur, err := p.parseValue(tokens, cur, constant)
+		if err != nil {
+			return nil, newCur, err
+		}
+		cur = newCur
+		list.Values = append(list.Values, value)
+	}
+	cur++
+	list.Loc = newLoc(tokens, start, cur)
+
+	return list, cur, nil
+}
+
+func (p *Parser) parseObjectValue(tokens Tokens, cur int, constant bool) (*ObjectValue, int, error) {
+	start := cur
+	cur++
+
+	object := &ObjectValue{Fields: make([]*ObjectField, 0)}
+	for tokens[cur].Type != CurlyClose {
+		if tokens[cur].Type == Comma {
+			cur++
+			continue
+		}
+
+		fieldStart := cur
+		if !isNameToken(tokens[cur]) {
+			return nil, cur, fmt.Errorf("expected object field name but got %s at line %d, column %d", tokens[cur].Value, tokens[cur].Line, tokens[cur].Column)
+		}
+		name := tokens[cur].Value
+		cur++
+
+		if tokens[cur].Type != Colon {
+			return nil, cur, fmt.Errorf("expected : after object field %s", name)
+		}
+		cur++
+
+		value, newCur, err := p.parseValue(tokens, cur, constant)
+		if err != nil {
+			return nil, newCur, err
+		}
+		cur = newCur
+
+		object.Fields = append(object.Fields, &ObjectField{
+			Name:  name,
+			Value: value,
+			Loc:   newLoc(tokens, fieldStart, cur),
+		})
+	}
+	cur++
+	object.Loc = newLoc(tokens, start, cur)
+
+	return object, cur, nil
+}
+
+// scalarValue returns the constructor of the value written as name, which is a number, a boolean, null or an enum value.
+func scalarValue(name []byte) (func(loc *Loc) InputValue, error) {
+	switch string(name) {
+	case "true", "false":
+		return func(loc *Loc) InputValue { return &BooleanValue{Value: string(name) == "true", Loc: loc} }, nil
+	case "null":
+		return func(loc *Loc) InputValue { return &NullValue{Loc: loc} }, nil
+	}
+
+	if name[0] != '-' && (name[0] < '0' || name[0] > '9') {
+		return func(loc *Loc) InputValue { return &EnumValue{Value: name, Loc: loc} }, nil
+	}
+
+	if !json.Valid(name) {
+		return nil, fmt.Errorf("invalid number %s", name)
+	}
+
+	if bytes.ContainsAny(name, ".eE") {
+		return func(loc *Loc) InputValue { return &FloatValue{Value: name, Loc: loc} }, nil
+	}
+
+	return func(loc *Loc) InputValue { return &IntValue{Value: name, Loc: loc} }, nil
+}
+
+// stringValue returns the string or the block string written as raw, with its quotes.
+func stringValue(raw []byte) (*StringValue, error) {
+	if bytes.HasPrefix(raw, []byte(`"""`)) {
+		return &StringValue{
+			Value: []byte(blockStringValue(string(raw[3 : len(raw)-3]))),
+			Block: true,
+		}, nil
+	}
+
+	// the escape sequences of strings are those of JSON strings
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, fmt.Errorf("invalid string %s", raw)
+	}
+
+	return &StringValue{Value: []byte(s)}, nil
+}
+
+// blockStringValue removes the common indentation and the blank leading and trailing lines of raw,
+// as described in "BlockStringValue" of the specification.
+func blockStringValue(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+
+		if n := len(line) - len(trimmed); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+
+	if indent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= indent {
+				lines[i] = lines[i][indent:]
+			} else {
+				lines[i] = ""
+			}
+		}
+	}
+
+	for len(lines) > 0 && strings.TrimLeft(lines[0], " \t") == "" {
+		lines = lines[1:]
+	}
+
+	for len(lines) > 0 && strings.TrimLeft(lines[len(lines)-1], " \t") == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return strings.ReplaceAll(strings.Join(lines, "\n"), `\"""`, `"""`)
+}
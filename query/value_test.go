@@ -0,0 +1,131 @@
+package query_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/query"
+)
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expected query.InputValue
+		wantErr  error
+	}{
+		{
+			name:     "Parse negative int value",
+			input:    []byte(`-10`),
+			expected: &query.IntValue{Value: []byte("-10")},
+		},
+		{
+			name:     "Parse float value with exponent",
+			input:    []byte(`1.5e-3`),
+			expected: &query.FloatValue{Value: []byte("1.5e-3")},
+		},
+		{
+			name:     "Parse string value with escape sequences",
+			input:    []byte(`"a\"bé"`),
+			expected: &query.StringValue{Value: []byte(`a"bé`)},
+		},
+		{
+			name: "Parse block string value",
+			input: []byte(`"""
+    Hello,
+      "World"
+    """`),
+			expected: &query.StringValue{Value: []byte("Hello,\n  \"World\""), Block: true},
+		},
+		{
+			name:     "Parse boolean, null and enum values in a list",
+			input:    []byte(`[true, null, PUBLISHED]`),
+			expected: &query.ListValue{Values: []query.InputValue{&query.BooleanValue{Value: true}, &query.NullValue{}, &query.EnumValue{Value: []byte("PUBLISHED")}}},
+		},
+		{
+			name:  "Parse object value with keyword field names",
+			input: []byte(`{query: "q", type: [1 2]}`),
+			expected: &query.ObjectValue{
+				Fields: []*query.ObjectField{
+					{Name: []byte("query"), Value: &query.StringValue{Value: []byte("q")}},
+					{Name: []byte("type"), Value: &query.ListValue{Values: []query.InputValue{&query.IntValue{Value: []byte("1")}, &query.IntValue{Value: []byte("2")}}}},
+				},
+			},
+		},
+		{
+			name:    "Parse value with variable",
+			input:   []byte(`{id: $id}`),
+			wantErr: errors.New("unexpected variable in constant value at line 1, column 6"),
+		},
+	}
+
+	opts := cmp.FilterPath(func(p cmp.Path) bool {
+		return p.Last().String() == ".Loc"
+	}, cmp.Ignore())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := query.ParseValue(tt.input)
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Errorf("ParseValue() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseValue() error %v", err)
+			}
+
+			if diff := cmp.Diff(got, tt.expected, opts); diff != "" {
+				t.Errorf("ParseValue() mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInputValue_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		wantStr  string
+		wantJSON string
+	}{
+		{
+			name:     "Scalar values",
+			input:    []byte(`[1, -2.5, "a\nb", false, null]`),
+			wantStr:  `[1, -2.5, "a\nb", false, null]`,
+			wantJSON: `[1,-2.5,"a\nb",false,null]`,
+		},
+		{
+			name:     "Enum values are strings in JSON",
+			input:    []byte(`{status: PUBLISHED, tags: ["x"]}`),
+			wantStr:  `{status: PUBLISHED, tags: ["x"]}`,
+			wantJSON: `{"status":"PUBLISHED","tags":["x"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := query.ParseValue(tt.input)
+			if err != nil {
+				t.Fatalf("ParseValue() error %v", err)
+			}
+
+			if diff := cmp.Diff(v.String(), tt.wantStr); diff != "" {
+				t.Errorf("String() mismatch (-got +want):\n%s", diff)
+			}
+
+			got, err := json.Marshal(v)
+			if err != nil {
+				t.Fatalf("MarshalJSON() error %v", err)
+			}
+
+			if diff := cmp.Diff(string(got), tt.wantJSON); diff != "" {
+				t.Errorf("MarshalJSON() mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
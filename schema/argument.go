@@ -1,61 +1,220 @@
 package schema
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
-)
 
+	"github.com/n9te9/goliteql/query"
+)
 
-var typesValidator = map[string]func([]byte) error{
-	"Int": func(value []byte) error {
-		if _, err := strconv.Atoi(string(value)); err != nil {
+// builtinScalarValidators validates the literal values of the built-in scalars, which can't be overridden by custom scalars.
+var builtinScalarValidators = map[string]func(query.InputValue) error{
+	"Int": func(value query.InputValue) error {
+		v, ok := value.(*query.IntValue)
+		if !ok {
 			return fmt.Errorf("expected integer value, got %s", value)
 		}
 
+		if _, err := strconv.ParseInt(string(v.Value), 10, 32); err != nil {
+			return fmt.Errorf("expected 32-bit signed integer value, got %s", value)
+		}
+
 		return nil
 	},
-	"Float": func(value []byte) error {
-		if _, err := strconv.ParseFloat(string(value), 64); err != nil {
-			return fmt.Errorf("expected float value, got %s", value)
+	"Float": func(value query.InputValue) error {
+		switch value.(type) {
+		case *query.IntValue, *query.FloatValue:
+			return nil
 		}
 
-		return nil
+		return fmt.Errorf("expected float value, got %s", value)
 	},
-	"String": func(value []byte) error {
-		if value[0] != '"' || value[len(value)-1] != '"' {
+	"String": func(value query.InputValue) error {
+		if _, ok := value.(*query.StringValue); !ok {
 			return fmt.Errorf("expected String but got %s", value)
 		}
 		return nil
 	},
-	"Boolean": func(value []byte) error {
-		if _, err := strconv.ParseBool(string(value)); err != nil {
+	"Boolean": func(value query.InputValue) error {
+		if _, ok := value.(*query.BooleanValue); !ok {
 			return fmt.Errorf("expected boolean value, got %s", value)
 		}
 
 		return nil
 	},
-	"ID": func(value []byte) error {
-		if _, err := strconv.Atoi(string(value)); err != nil || value[0] != '"' || value[len(value)-1] != '"' {
-			return fmt.Errorf("expected ID but got %s", value)
+	"ID": func(value query.InputValue) error {
+		switch value.(type) {
+		case *query.IntValue, *query.StringValue:
+			return nil
 		}
-		return nil
+
+		return fmt.Errorf("expected ID but got %s", value)
 	},
 }
 
+// RegisterScalar maps the custom scalar named name of s to the Go type T,
+// so that literal values and variable values of the scalar are validated by decoding them into T as JSON.
+// T is expected to implement json.Unmarshaler when its JSON encoding isn't the default one.
+// Generated models register every custom scalar in their RegisterScalars function, which must be called before s is used.
+func RegisterScalar[T any](s *Schema, name string) error {
+	if _, ok := builtinScalarValidators[name]; ok {
+		return fmt.Errorf("error registering scalar %s: built-in scalars can't be overridden", name)
+	}
+
+	if s.scalarValidators == nil {
+		s.scalarValidators = make(map[string]func(json.RawMessage) error)
+	}
+
+	s.scalarValidators[name] = func(value json.RawMessage) error {
+		var v T
+		return json.Unmarshal(value, &v)
+	}
+
+	return nil
+}
+
+// ValidateScalarValue validates value, a JSON value such as the value of a variable, against the custom scalar named name,
+// by decoding it into the Go type registered with RegisterScalar. Values of unregistered scalars are accepted as they are.
+func (s *Schema) ValidateScalarValue(name string, value json.RawMessage) error {
+	validator, ok := s.scalarValidators[name]
+	if !ok {
+		return nil
+	}
+
+	if err := validator(value); err != nil {
+		return fmt.Errorf("expected %s value, got %s", name, value)
+	}
+
+	return nil
+}
+
+// scalarValidator returns the validator of the literal values of the scalar named name in s, or nil if it doesn't have one.
+func (s *Schema) scalarValidator(name string) func(query.InputValue) error {
+	if validator, ok := builtinScalarValidators[name]; ok {
+		return validator
+	}
+
+	if _, ok := s.scalarValidators[name]; !ok {
+		return nil
+	}
+
+	return func(value query.InputValue) error {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("expected %s value, got %s", name, value)
+		}
+
+		if err := s.ValidateScalarValue(name, b); err != nil {
+			return fmt.Errorf("expected %s value, got %s", name, value)
+		}
+
+		return nil
+	}
+}
+
 type ArgumentDefinition struct {
 	Name []byte
-	Default []byte
+	Description []byte
+	Default query.InputValue
 	Type *FieldType
+	Loc *Loc
 }
 
-func (a *ArgumentDefinition) ValidateValueType(value []byte) error {
-	if err := typesValidator[string(a.Type.Name)](value); err != nil {
+// ValidateValueType validates value, a literal in a query, against the type of the argument in s.
+// Variables are accepted, as their values are validated against the types of the variables.
+// Lists, input objects and their fields are validated recursively as described in "Input Coercion" of the specification.
+// Values of enum types must be one of the values of the enum, values of custom scalars are
+// validated by the Go type registered with RegisterScalar, and values of unregistered scalars are accepted as they are.
+func (a *ArgumentDefinition) ValidateValueType(s *Schema, value query.InputValue) error {
+	if value == nil {
+		return nil
+	}
+
+	if err := s.ValidateInputValue(a.Type, value); err != nil {
 		return fmt.Errorf("error validating value for argument %s: %w", a.Name, err)
 	}
 
 	return nil
 }
 
+// ValidateInputValue validates value, a literal in a query such as the default value of a variable, against the input type t in s,
+// as ValidateValueType validates the values of arguments.
+func (s *Schema) ValidateInputValue(t *FieldType, value query.InputValue) error {
+	switch value.(type) {
+	case *query.VariableValue:
+		return nil
+	case *query.NullValue:
+		if !t.Nullable {
+			return fmt.Errorf("expected %s value, got null", t)
+		}
+
+		return nil
+	}
+
+	if t.IsList {
+		list, ok := value.(*query.ListValue)
+		if !ok {
+			// A single value is coerced to a list of the value.
+			return s.ValidateInputValue(t.ListType, value)
+		}
+
+		for i, item := range list.Values {
+			if err := s.ValidateInputValue(t.ListType, item); err != nil {
+				return fmt.Errorf("error validating item %d: %w", i, err)
+			}
+		}
+
+		return nil
+	}
+
+	if input := s.Indexes.InputIndex[string(t.Name)]; input != nil {
+		return s.validateInputObject(input, value)
+	}
+
+	if enum := EnumDefinitions(s.Enums).Get(string(t.Name)); enum != nil {
+		if v, ok := value.(*query.EnumValue); !ok || !enum.HasValue(string(v.Value)) {
+			return fmt.Errorf("expected %s value, got %s", enum.Name, value)
+		}
+
+		return nil
+	}
+
+	if validator := s.scalarValidator(string(t.Name)); validator != nil {
+		return validator(value)
+	}
+
+	return nil
+}
+
+// validateInputObject validates value, a literal in a query, against the input object type input in s.
+// Every field of value must be defined by input, and the non-null fields of input without a default value must be given.
+func (s *Schema) validateInputObject(input *InputDefinition, value query.InputValue) error {
+	obj, ok := value.(*query.ObjectValue)
+	if !ok {
+		return fmt.Errorf("expected an object of input type %s, got %s", input.Name, value)
+	}
+
+	for _, f := range obj.Fields {
+		def := input.Fields.Last(string(f.Name))
+		if def == nil {
+			return fmt.Errorf("field %s is not defined by input type %s", f.Name, input.Name)
+		}
+
+		if err := s.ValidateInputValue(def.Type, f.Value); err != nil {
+			return fmt.Errorf("error validating field %s: %w", f.Name, err)
+		}
+	}
+
+	for _, def := range input.Fields {
+		if !def.Type.Nullable && def.Default == nil && obj.Get(def.Name) == nil {
+			return fmt.Errorf("field %s of non-null type %s is not provided", def.Name, def.Type)
+		}
+	}
+
+	return nil
+}
+
 type ArgumentDefinitions []*ArgumentDefinition
 
 func (a ArgumentDefinitions) RequiredArguments() map[*ArgumentDefinition]struct{} {
@@ -9,17 +9,20 @@ import (
 
 type Location struct {
 	Name []byte
+	Loc  *Loc
 }
 
 type Directive struct {
 	Name      []byte
 	Arguments []*DirectiveArgument
 	Locations []*Location
+	Loc       *Loc
 }
 
 type DirectiveArgument struct {
 	Name  []byte
-	Value []byte
+	Value query.InputValue
+	Loc   *Loc
 }
 
 type DirectiveDefinition struct {
@@ -28,6 +31,7 @@ type DirectiveDefinition struct {
 	Arguments   []*ArgumentDefinition
 	Repeatable  bool
 	Locations   []*Location
+	Loc         *Loc
 }
 
 func (d *DirectiveDefinition) IsAllowedApplySchema() bool {
@@ -64,13 +68,13 @@ func (d *DirectiveDefinition) IsAllowedApplyField() bool {
 	return false
 }
 
-func (d *DirectiveDefinition) ValidateArguments(args []*query.DirectiveArgument) error {
+func (d *DirectiveDefinition) ValidateArguments(s *Schema, args []*query.DirectiveArgument) error {
 	for _, def := range d.Arguments {
 		required := !def.Type.Nullable
 		found := false
 		for _, arg := range args {
 			if bytes.Equal(def.Name, arg.Name) {
-				if err := def.ValidateValueType(arg.Value); err != nil {
+				if err := def.ValidateValueType(s, arg.Value); err != nil {
 					return fmt.Errorf("error validating argument %s: %w", def.Name, err)
 				}
 
@@ -171,7 +175,7 @@ func NewBuildInDirectives() []*DirectiveDefinition {
 				{
 					Name:    []byte("reason"),
 					Type:    &FieldType{Name: []byte("String"), Nullable: true},
-					Default: []byte("No longer supported"),
+					Default: &query.StringValue{Value: []byte("No longer supported")},
 				},
 			},
 			Repeatable: false,
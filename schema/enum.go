@@ -3,9 +3,11 @@ package schema
 
 type EnumDefinition struct {
 	Name []byte
+	Description []byte
 	Values []*EnumElement
 	Extentions []*EnumDefinition
 	Directives []*Directive
+	Loc *Loc
 }
 
 func (e *EnumDefinition) Location() *Location {
@@ -26,10 +28,32 @@ func (e EnumDefinitions) Has(name string) bool {
 	return false
 }
 
+func (e EnumDefinitions) Get(name string) *EnumDefinition {
+	for _, enum := range e {
+		if string(enum.Name) == name {
+			return enum
+		}
+	}
+
+	return nil
+}
+
+func (e *EnumDefinition) HasValue(value string) bool {
+	for _, v := range e.Values {
+		if string(v.Name) == value {
+			return true
+		}
+	}
+
+	return false
+}
+
 type EnumElement struct {
 	Name []byte
+	Description []byte
 	Value []byte
 	Directives []*Directive
+	Loc *Loc
 }
 
 func (e *EnumElement) Location() *Location {
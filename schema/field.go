@@ -1,12 +1,16 @@
 package schema
 
+import "github.com/n9te9/goliteql/query"
+
 type FieldDefinition struct {
 	Name []byte
+	Description []byte
 	Arguments []*ArgumentDefinition
 	Type *FieldType
 	Directives []*Directive
-	Default []byte
+	Default query.InputValue
 	Location *Location
+	Loc *Loc
 }
 
 func (f *FieldDefinition) IsPremitive() bool {
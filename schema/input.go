@@ -2,9 +2,11 @@ package schema
 
 type InputDefinition struct {
 	Name []byte
+	Description []byte
 	Fields FieldDefinitions
 	tokens Tokens
 	Extentions []*InputDefinition
+	Loc *Loc
 }
 
 func (i *InputDefinition) Location() *Location {
@@ -4,9 +4,11 @@ import "bytes"
 
 type InterfaceDefinition struct {
 	Name []byte
+	Description []byte
 	Fields FieldDefinitions
 	Extentions []*InterfaceDefinition
 	Directives []*Directive
+	Loc *Loc
 }
 
 func (i *InterfaceDefinition) Location () *Location {
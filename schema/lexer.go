@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"bytes"
 	"fmt"
 	"unicode"
 )
@@ -21,6 +22,7 @@ const (
 	Interface Type = "INTERFACE"
 	Union     Type = "UNION"
 	Comment Type = "COMMENT"
+	Description Type = "DESCRIPTION"
 
 	Value Type = "VALUE"
 
@@ -54,6 +56,26 @@ type Token struct {
 	Value  []byte
 	Line   int
 	Column int
+	// Offset is the byte offset of the token from the start of the source.
+	Offset int
+	// Source is the name of the source the token is read from, which is empty unless it's lexed by LexSource.
+	Source string
+}
+
+func (t *Token) start() Position {
+	return Position{Line: t.Line, Column: t.Column, Offset: t.Offset}
+}
+
+// end returns the position just after the token, which is on a later line than its start
+// if the token is a comment or a string with line breaks.
+func (t *Token) end() Position {
+	pos := Position{Line: t.Line, Column: t.Column + len(t.Value), Offset: t.Offset + len(t.Value)}
+	if i := bytes.LastIndexByte(t.Value, '\n'); i >= 0 {
+		pos.Line += bytes.Count(t.Value, []byte("\n"))
+		pos.Column = len(t.Value) - i
+	}
+
+	return pos
 }
 
 func newKeywordToken(input []byte, t Type, cur, col, line int) (*Token, int) {
@@ -90,7 +112,7 @@ var (
 
 func newIdentifierToken(input []byte, cur, col, line int) (*Token, int) {
 	start := cur
-	for cur < len(input) && unicode.IsLetter(rune(input[cur])) {
+	for cur < len(input) && (unicode.IsLetter(rune(input[cur])) || unicode.IsDigit(rune(input[cur])) || input[cur] == '_') {
 		cur++
 	}
 
@@ -214,6 +236,13 @@ func newDirectiveDeclearationArgumentTokens(input []byte, cur, col, line int) (T
 			continue
 		}
 
+		if input[cur] == '"' {
+			token, cur = newDescriptionToken(input, cur, col, line)
+			tokens = append(tokens, token)
+			col = token.end().Column
+			continue
+		}
+
 		if t, ok := punctuators[punctuator(input[cur])]; ok {
 			token, cur = newPunctuatorToken(input, t, cur, col, line)
 			tokens = append(tokens, token)
@@ -278,6 +307,14 @@ func newEnumTokens(input []byte, cur, col, line int) (Tokens, int, int, int) {
 			continue
 		}
 
+		if input[cur] == '"' {
+			token, cur = newDescriptionToken(input, cur, col, line)
+			tokens = append(tokens, token)
+			end := token.end()
+			line, col = end.Line, end.Column
+			continue
+		}
+
 		switch input[cur] {
 		case '{', ',':
 			token, cur = newPunctuatorToken(input, punctuators[punctuator(input[cur])], cur, col, line)
@@ -584,6 +621,39 @@ func newComment(input []byte, cur, col, line int) (*Token, int) {
 	return &Token{Type: Comment, Value: input[start:cur], Column: col, Line: line}, cur
 }
 
+// newDescriptionToken returns the description starting at cur, which is a string or a block string with its quotes.
+// An unterminated description runs to the end of its line, or to the end of input for a block string, and fails to be parsed.
+func newDescriptionToken(input []byte, cur, col, line int) (*Token, int) {
+	start := cur
+	if bytes.HasPrefix(input[cur:], []byte(`"""`)) {
+		cur += 3
+		for cur < len(input) && !bytes.HasPrefix(input[cur:], []byte(`"""`)) {
+			if bytes.HasPrefix(input[cur:], []byte(`\"""`)) {
+				cur += 4
+				continue
+			}
+			cur++
+		}
+
+		end := min(cur+3, len(input))
+		return &Token{Type: Description, Value: input[start:end], Column: col, Line: line}, end
+	}
+
+	cur++
+	for cur < len(input) && input[cur] != '"' && input[cur] != '\n' {
+		if input[cur] == '\\' && cur+1 < len(input) {
+			cur++
+		}
+		cur++
+	}
+
+	if cur < len(input) && input[cur] == '"' {
+		cur++
+	}
+
+	return &Token{Type: Description, Value: input[start:cur], Column: col, Line: line}, cur
+}
+
 type Lexer struct{}
 
 func NewLexer() *Lexer {
@@ -618,16 +688,12 @@ func (l *Lexer) Lex(input []byte) ([]*Token, error) {
 			continue
 		}
 
-		if input[cur] == '"' {
-			if len(input) > cur + 2 {
-				if input[cur + 1] == '"' && input[cur + 2] == '"' {
-					comment, newCur := newComment(input, cur, col, line)
-					tokens = append(tokens, comment)
-					col = 1
-					cur = newCur
-					continue
-				}
-			}
+		if input[cur] == '"' && !tokens.isDefaultArgument() && !tokens.isDirectiveArgument() {
+			token, cur = newDescriptionToken(input, cur, col, line)
+			tokens = append(tokens, token)
+			end := token.end()
+			line, col = end.Line, end.Column
+			continue
 		}
 
 		if tokens.isEnum() {
@@ -746,10 +812,57 @@ func (l *Lexer) Lex(input []byte) ([]*Token, error) {
 	}
 
 	tokens = append(tokens, &Token{Type: EOF, Value: nil, Column: col, Line: line})
+	locateTokens(input, tokens)
 
 	return tokens, nil
 }
 
+// LexSource lexes the schema in input read from the source named name, such as its file path.
+func (l *Lexer) LexSource(name string, input []byte) ([]*Token, error) {
+	tokens, err := l.Lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range tokens {
+		t.Source = name
+	}
+
+	return tokens, nil
+}
+
+// locateTokens sets the positions of tokens, which are in the order of input, from their offsets.
+// The lexers of directive arguments and locations don't track their line breaks,
+// so the tokens are found in input again rather than trusting the lines and columns they're lexed with.
+func locateTokens(input []byte, tokens Tokens) {
+	cur, line, lineStart := 0, 1, 0
+	advance := func(to int) {
+		for ; cur < to; cur++ {
+			if input[cur] == '\n' {
+				line++
+				lineStart = cur + 1
+			}
+		}
+	}
+
+	for _, t := range tokens {
+		offset := len(input)
+		if t.Type != EOF {
+			i := bytes.Index(input[cur:], t.Value)
+			if i < 0 {
+				continue
+			}
+			offset = cur + i
+		}
+
+		advance(offset)
+		t.Offset = offset
+		t.Line = line
+		t.Column = offset - lineStart + 1
+		advance(offset + len(t.Value))
+	}
+}
+
 type keyword string
 
 func (k keyword) String() string {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/n9te9/goliteql/schema"
 )
 
@@ -720,7 +721,7 @@ func TestLexer_Lex(t *testing.T) {
 				{Type: schema.Identifier, Value: []byte("deprecated"), Column: 16, Line: 2},
 				{Type: schema.On, Value: []byte("on"), Column: 27, Line: 2},
 				{Type: schema.DirectiveLocation, Value: []byte("FIELD_DEFINITION"), Column: 30, Line: 2},
-				{Type: schema.EOF, Value: nil, Column: 50, Line: 2},
+				{Type: schema.EOF, Value: nil, Column: 4, Line: 3},
 			},
 		},
 		{
@@ -746,12 +747,12 @@ func TestLexer_Lex(t *testing.T) {
 				{Type: schema.Identifier, Value: []byte("Boolean"), Line: 3, Column: 15},
 				{Type: schema.Exclamation, Value: []byte("!"), Line: 3, Column: 22},
 				{Type: schema.ParenClose, Value: []byte(")"), Line: 4, Column: 5},
-				{Type: schema.Repeatable, Value: []byte("repeatable"), Line: 2, Column: 58},
-				{Type: schema.On, Value: []byte("on"), Line: 2, Column: 69},
-				{Type: schema.DirectiveLocation, Value: []byte("FIELD_DEFINITION"), Line: 2, Column: 72},
-				{Type: schema.Pipe, Value: []byte("|"), Line: 2, Column: 89},
-				{Type: schema.DirectiveLocation, Value: []byte("OBJECT"), Line: 2, Column: 91},
-				{Type: schema.EOF, Value: nil, Line: 2, Column: 101},
+				{Type: schema.Repeatable, Value: []byte("repeatable"), Line: 4, Column: 7},
+				{Type: schema.On, Value: []byte("on"), Line: 4, Column: 18},
+				{Type: schema.DirectiveLocation, Value: []byte("FIELD_DEFINITION"), Line: 4, Column: 21},
+				{Type: schema.Pipe, Value: []byte("|"), Line: 4, Column: 38},
+				{Type: schema.DirectiveLocation, Value: []byte("OBJECT"), Line: 4, Column: 40},
+				{Type: schema.EOF, Value: nil, Line: 5, Column: 4},
 			},
 		},
 		{
@@ -773,21 +774,21 @@ func TestLexer_Lex(t *testing.T) {
 				{Type: schema.ParenClose, Value: []byte(")"), Column: 37, Line: 1},
 				{Type: schema.On, Value: []byte("on"), Column: 39, Line: 1},
 				{Type: schema.DirectiveLocation, Value: []byte("FIELD_DEFINITION"), Column: 42, Line: 1},
-				{Type: schema.ReservedType, Value: []byte("type"), Column: 64, Line: 1},
-				{Type: schema.Identifier, Value: []byte("User"), Column: 69, Line: 1},
-				{Type: schema.CurlyOpen, Value: []byte("{"), Column: 74, Line: 1},
-				{Type: schema.Field, Value: []byte("name"), Column: 6, Line: 2},
-				{Type: schema.Colon, Value: []byte(":"), Column: 10, Line: 2},
-				{Type: schema.Identifier, Value: []byte("String"), Column: 12, Line: 2},
-				{Type: schema.At, Value: []byte("@"), Column: 19, Line: 2},
-				{Type: schema.Identifier, Value: []byte("deprecated"), Column: 20, Line: 2},
-				{Type: schema.ParenOpen, Value: []byte("("), Column: 30, Line: 2},
-				{Type: schema.Field, Value: []byte("reason"), Column: 31, Line: 2},
-				{Type: schema.Colon, Value: []byte(":"), Column: 37, Line: 2},
-				{Type: schema.Value, Value: []byte(`"Use fullName instead"`), Column: 39, Line: 2},
-				{Type: schema.ParenClose, Value: []byte(")"), Column: 61, Line: 2},
-				{Type: schema.CurlyClose, Value: []byte("}"), Column: 5, Line: 3},
-				{Type: schema.EOF, Value: nil, Column: 4, Line: 4},
+				{Type: schema.ReservedType, Value: []byte("type"), Column: 5, Line: 3},
+				{Type: schema.Identifier, Value: []byte("User"), Column: 10, Line: 3},
+				{Type: schema.CurlyOpen, Value: []byte("{"), Column: 15, Line: 3},
+				{Type: schema.Field, Value: []byte("name"), Column: 6, Line: 4},
+				{Type: schema.Colon, Value: []byte(":"), Column: 10, Line: 4},
+				{Type: schema.Identifier, Value: []byte("String"), Column: 12, Line: 4},
+				{Type: schema.At, Value: []byte("@"), Column: 19, Line: 4},
+				{Type: schema.Identifier, Value: []byte("deprecated"), Column: 20, Line: 4},
+				{Type: schema.ParenOpen, Value: []byte("("), Column: 30, Line: 4},
+				{Type: schema.Field, Value: []byte("reason"), Column: 31, Line: 4},
+				{Type: schema.Colon, Value: []byte(":"), Column: 37, Line: 4},
+				{Type: schema.Value, Value: []byte(`"Use fullName instead"`), Column: 39, Line: 4},
+				{Type: schema.ParenClose, Value: []byte(")"), Column: 61, Line: 4},
+				{Type: schema.CurlyClose, Value: []byte("}"), Column: 5, Line: 5},
+				{Type: schema.EOF, Value: nil, Column: 4, Line: 6},
 			},
 		},
 		{
@@ -824,25 +825,25 @@ func TestLexer_Lex(t *testing.T) {
 				{Type: schema.Colon, Value: []byte(":"), Column: 10, Line: 5},
 				{Type: schema.Identifier, Value: []byte("String"), Column: 12, Line: 5},
 				{Type: schema.ParenClose, Value: []byte(")"), Column: 5, Line: 6},
-				{Type: schema.On, Value: []byte("on"), Column: 71, Line: 3},
-				{Type: schema.DirectiveLocation, Value: []byte("OBJECT"), Column: 74, Line: 3},
-				{Type: schema.Pipe, Value: []byte("|"), Column: 81, Line: 3},
-				{Type: schema.DirectiveLocation, Value: []byte("FIELD_DEFINITION"), Column: 83, Line: 3},
-				{Type: schema.ReservedType, Value: []byte("type"), Column: 105, Line: 3},
-				{Type: schema.Query, Value: []byte("Query"), Column: 110, Line: 3},
-				{Type: schema.At, Value: []byte("@"), Column: 116, Line: 3},
-				{Type: schema.Identifier, Value: []byte("complex"), Column: 117, Line: 3},
-				{Type: schema.ParenOpen, Value: []byte("("), Column: 124, Line: 3},
-				{Type: schema.Field, Value: []byte("level"), Column: 125, Line: 3},
-				{Type: schema.Colon, Value: []byte(":"), Column: 130, Line: 3},
-				{Type: schema.Value, Value: []byte("5"), Column: 132, Line: 3},
-				{Type: schema.ParenClose, Value: []byte(")"), Column: 133, Line: 3},
-				{Type: schema.CurlyOpen, Value: []byte("{"), Column: 135, Line: 3},
-				{Type: schema.Field, Value: []byte("test"), Column: 6, Line: 4},
-				{Type: schema.Colon, Value: []byte(":"), Column: 10, Line: 4},
-				{Type: schema.Identifier, Value: []byte("String"), Column: 12, Line: 4},
-				{Type: schema.CurlyClose, Value: []byte("}"), Column: 5, Line: 5},
-				{Type: schema.EOF, Value: nil, Column: 4, Line: 6},
+				{Type: schema.On, Value: []byte("on"), Column: 7, Line: 6},
+				{Type: schema.DirectiveLocation, Value: []byte("OBJECT"), Column: 10, Line: 6},
+				{Type: schema.Pipe, Value: []byte("|"), Column: 17, Line: 6},
+				{Type: schema.DirectiveLocation, Value: []byte("FIELD_DEFINITION"), Column: 19, Line: 6},
+				{Type: schema.ReservedType, Value: []byte("type"), Column: 5, Line: 8},
+				{Type: schema.Query, Value: []byte("Query"), Column: 10, Line: 8},
+				{Type: schema.At, Value: []byte("@"), Column: 16, Line: 8},
+				{Type: schema.Identifier, Value: []byte("complex"), Column: 17, Line: 8},
+				{Type: schema.ParenOpen, Value: []byte("("), Column: 24, Line: 8},
+				{Type: schema.Field, Value: []byte("level"), Column: 25, Line: 8},
+				{Type: schema.Colon, Value: []byte(":"), Column: 30, Line: 8},
+				{Type: schema.Value, Value: []byte("5"), Column: 32, Line: 8},
+				{Type: schema.ParenClose, Value: []byte(")"), Column: 33, Line: 8},
+				{Type: schema.CurlyOpen, Value: []byte("{"), Column: 35, Line: 8},
+				{Type: schema.Field, Value: []byte("test"), Column: 6, Line: 9},
+				{Type: schema.Colon, Value: []byte(":"), Column: 10, Line: 9},
+				{Type: schema.Identifier, Value: []byte("String"), Column: 12, Line: 9},
+				{Type: schema.CurlyClose, Value: []byte("}"), Column: 5, Line: 10},
+				{Type: schema.EOF, Value: nil, Column: 4, Line: 11},
 			},
 		},
 		{
@@ -869,28 +870,28 @@ func TestLexer_Lex(t *testing.T) {
 				{Type: schema.Repeatable, Value: []byte("repeatable"), Column: 36, Line: 2},
 				{Type: schema.On, Value: []byte("on"), Column: 47, Line: 2},
 				{Type: schema.DirectiveLocation, Value: []byte("FIELD_DEFINITION"), Column: 50, Line: 2},
-				{Type: schema.ReservedType, Value: []byte("type"), Column: 72, Line: 2},
-				{Type: schema.Query, Value: []byte("Query"), Column: 77, Line: 2},
-				{Type: schema.CurlyOpen, Value: []byte("{"), Column: 83, Line: 2},
-				{Type: schema.Field, Value: []byte("myField"), Column: 6, Line: 3},
-				{Type: schema.Colon, Value: []byte(":"), Column: 13, Line: 3},
-				{Type: schema.Identifier, Value: []byte("String"), Column: 15, Line: 3},
-				{Type: schema.At, Value: []byte("@"), Column: 7, Line: 4},
-				{Type: schema.Identifier, Value: []byte("tag"), Column: 8, Line: 4},
-				{Type: schema.ParenOpen, Value: []byte("("), Column: 11, Line: 4},
-				{Type: schema.Field, Value: []byte("label"), Column: 12, Line: 4},
-				{Type: schema.Colon, Value: []byte(":"), Column: 17, Line: 4},
-				{Type: schema.Value, Value: []byte(`"first"`), Column: 19, Line: 4},
-				{Type: schema.ParenClose, Value: []byte(")"), Column: 26, Line: 4},
-				{Type: schema.At, Value: []byte("@"), Column: 7, Line: 5},
-				{Type: schema.Identifier, Value: []byte("tag"), Column: 8, Line: 5},
-				{Type: schema.ParenOpen, Value: []byte("("), Column: 11, Line: 5},
-				{Type: schema.Field, Value: []byte("label"), Column: 12, Line: 5},
-				{Type: schema.Colon, Value: []byte(":"), Column: 17, Line: 5},
-				{Type: schema.Value, Value: []byte(`"second"`), Column: 19, Line: 5},
-				{Type: schema.ParenClose, Value: []byte(")"), Column: 27, Line: 5},
-				{Type: schema.CurlyClose, Value: []byte("}"), Column: 5, Line: 6},
-				{Type: schema.EOF, Value: nil, Column: 4, Line: 7},
+				{Type: schema.ReservedType, Value: []byte("type"), Column: 5, Line: 4},
+				{Type: schema.Query, Value: []byte("Query"), Column: 10, Line: 4},
+				{Type: schema.CurlyOpen, Value: []byte("{"), Column: 16, Line: 4},
+				{Type: schema.Field, Value: []byte("myField"), Column: 6, Line: 5},
+				{Type: schema.Colon, Value: []byte(":"), Column: 13, Line: 5},
+				{Type: schema.Identifier, Value: []byte("String"), Column: 15, Line: 5},
+				{Type: schema.At, Value: []byte("@"), Column: 7, Line: 6},
+				{Type: schema.Identifier, Value: []byte("tag"), Column: 8, Line: 6},
+				{Type: schema.ParenOpen, Value: []byte("("), Column: 11, Line: 6},
+				{Type: schema.Field, Value: []byte("label"), Column: 12, Line: 6},
+				{Type: schema.Colon, Value: []byte(":"), Column: 17, Line: 6},
+				{Type: schema.Value, Value: []byte(`"first"`), Column: 19, Line: 6},
+				{Type: schema.ParenClose, Value: []byte(")"), Column: 26, Line: 6},
+				{Type: schema.At, Value: []byte("@"), Column: 7, Line: 7},
+				{Type: schema.Identifier, Value: []byte("tag"), Column: 8, Line: 7},
+				{Type: schema.ParenOpen, Value: []byte("("), Column: 11, Line: 7},
+				{Type: schema.Field, Value: []byte("label"), Column: 12, Line: 7},
+				{Type: schema.Colon, Value: []byte(":"), Column: 17, Line: 7},
+				{Type: schema.Value, Value: []byte(`"second"`), Column: 19, Line: 7},
+				{Type: schema.ParenClose, Value: []byte(")"), Column: 27, Line: 7},
+				{Type: schema.CurlyClose, Value: []byte("}"), Column: 5, Line: 8},
+				{Type: schema.EOF, Value: nil, Column: 4, Line: 9},
 			},
 		},
 		{
@@ -1172,12 +1173,12 @@ func TestLexer_Lex(t *testing.T) {
 				{Type: schema.Identifier, Value: []byte("NORTH"), Line: 2, Column: 4},
 				{Type: schema.Identifier, Value: []byte("EAST"), Line: 3, Column: 4},
 				{Type: schema.At, Value: []byte("@"), Line: 3, Column: 9},
-				{Type: schema.Identifier, Value: []byte("deprecated"), Line: 3, Column: 9},
-				{Type: schema.ParenOpen, Value: []byte("("), Line: 3, Column: 19},
-				{Type: schema.Field, Value: []byte("reason"), Line: 3, Column: 20},
-				{Type: schema.Colon, Value: []byte(":"), Line: 3, Column: 26},
-				{Type: schema.Value, Value: []byte(`"No longer used"`), Line: 3, Column: 28},
-				{Type: schema.ParenClose, Value: []byte(")"), Line: 3, Column: 44},
+				{Type: schema.Identifier, Value: []byte("deprecated"), Line: 3, Column: 10},
+				{Type: schema.ParenOpen, Value: []byte("("), Line: 3, Column: 20},
+				{Type: schema.Field, Value: []byte("reason"), Line: 3, Column: 21},
+				{Type: schema.Colon, Value: []byte(":"), Line: 3, Column: 27},
+				{Type: schema.Value, Value: []byte(`"No longer used"`), Line: 3, Column: 29},
+				{Type: schema.ParenClose, Value: []byte(")"), Line: 3, Column: 45},
 				{Type: schema.Identifier, Value: []byte("SOUTH"), Line: 4, Column: 4},
 				{Type: schema.Identifier, Value: []byte("WEST"), Line: 5, Column: 4},
 				{Type: schema.CurlyClose, Value: []byte("}"), Line: 6, Column: 3},
@@ -1497,7 +1498,7 @@ func TestLexer_Lex(t *testing.T) {
 			},
 		},
 		{
-			name: "Parse for comment out",
+			name: "Parse for comment out and description",
 			input: []byte(`type User {
 				# ID
 				id: ID!
@@ -1514,7 +1515,7 @@ func TestLexer_Lex(t *testing.T) {
 				{Type: schema.Colon, Value: []byte(":"), Line: 3, Column: 7},
 				{Type: schema.Identifier, Value: []byte("ID"), Line: 3, Column: 9},
 				{Type: schema.Exclamation, Value: []byte("!"), Line: 3, Column: 11},
-				{Type: schema.Comment, Value: []byte(`"""hoge"""`), Line: 4, Column: 5},
+				{Type: schema.Description, Value: []byte(`"""hoge"""`), Line: 4, Column: 5},
 				{Type: schema.Field, Value: []byte("hoge"), Line: 5, Column: 5},
 				{Type: schema.Colon, Value: []byte(":"), Line: 5, Column: 9},
 				{Type: schema.Identifier, Value: []byte("String"), Line: 5, Column: 11},
@@ -1538,7 +1539,7 @@ func TestLexer_Lex(t *testing.T) {
 				return
 			}
 
-			if diff := cmp.Diff(got, tt.expected); diff != "" {
+			if diff := cmp.Diff(got, tt.expected, cmpopts.IgnoreFields(schema.Token{}, "Offset")); diff != "" {
 				t.Errorf("Lex() mismatch (-got +want):\n%s", diff)
 			}
 		})
@@ -3,8 +3,39 @@ package schema
 import (
 	"fmt"
 
+	"github.com/n9te9/goliteql/query"
 )
 
+// Position is a position in a schema source, where Line and Column start from 1 and Offset is the byte offset from 0.
+type Position struct {
+	Line   int
+	Column int
+	Offset int
+}
+
+// Loc is the location of a node in the source it's parsed from,
+// from the start of its first token to the end of its last token.
+// Source is the name of the source given to ParseSources, which is empty if the schema is parsed by Parse.
+type Loc struct {
+	Source string
+	Start  Position
+	End    Position
+}
+
+func newLoc(tokens Tokens, start, end int) *Loc {
+	return &Loc{
+		Source: tokens[start].Source,
+		Start:  tokens[start].start(),
+		End:    tokens[end-1].end(),
+	}
+}
+
+// Source is a schema source, such as a .graphql file, whose Name is reported in the locations of its nodes.
+type Source struct {
+	Name string
+	Body []byte
+}
+
 type Parser struct {
 	Lexer *Lexer
 }
@@ -21,6 +52,30 @@ func (p *Parser) Parse(input []byte) (*Schema, error) {
 		return nil, err
 	}
 
+	return p.parse(tokens)
+}
+
+// ParseSources parses the schema made of sources as a single schema,
+// keeping the name of the source every node is read from in its location.
+func (p *Parser) ParseSources(sources ...*Source) (*Schema, error) {
+	tokens := make(Tokens, 0)
+	eof := &Token{Type: EOF, Line: 1, Column: 1}
+	for _, source := range sources {
+		sourceTokens, err := p.Lexer.LexSource(source.Name, source.Body)
+		if err != nil {
+			return nil, fmt.Errorf("error lexing %s: %w", source.Name, err)
+		}
+
+		// the sources are parsed as a single schema, ending at the EOF of the last source
+		tokens = append(tokens, sourceTokens[:len(sourceTokens)-1]...)
+		eof = sourceTokens[len(sourceTokens)-1]
+	}
+
+	return p.parse(append(tokens, eof))
+}
+
+func (p *Parser) parse(tokens Tokens) (*Schema, error) {
+	var err error
 	schema := NewSchema(tokens)
 
 	cur := 0
@@ -143,6 +198,9 @@ func (p *Parser) Parse(input []byte) (*Schema, error) {
 			}
 			cur = newCur
 			schema.Scalars = append(schema.Scalars, scalarDefinition)
+		case Comment, Description:
+			// descriptions are read by the definitions after them
+			cur++
 		case EOF:
 			return schema, nil
 		}
@@ -151,14 +209,57 @@ func (p *Parser) Parse(input []byte) (*Schema, error) {
 	return nil, fmt.Errorf("unexpected end of input")
 }
 
+// MustParse parses and merges input, and panics if the schema is invalid.
+// Generated resolvers use it to load the schema they were generated from.
+func MustParse(input []byte) *Schema {
+	s, err := NewParser(NewLexer()).Parse(input)
+	if err != nil {
+		panic(fmt.Sprintf("error parsing schema: %v", err))
+	}
+
+	s, err = s.Merge()
+	if err != nil {
+		panic(fmt.Sprintf("error merging schema: %v", err))
+	}
+
+	return s
+}
+
+// parseDescription returns the description written just before the definition whose first token is at start,
+// or nil if the definition doesn't have a description.
+func parseDescription(tokens Tokens, start int) ([]byte, error) {
+	if start <= 0 || tokens[start-1].Type != Description {
+		return nil, nil
+	}
+
+	value, err := query.ParseValue(tokens[start-1].Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid description at line %d, column %d: %w", tokens[start-1].Line, tokens[start-1].Column, err)
+	}
+
+	description, ok := value.(*query.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("invalid description %s at line %d, column %d", tokens[start-1].Value, tokens[start-1].Line, tokens[start-1].Column)
+	}
+
+	return description.Value, nil
+}
+
 func (p *Parser) parseScalarDefinition(tokens Tokens, cur int) (*ScalarDefinition, int, error) {
+	start := cur
 	cur++
 	if tokens[cur].Type != Identifier {
 		return nil, 0, fmt.Errorf("expected identifier but got %s", string(tokens[cur].Value))
 	}
 
+	description, err := parseDescription(tokens, start)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	scalarDefinition := &ScalarDefinition{
-		Name: tokens[cur].Value,
+		Name:        tokens[cur].Value,
+		Description: description,
 	}
 	cur++
 
@@ -170,6 +271,7 @@ func (p *Parser) parseScalarDefinition(tokens Tokens, cur int) (*ScalarDefinitio
 		scalarDefinition.Directives = directives
 		cur = newCur
 	}
+	scalarDefinition.Loc = newLoc(tokens, start, cur)
 
 	return scalarDefinition, cur, nil
 }
@@ -274,6 +376,8 @@ func (p *Parser) parseExtendDefinition(schema *Schema, tokens Tokens, cur int) (
 }
 
 func (p *Parser) parseSchemaDefinition(tokens Tokens, cur int) (*SchemaDefinition, int, error) {
+	// the definition starts at the schema keyword before cur
+	start := cur - 1
 	definition := new(SchemaDefinition)
 	if tokens[cur].Type == At {
 		directives, newCur, err := p.parseDirectives(tokens, cur)
@@ -334,15 +438,23 @@ func (p *Parser) parseSchemaDefinition(tokens Tokens, cur int) (*SchemaDefinitio
 		return nil, 0, fmt.Errorf("expected '}' but got %s", string(tokens[cur].Value))
 	}
 	cur++
+	definition.Loc = newLoc(tokens, start, cur)
 
 	return definition, cur, nil
 }
 
 func (p *Parser) parseTypeDefinition(schema *Schema,tokens Tokens, cur int) (*TypeDefinition, int, error) {
 	start := cur
+	// the description is before the keyword before the name
+	description, err := parseDescription(tokens, start-1)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	definition := &TypeDefinition{
-		Fields: make([]*FieldDefinition, 0),
-		Name:   tokens[cur].Value,
+		Fields:      make([]*FieldDefinition, 0),
+		Name:        tokens[cur].Value,
+		Description: description,
 	}
 
 	cur++
@@ -393,7 +505,7 @@ func (p *Parser) parseTypeDefinition(schema *Schema,tokens Tokens, cur int) (*Ty
 	cur++
 	for cur < len(tokens) {
 		switch tokens[cur].Type {
-		case Comment:
+		case Comment, Description:
 			cur++
 			continue
 		case Field:
@@ -406,6 +518,8 @@ func (p *Parser) parseTypeDefinition(schema *Schema,tokens Tokens, cur int) (*Ty
 		case CurlyClose:
 			definition.tokens = tokens[start:cur]
 			cur++
+			// the definition starts at the keyword before its name
+			definition.Loc = newLoc(tokens, start-1, cur)
 			return definition, cur, nil
 		}
 	}
@@ -415,9 +529,16 @@ func (p *Parser) parseTypeDefinition(schema *Schema,tokens Tokens, cur int) (*Ty
 
 func (p *Parser) parseInputDefinition(tokens Tokens, cur int) (*InputDefinition, int, error) {
 	start := cur
+	// the description is before the keyword before the name
+	description, err := parseDescription(tokens, start-1)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	definition := &InputDefinition{
-		Fields: make([]*FieldDefinition, 0),
-		Name:   tokens[cur].Value,
+		Fields:      make([]*FieldDefinition, 0),
+		Name:        tokens[cur].Value,
+		Description: description,
 	}
 
 	cur++
@@ -428,6 +549,9 @@ func (p *Parser) parseInputDefinition(tokens Tokens, cur int) (*InputDefinition,
 	cur++
 	for cur < len(tokens) {
 		switch tokens[cur].Type {
+		case Comment, Description:
+			cur++
+			continue
 		case Field:
 			fieldDefinitions, newCur, err := p.parseFieldDefinitions(tokens, cur, true)
 			if err != nil {
@@ -438,6 +562,8 @@ func (p *Parser) parseInputDefinition(tokens Tokens, cur int) (*InputDefinition,
 		case CurlyClose:
 			definition.tokens = tokens[start:cur]
 			cur++
+			// the definition starts at the keyword before its name
+			definition.Loc = newLoc(tokens, start-1, cur)
 			return definition, cur, nil
 		}
 	}
@@ -446,13 +572,20 @@ func (p *Parser) parseInputDefinition(tokens Tokens, cur int) (*InputDefinition,
 }
 
 func (p *Parser) parseEnumDefinition(tokens Tokens, cur int) (*EnumDefinition, int, error) {
+	start := cur
 	cur++
 	if tokens[cur].Type != Identifier {
 		return nil, 0, fmt.Errorf("expected identifier but got %s", string(tokens[cur].Value))
 	}
 
+	description, err := parseDescription(tokens, start)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	enumDefinition := &EnumDefinition{
-		Name: tokens[cur].Value,
+		Name:        tokens[cur].Value,
+		Description: description,
 	}
 	cur++
 
@@ -480,8 +613,11 @@ func (p *Parser) parseEnumDefinition(tokens Tokens, cur int) (*EnumDefinition, i
 
 			enumDefinition.Values = append(enumDefinition.Values, element)
 			cur = newCur
+		case Comment, Description:
+			cur++
 		case CurlyClose:
 			cur++
+			enumDefinition.Loc = newLoc(tokens, start, cur)
 			return enumDefinition, cur, nil
 		default:
 			return nil, 0, fmt.Errorf("unexpected token %s", string(tokens[cur].Value))
@@ -496,9 +632,16 @@ func (p *Parser) parseEnumElement(tokens Tokens, cur int) (*EnumElement, int, er
 		return nil, 0, fmt.Errorf("expected identifier but got %s", string(tokens[cur].Value))
 	}
 
+	start := cur
+	description, err := parseDescription(tokens, start)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	element := &EnumElement{
-		Name: tokens[cur].Value,
-		Value: tokens[cur].Value,
+		Name:        tokens[cur].Value,
+		Value:       tokens[cur].Value,
+		Description: description,
 	}
 	cur++
 
@@ -520,11 +663,14 @@ func (p *Parser) parseEnumElement(tokens Tokens, cur int) (*EnumElement, int, er
 		element.Value = tokens[cur].Value
 		cur++
 	}
+	element.Loc = newLoc(tokens, start, cur)
 
 	return element, cur, nil
 }
 
 func (p *Parser) parseOperationDefinition(tokens Tokens, cur int) (*OperationDefinition, int, error) {
+	// the definition starts at the type keyword before cur
+	start := cur - 1
 	var operationType OperationType
 	switch tokens[cur].Type {
 	case Query:
@@ -542,15 +688,21 @@ func (p *Parser) parseOperationDefinition(tokens Tokens, cur int) (*OperationDef
 		return nil, 0, fmt.Errorf("expected identifier but got %s", string(tokens[cur].Value))
 	}
 
+	description, err := parseDescription(tokens, start)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	operationDefinition := &OperationDefinition{
 		OperationType: operationType,
+		Description:   description,
 		Fields:        make([]*FieldDefinition, 0),
 	}
 	cur++
 
 	for cur < len(tokens) {
 		switch tokens[cur].Type {
-		case Comment:
+		case Comment, Description:
 			cur++
 			continue
 		case Field:
@@ -562,6 +714,7 @@ func (p *Parser) parseOperationDefinition(tokens Tokens, cur int) (*OperationDef
 			cur = newCur
 		case CurlyClose:
 			cur++
+			operationDefinition.Loc = newLoc(tokens, start, cur)
 			return operationDefinition, cur, nil
 		}
 	}
@@ -570,7 +723,14 @@ func (p *Parser) parseOperationDefinition(tokens Tokens, cur int) (*OperationDef
 }
 
 func (p *Parser) parseDirectiveDefinition(tokens Tokens, cur int) (*DirectiveDefinition, int, error) {
-	definition := new(DirectiveDefinition)
+	// the definition starts at the directive keyword before cur
+	start := cur - 1
+	description, err := parseDescription(tokens, start)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	definition := &DirectiveDefinition{Description: description}
 	if tokens[cur].Type != At {
 		return nil, 0, fmt.Errorf("expected '@' but got %s", string(tokens[cur].Value))
 	}
@@ -601,6 +761,7 @@ func (p *Parser) parseDirectiveDefinition(tokens Tokens, cur int) (*DirectiveDef
 
 	cur = newCur
 	definition.Locations = locations
+	definition.Loc = newLoc(tokens, start, cur)
 
 	return definition, cur, nil
 }
@@ -632,6 +793,7 @@ func (p *Parser) parseDirectiveLocations(tokens Tokens, cur int) ([]*Location, i
 func (p *Parser) parseDirectiveLocation(tokens Tokens, cur int) (*Location, int, error) {
 	location := &Location{
 		Name: tokens[cur].Value,
+		Loc:  newLoc(tokens, cur, cur+1),
 	}
 	cur++
 	return location, cur, nil
@@ -650,6 +812,8 @@ func (p *Parser) parseOperationFields(tokens Tokens, cur int) ([]*FieldDefinitio
 			definitions = append(definitions, fieldDefinition)
 			cur = newCur
 			continue
+		case Comment, Description:
+			cur++
 		case CurlyClose:
 			return definitions, cur, nil
 		case EOF:
@@ -661,11 +825,18 @@ func (p *Parser) parseOperationFields(tokens Tokens, cur int) ([]*FieldDefinitio
 }
 
 func (p *Parser) parseOperationField(tokens Tokens, cur int) (*FieldDefinition, int, error) {
+	start := cur
+	description, err := parseDescription(tokens, start)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	definition := &FieldDefinition{
-		Name:      tokens[cur].Value,
-		Arguments: make([]*ArgumentDefinition, 0),
-		Type:      nil,
-		Location: &Location{Name: []byte("FIELD_DEFINITION")},
+		Name:        tokens[cur].Value,
+		Description: description,
+		Arguments:   make([]*ArgumentDefinition, 0),
+		Type:        nil,
+		Location:    &Location{Name: []byte("FIELD_DEFINITION")},
 	}
 	cur++
 
@@ -695,6 +866,7 @@ func (p *Parser) parseOperationField(tokens Tokens, cur int) (*FieldDefinition,
 		definition.Directives = directiveDefinitions
 		cur = newCur
 	}
+	definition.Loc = newLoc(tokens, start, cur)
 
 	return definition, cur, nil
 }
@@ -706,6 +878,7 @@ func (p *Parser) parseDirectives(tokens Tokens, cur int) ([]*Directive, int, err
 	for cur < len(tokens) {
 		switch tokens[cur].Type {
 		case At:
+			start := cur
 			cur++
 			var definition *Directive
 			if cur < len(tokens) && tokens[cur].Type == Identifier {
@@ -723,6 +896,7 @@ func (p *Parser) parseDirectives(tokens Tokens, cur int) ([]*Directive, int, err
 					return nil, 0, err
 				}
 			}
+			definition.Loc = newLoc(tokens, start, cur)
 
 			definitions = append(definitions, definition)
 		default:
@@ -759,6 +933,7 @@ func (p *Parser) parseDirectiveArguments(tokens Tokens, cur int) ([]*DirectiveAr
 }
 
 func (p *Parser) parseDirectiveArgument(tokens Tokens, cur int) (*DirectiveArgument, int, error) {
+	start := cur
 	arg := &DirectiveArgument{
 		Name: tokens[cur].Value,
 	}
@@ -771,11 +946,16 @@ func (p *Parser) parseDirectiveArgument(tokens Tokens, cur int) (*DirectiveArgum
 
 	switch tokens[cur].Type {
 	case Value:
-		arg.Value = tokens[cur].Value
+		value, err := query.ParseValue(tokens[cur].Value)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid value of argument %s: %w", arg.Name, err)
+		}
+		arg.Value = value
 		cur++
 	default:
 		return nil, 0, fmt.Errorf("unexpected token %s", string(tokens[cur].Value))
 	}
+	arg.Loc = newLoc(tokens, start, cur)
 
 	return arg, cur, nil
 }
@@ -786,7 +966,7 @@ func (p *Parser) parseArguments(tokens Tokens, cur int) ([]*ArgumentDefinition,
 		switch tokens[cur].Type {
 		case On:
 			return args, cur, nil
-		case ParenOpen, Comma:
+		case ParenOpen, Comma, Comment, Description:
 			cur++
 			continue
 		case Field:
@@ -808,8 +988,15 @@ func (p *Parser) parseArguments(tokens Tokens, cur int) ([]*ArgumentDefinition,
 }
 
 func (p *Parser) parseArgument(tokens Tokens, cur int) (*ArgumentDefinition, int, error) {
+	start := cur
+	description, err := parseDescription(tokens, start)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	arg := &ArgumentDefinition{
-		Name: tokens[cur].Value,
+		Name:        tokens[cur].Value,
+		Description: description,
 	}
 	cur++
 
@@ -829,25 +1016,37 @@ func (p *Parser) parseArgument(tokens Tokens, cur int) (*ArgumentDefinition, int
 		cur++
 		switch tokens[cur].Type {
 		case Value:
-			arg.Default = tokens[cur].Value
+			value, err := query.ParseValue(tokens[cur].Value)
+			if err != nil {
+				return nil, 0, fmt.Errorf("invalid default value of argument %s: %w", arg.Name, err)
+			}
+			arg.Default = value
 			cur++
 		default:
 			return nil, 0, fmt.Errorf("unexpected token %s", string(tokens[cur].Value))
 		}
 	}
+	arg.Loc = newLoc(tokens, start, cur)
 
 	return arg, cur, nil
 }
 
 func (p *Parser) parseInterfaceDefinition(tokens Tokens, cur int) (*InterfaceDefinition, int, error) {
+	start := cur
 	cur++
 	if tokens[cur].Type != Identifier {
 		return nil, 0, fmt.Errorf("expected identifier but got %s", string(tokens[cur].Type))
 	}
 
+	description, err := parseDescription(tokens, start)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	interfaceDefinition := &InterfaceDefinition{
-		Name:   tokens[cur].Value,
-		Fields: make([]*FieldDefinition, 0),
+		Name:        tokens[cur].Value,
+		Description: description,
+		Fields:      make([]*FieldDefinition, 0),
 	}
 	cur++
 
@@ -867,7 +1066,7 @@ func (p *Parser) parseInterfaceDefinition(tokens Tokens, cur int) (*InterfaceDef
 	cur++
 	for cur < len(tokens) {
 		switch tokens[cur].Type {
-		case Comment:
+		case Comment, Description:
 			cur++
 			continue
 		case Field:
@@ -879,6 +1078,7 @@ func (p *Parser) parseInterfaceDefinition(tokens Tokens, cur int) (*InterfaceDef
 			cur = newCur
 		case CurlyClose:
 			cur++
+			interfaceDefinition.Loc = newLoc(tokens, start, cur)
 			return interfaceDefinition, cur, nil
 		}
 	}
@@ -893,10 +1093,11 @@ func (p *Parser) parseFieldDefinitions(tokens Tokens, cur int, isInputField bool
 		switch tokens[cur].Type {
 		case CurlyOpen, ParenOpen:
 			cur++
-		case Comment:
+		case Comment, Description:
 			cur++
 			continue
 		case Field:
+			start := cur
 			fieldDefinition, newCur, err := p.parseFieldDefinition(tokens, cur, isInputField)
 			if err != nil {
 				return nil, 0, err
@@ -910,6 +1111,7 @@ func (p *Parser) parseFieldDefinitions(tokens Tokens, cur int, isInputField bool
 			cur = newCur
 
 			fieldDefinition.Directives = directives
+			fieldDefinition.Loc = newLoc(tokens, start, cur)
 			definitions = append(definitions, fieldDefinition)
 		case CurlyClose, ParenClose:
 			return definitions, cur, nil
@@ -930,9 +1132,15 @@ func (p *Parser) parseFieldDefinition(tokens Tokens, cur int, isInputField bool)
 		location.Name = []byte("INPUT_FIELD_DEFINITION")
 	}
 
+	description, err := parseDescription(tokens, cur)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	definition := &FieldDefinition{
-		Name: tokens[cur].Value,
-		Location: location,
+		Name:        tokens[cur].Value,
+		Description: description,
+		Location:    location,
 	}
 
 	cur++
@@ -968,7 +1176,11 @@ func (p *Parser) parseFieldDefinition(tokens Tokens, cur int, isInputField bool)
 		cur++
 		switch tokens[cur].Type {
 		case Value:
-			definition.Default = tokens[cur].Value
+			value, err := query.ParseValue(tokens[cur].Value)
+			if err != nil {
+				return nil, 0, fmt.Errorf("invalid default value of field %s: %w", definition.Name, err)
+			}
+			definition.Default = value
 			cur++
 		default:
 			return nil, 0, fmt.Errorf("unexpected token %s", string(tokens[cur].Value))
@@ -979,6 +1191,7 @@ func (p *Parser) parseFieldDefinition(tokens Tokens, cur int, isInputField bool)
 }
 
 func (p *Parser) parseFieldType(tokens Tokens, cur int) (*FieldType, int, error) {
+	start := cur
 	fieldType := &FieldType{
 		Nullable: true,
 	}
@@ -1003,6 +1216,8 @@ func (p *Parser) parseFieldType(tokens Tokens, cur int) (*FieldType, int, error)
 		fieldType.Nullable = false
 		cur++
 	}
+	// the closing bracket belongs to the enclosing list type
+	fieldType.Loc = newLoc(tokens, start, cur)
 
 	if tokens[cur].Type == BracketClose {
 		cur++
@@ -1012,13 +1227,20 @@ func (p *Parser) parseFieldType(tokens Tokens, cur int) (*FieldType, int, error)
 }
 
 func (p *Parser) parseUnionDefinition(tokens Tokens, cur int) (*UnionDefinition, int, error) {
+	start := cur
 	cur++
 	if tokens[cur].Type != Identifier {
 		return nil, 0, fmt.Errorf("expected identifier but got %s", string(tokens[cur].Value))
 	}
 
+	description, err := parseDescription(tokens, start)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	unionDefinition := &UnionDefinition{
-		Name: tokens[cur].Value,
+		Name:        tokens[cur].Value,
+		Description: description,
 	}
 	cur++
 
@@ -1057,8 +1279,10 @@ func (p *Parser) parseUnionDefinition(tokens Tokens, cur int) (*UnionDefinition,
 				return nil, 0, fmt.Errorf("unexpected end of input")
 			}
 
+			unionDefinition.Loc = newLoc(tokens, start, cur)
 			return unionDefinition, cur, nil
-		case ReservedType, Union, Enum, Interface, Input, Extend, ReservedSchema:
+		case ReservedType, Union, Enum, Interface, Input, Extend, ReservedSchema, Scalar, ReservedDirective, Comment, Description:
+			unionDefinition.Loc = newLoc(tokens, start, cur)
 			return unionDefinition, cur, nil
 		default:
 			return nil, 0, fmt.Errorf("unexpected token %s", string(tokens[cur].Value))
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/n9te9/goliteql/query"
 	"github.com/n9te9/goliteql/schema"
 )
 
@@ -376,7 +377,7 @@ func TestParser_Parse(t *testing.T) {
 									Nullable: false,
 									IsList:   false,
 								},
-								Default:    []byte(`"name"`),
+								Default:    &query.StringValue{Value: []byte("name")},
 								Directives: []*schema.Directive{},
 								Location:   &schema.Location{Name: []byte("INPUT_FIELD_DEFINITION")},
 							},
@@ -409,7 +410,7 @@ func TestParser_Parse(t *testing.T) {
 									Nullable: false,
 									IsList:   false,
 								},
-								Default:    []byte(`"name"`),
+								Default:    &query.StringValue{Value: []byte("name")},
 								Directives: []*schema.Directive{},
 								Location:   &schema.Location{Name: []byte("INPUT_FIELD_DEFINITION")},
 							},
@@ -420,7 +421,7 @@ func TestParser_Parse(t *testing.T) {
 									Nullable: false,
 									IsList:   false,
 								},
-								Default:    []byte(`"John Doe"`),
+								Default:    &query.StringValue{Value: []byte("John Doe")},
 								Directives: []*schema.Directive{},
 								Location:   &schema.Location{Name: []byte("INPUT_FIELD_DEFINITION")},
 							},
@@ -649,7 +650,7 @@ func TestParser_Parse(t *testing.T) {
 											Nullable: true,
 											IsList:   false,
 										},
-										Default: []byte("1"),
+										Default: &query.IntValue{Value: []byte("1")},
 									},
 								},
 								Type: &schema.FieldType{
@@ -752,7 +753,26 @@ func TestParser_Parse(t *testing.T) {
 												},
 											},
 										},
-										Default: []byte(`[[{field: "name", value: "John Doe"}]]`),
+										Default: &query.ListValue{
+											Values: []query.InputValue{
+												&query.ListValue{
+													Values: []query.InputValue{
+														&query.ObjectValue{
+															Fields: []*query.ObjectField{
+																{
+																	Name:  []byte("field"),
+																	Value: &query.StringValue{Value: []byte("name")},
+																},
+																{
+																	Name:  []byte("value"),
+																	Value: &query.StringValue{Value: []byte("John Doe")},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
 									},
 								},
 								Type: &schema.FieldType{
@@ -898,7 +918,7 @@ func TestParser_Parse(t *testing.T) {
 											Nullable: true,
 											IsList:   false,
 										},
-										Default: []byte(`"John Doe"`),
+										Default: &query.StringValue{Value: []byte("John Doe")},
 									},
 								},
 								Type: &schema.FieldType{
@@ -1056,7 +1076,7 @@ func TestParser_Parse(t *testing.T) {
 											Nullable: true,
 											IsList:   false,
 										},
-										Default: []byte("true"),
+										Default: &query.BooleanValue{Value: true},
 									},
 								},
 								Type: &schema.FieldType{
@@ -1212,7 +1232,7 @@ func TestParser_Parse(t *testing.T) {
 											},
 											{
 												Name:    []byte(`isActive`),
-												Default: []byte(`false`),
+												Default: &query.BooleanValue{Value: false},
 												Type: &schema.FieldType{
 													Name:     []byte(`Boolean`),
 													Nullable: false,
@@ -1314,7 +1334,7 @@ func TestParser_Parse(t *testing.T) {
 											},
 											{
 												Name:    []byte(`isActive`),
-												Default: []byte(`true`),
+												Default: &query.BooleanValue{Value: true},
 												Type: &schema.FieldType{
 													Name:     []byte(`Boolean`),
 													Nullable: false,
@@ -1411,7 +1431,7 @@ func TestParser_Parse(t *testing.T) {
 										Arguments: []*schema.ArgumentDefinition{
 											{
 												Name:    []byte(`isActive`),
-												Default: []byte(`false`),
+												Default: &query.BooleanValue{Value: false},
 												Type: &schema.FieldType{
 													Name:     []byte(`Boolean`),
 													Nullable: false,
@@ -1695,7 +1715,7 @@ func TestParser_Parse(t *testing.T) {
 									{
 										Name: []byte("deprecated"),
 										Arguments: []*schema.DirectiveArgument{
-											{Name: []byte("reason"), Value: []byte("\"Use fullName instead\"")},
+											{Name: []byte("reason"), Value: &query.StringValue{Value: []byte("Use fullName instead")}},
 										},
 									},
 								},
@@ -1772,7 +1792,7 @@ func TestParser_Parse(t *testing.T) {
 							{
 								Name:    []byte("reason"),
 								Type:    &schema.FieldType{Name: []byte("String"), Nullable: true},
-								Default: []byte("No longer supported"),
+								Default: &query.StringValue{Value: []byte("No longer supported")},
 							},
 						},
 						Repeatable: false,
@@ -1825,7 +1845,7 @@ func TestParser_Parse(t *testing.T) {
 									{
 										Name: []byte("length"),
 										Arguments: []*schema.DirectiveArgument{
-											{Name: []byte("max"), Value: []byte("50")},
+											{Name: []byte("max"), Value: &query.IntValue{Value: []byte("50")}},
 										},
 									},
 								},
@@ -1861,7 +1881,7 @@ func TestParser_Parse(t *testing.T) {
 									{
 										Name: []byte("auth"),
 										Arguments: []*schema.DirectiveArgument{
-											{Name: []byte("role"), Value: []byte("\"USER\"")},
+											{Name: []byte("role"), Value: &query.StringValue{Value: []byte("USER")}},
 										},
 									},
 								},
@@ -1899,7 +1919,7 @@ func TestParser_Parse(t *testing.T) {
 									Nullable: true,
 									IsList:   false,
 								},
-								Default: []byte(`"USER"`),
+								Default: &query.StringValue{Value: []byte("USER")},
 							},
 							{
 								Name: []byte("enabled"),
@@ -1950,7 +1970,7 @@ func TestParser_Parse(t *testing.T) {
 									{
 										Name: []byte("deprecated"),
 										Arguments: []*schema.DirectiveArgument{
-											{Name: []byte("reason"), Value: []byte("\"No longer used\"")},
+											{Name: []byte("reason"), Value: &query.StringValue{Value: []byte("No longer used")}},
 										},
 									},
 								},
@@ -2003,7 +2023,7 @@ func TestParser_Parse(t *testing.T) {
 								Arguments: []*schema.DirectiveArgument{
 									{
 										Name:  []byte("role"),
-										Value: []byte(`"ADMIN"`),
+										Value: &query.StringValue{Value: []byte("ADMIN")},
 									},
 								},
 							},
@@ -2035,7 +2055,7 @@ func TestParser_Parse(t *testing.T) {
 								Arguments: []*schema.DirectiveArgument{
 									{
 										Name:  []byte("reason"),
-										Value: []byte(`"Use another union"`),
+										Value: &query.StringValue{Value: []byte("Use another union")},
 									},
 								},
 							},
@@ -2093,13 +2113,13 @@ func TestParser_Parse(t *testing.T) {
 							{
 								Name: []byte("auth"),
 								Arguments: []*schema.DirectiveArgument{
-									{Name: []byte("role"), Value: []byte(`"ADMIN"`)},
+									{Name: []byte("role"), Value: &query.StringValue{Value: []byte("ADMIN")}},
 								},
 							},
 							{
 								Name: []byte("deprecated"),
 								Arguments: []*schema.DirectiveArgument{
-									{Name: []byte("reason"), Value: []byte(`"Will be removed"`)},
+									{Name: []byte("reason"), Value: &query.StringValue{Value: []byte("Will be removed")}},
 								},
 							},
 						},
@@ -2179,7 +2199,7 @@ func TestParser_Parse(t *testing.T) {
 							{
 								Name: []byte("specifiedBy"),
 								Arguments: []*schema.DirectiveArgument{
-									{Name: []byte("url"), Value: []byte(`"https://example.com/url-spec"`)},
+									{Name: []byte("url"), Value: &query.StringValue{Value: []byte("https://example.com/url-spec")}},
 								},
 							},
 						},
@@ -2206,13 +2226,13 @@ func TestParser_Parse(t *testing.T) {
 							{
 								Name: []byte("specifiedBy"),
 								Arguments: []*schema.DirectiveArgument{
-									{Name: []byte("url"), Value: []byte(`"https://example.com/json-spec"`)},
+									{Name: []byte("url"), Value: &query.StringValue{Value: []byte("https://example.com/json-spec")}},
 								},
 							},
 							{
 								Name: []byte("deprecated"),
 								Arguments: []*schema.DirectiveArgument{
-									{Name: []byte("reason"), Value: []byte(`"Prefer using JSON2"`)},
+									{Name: []byte("reason"), Value: &query.StringValue{Value: []byte("Prefer using JSON2")}},
 								},
 							},
 						},
@@ -2621,7 +2641,7 @@ func TestParser_Parse(t *testing.T) {
 				},
 			},
 		}, {
-			name: "Parse comment out",
+			name: "Parse comment out and description",
 			input: []byte(`type User {
 				# ID
 				id: ID!
@@ -2651,7 +2671,8 @@ func TestParser_Parse(t *testing.T) {
 								Directives: []*schema.Directive{},
 							},
 							{
-								Name: []byte("hoge"),
+								Name:        []byte("hoge"),
+								Description: []byte("hoge"),
 								Type: &schema.FieldType{
 									Name:     []byte("String"),
 									Nullable: true,
@@ -2673,6 +2694,10 @@ func TestParser_Parse(t *testing.T) {
 		schema.InputDefinition{},
 	}
 
+	ignoreLoc := cmp.FilterPath(func(p cmp.Path) bool {
+		return p.Last().String() == ".Loc"
+	}, cmp.Ignore())
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.isSkip {
@@ -2691,9 +2716,187 @@ func TestParser_Parse(t *testing.T) {
 				return
 			}
 
-			if diff := cmp.Diff(got, tt.want, cmpopts.IgnoreUnexported(ignores...), cmpopts.IgnoreFields(schema.Schema{}, "Indexes")); diff != "" {
+			if diff := cmp.Diff(got, tt.want, cmpopts.IgnoreUnexported(ignores...), cmpopts.IgnoreFields(schema.Schema{}, "Indexes"), ignoreLoc); diff != "" {
 				t.Errorf("Parse() mismatch (-got +want):\n%s", diff)
 			}
 		})
 	}
 }
+
+func TestParser_ParseSources(t *testing.T) {
+	tests := []struct {
+		name     string
+		sources  []*schema.Source
+		loc      func(s *schema.Schema) *schema.Loc
+		expected *schema.Loc
+	}{
+		{
+			name: "Type definition spans from its keyword to its closing brace",
+			sources: []*schema.Source{
+				{Name: "user.graphql", Body: []byte("type User {\n  id: ID!\n  name: String\n}\n")},
+			},
+			loc: func(s *schema.Schema) *schema.Loc {
+				return s.Types[0].Loc
+			},
+			expected: &schema.Loc{
+				Source: "user.graphql",
+				Start:  schema.Position{Line: 1, Column: 1, Offset: 0},
+				End:    schema.Position{Line: 4, Column: 2, Offset: 38},
+			},
+		},
+		{
+			name: "Field definition is located in the source it's read from",
+			sources: []*schema.Source{
+				{Name: "user.graphql", Body: []byte("type User {\n  id: ID!\n}\n")},
+				{Name: "query.graphql", Body: []byte("type Query {\n  user(id: ID!): User @deprecated\n}\n")},
+			},
+			loc: func(s *schema.Schema) *schema.Loc {
+				return s.Operations[0].Fields[0].Loc
+			},
+			expected: &schema.Loc{
+				Source: "query.graphql",
+				Start:  schema.Position{Line: 2, Column: 3, Offset: 15},
+				End:    schema.Position{Line: 2, Column: 34, Offset: 46},
+			},
+		},
+		{
+			name: "Argument definition spans its type",
+			sources: []*schema.Source{
+				{Name: "query.graphql", Body: []byte("type Query {\n  user(id: ID!): User\n}\n")},
+			},
+			loc: func(s *schema.Schema) *schema.Loc {
+				return s.Operations[0].Fields[0].Arguments[0].Loc
+			},
+			expected: &schema.Loc{
+				Source: "query.graphql",
+				Start:  schema.Position{Line: 2, Column: 8, Offset: 20},
+				End:    schema.Position{Line: 2, Column: 15, Offset: 27},
+			},
+		},
+		{
+			name: "List type spans its brackets",
+			sources: []*schema.Source{
+				{Name: "user.graphql", Body: []byte("type User {\n  tags: [String!]!\n}\n")},
+			},
+			loc: func(s *schema.Schema) *schema.Loc {
+				return s.Types[0].Fields[0].Type.Loc
+			},
+			expected: &schema.Loc{
+				Source: "user.graphql",
+				Start:  schema.Position{Line: 2, Column: 9, Offset: 20},
+				End:    schema.Position{Line: 2, Column: 19, Offset: 30},
+			},
+		},
+		{
+			name: "Enum value after a line break",
+			sources: []*schema.Source{
+				{Name: "role.graphql", Body: []byte("enum Role {\n  ADMIN\n  USER\n}\n")},
+			},
+			loc: func(s *schema.Schema) *schema.Loc {
+				return s.Enums[0].Values[1].Loc
+			},
+			expected: &schema.Loc{
+				Source: "role.graphql",
+				Start:  schema.Position{Line: 3, Column: 3, Offset: 22},
+				End:    schema.Position{Line: 3, Column: 7, Offset: 26},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexer := schema.NewLexer()
+			parser := schema.NewParser(lexer)
+			got, err := parser.ParseSources(tt.sources...)
+			if err != nil {
+				t.Fatalf("ParseSources() error %v", err)
+			}
+
+			if diff := cmp.Diff(tt.loc(got), tt.expected); diff != "" {
+				t.Errorf("ParseSources() location mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParser_Parse_Description(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       []byte
+		description func(s *schema.Schema) []byte
+		expected    string
+	}{
+		{
+			name:  "Block string description of a type has its common indentation removed",
+			input: []byte("\"\"\"\n  A user.\n    Indented\n\"\"\"\ntype User {\n  id: ID!\n}\n"),
+			description: func(s *schema.Schema) []byte {
+				return s.Types[0].Description
+			},
+			expected: "A user.\n  Indented",
+		},
+		{
+			name:  "String description of a field",
+			input: []byte("type User {\n  \"The \\\"name\\\" of the user\"\n  name: String\n}\n"),
+			description: func(s *schema.Schema) []byte {
+				return s.Types[0].Fields[0].Description
+			},
+			expected: `The "name" of the user`,
+		},
+		{
+			name:  "Description of an argument of a root field",
+			input: []byte("\"Root query\"\ntype Query {\n  posts(\"Max number of posts\" first: Int = 10): [String!]!\n}\n"),
+			description: func(s *schema.Schema) []byte {
+				return s.Operations[0].Fields[0].Arguments[0].Description
+			},
+			expected: "Max number of posts",
+		},
+		{
+			name:  "Description of an enum value",
+			input: []byte("enum Role {\n  \"Administrator\" ADMIN\n  USER\n}\n"),
+			description: func(s *schema.Schema) []byte {
+				return s.Enums[0].Values[0].Description
+			},
+			expected: "Administrator",
+		},
+		{
+			name:  "Description of an input field",
+			input: []byte("input Filter {\n  \"\"\"Status of posts\"\"\"\n  status: String = \"DRAFT\"\n}\n"),
+			description: func(s *schema.Schema) []byte {
+				return s.Inputs[0].Fields[0].Description
+			},
+			expected: "Status of posts",
+		},
+		{
+			name:  "Description of a definition after a union",
+			input: []byte("union Result = User | Post\n\"A date\"\nscalar Date\n"),
+			description: func(s *schema.Schema) []byte {
+				return s.Scalars[0].Description
+			},
+			expected: "A date",
+		},
+		{
+			name:  "Description of a directive and its argument",
+			input: []byte("\"Caches the field\"\ndirective @cache(\"Seconds to cache\" maxAge: Int) on FIELD_DEFINITION\n"),
+			description: func(s *schema.Schema) []byte {
+				d := s.Directives[len(s.Directives)-1]
+				return []byte(string(d.Description) + ", " + string(d.Arguments[0].Description))
+			},
+			expected: "Caches the field, Seconds to cache",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexer := schema.NewLexer()
+			parser := schema.NewParser(lexer)
+			got, err := parser.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse() error %v", err)
+			}
+
+			if diff := cmp.Diff(string(tt.description(got)), tt.expected); diff != "" {
+				t.Errorf("Parse() description mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
@@ -2,8 +2,12 @@ package schema
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"reflect"
+
+	"github.com/n9te9/goliteql/query"
 )
 
 type OperationType string
@@ -33,12 +37,14 @@ type CompositeType interface {
 
 type TypeDefinition struct {
 	Name []byte
+	Description []byte
 	Fields FieldDefinitions
 	Required map[*FieldDefinition]struct{}
 	tokens Tokens
 	Interfaces []*InterfaceDefinition
 	Directives []*Directive
 	Extentions []*TypeDefinition
+	Loc *Loc
 }
 
 func (t *TypeDefinition) IsPremitive() bool {
@@ -64,6 +70,7 @@ type FieldType struct {
 	Nullable bool
 	IsList bool
 	ListType *FieldType
+	Loc *Loc
 }
 
 func (f *FieldType) GetPremitiveType() *FieldType {
@@ -74,12 +81,44 @@ func (f *FieldType) GetPremitiveType() *FieldType {
 	return f
 }
 
+// String returns f in the syntax of GraphQL, such as [ID!]!.
+func (f *FieldType) String() string {
+	s := string(f.Name)
+	if f.IsList {
+		s = "[" + f.ListType.String() + "]"
+	}
+
+	if !f.Nullable {
+		s += "!"
+	}
+
+	return s
+}
+
+// NewFieldType returns t, the type of a variable in a query, as a type of the schema,
+// so that it can be compared with the types of arguments and input fields.
+func NewFieldType(t *query.FieldType) *FieldType {
+	res := &FieldType{
+		Name:     t.Name,
+		Nullable: t.Nullable,
+		IsList:   t.IsList,
+	}
+
+	if t.ListType != nil {
+		res.ListType = NewFieldType(t.ListType)
+	}
+
+	return res
+}
+
 
 type OperationDefinition struct {
 	OperationType OperationType
 	Name []byte
+	Description []byte
 	Fields FieldDefinitions
 	Extentions []*OperationDefinition
+	Loc *Loc
 }
 
 func (o *OperationDefinition) GetFieldByName(name []byte) *FieldDefinition {
@@ -144,6 +183,9 @@ type Schema struct {
 	Scalars []*ScalarDefinition
 
 	Indexes *Indexes
+
+	// scalarValidators validates the JSON values of the custom scalars registered with RegisterScalar.
+	scalarValidators map[string]func(json.RawMessage) error
 }
 
 
@@ -199,7 +241,9 @@ func (s *Schema) mergeOperation(newSchema *Schema) error {
 		newOp := new(OperationDefinition)
 		newOp.OperationType = t.OperationType
 		newOp.Name = t.Name
+		newOp.Description = t.Description
 		newOp.Fields = t.Fields
+		newOp.Loc = t.Loc
 
 		field, err := s.digOperation(string(newOp.Name), t.Extentions)
 		if err != nil {
@@ -251,9 +295,11 @@ func (s *Schema) mergeTypeDefinition(newSchema *Schema) error {
 	for _, t := range s.Types {
 		newType := new(TypeDefinition)
 		newType.Name = t.Name
+		newType.Description = t.Description
 		newType.Fields = t.Fields
 		newType.Interfaces = t.Interfaces
 		newType.Directives = t.Directives
+		newType.Loc = t.Loc
 
 		newFields := make(FieldDefinitions, 0)
 
@@ -307,8 +353,10 @@ func (s *Schema) mergeInterfaceDefinition(newSchema *Schema) error {
 	for _, t := range s.Interfaces {
 		newInterface := new(InterfaceDefinition)
 		newInterface.Name = t.Name
+		newInterface.Description = t.Description
 		newInterface.Fields = t.Fields
 		newInterface.Directives = t.Directives
+		newInterface.Loc = t.Loc
 
 		newFields := make(FieldDefinitions, 0)
 
@@ -365,8 +413,10 @@ func (s *Schema) mergeUnionDefinition(newSchema *Schema) error {
 	for _, t := range s.Unions {
 		newUnion := new(UnionDefinition)
 		newUnion.Name = t.Name
+		newUnion.Description = t.Description
 		newUnion.Types = t.Types
 		newUnion.Directives = t.Directives
+		newUnion.Loc = t.Loc
 
 		newSchema.Unions = append(newSchema.Unions, newUnion)
 
@@ -418,8 +468,10 @@ func (s *Schema) mergeEnumDefinition(newSchema *Schema) error {
 	for _, enum := range s.Enums {
 		newEnum := new(EnumDefinition)
 		newEnum.Name = enum.Name
+		newEnum.Description = enum.Description
 		newEnum.Directives = enum.Directives
 		newEnum.Values = enum.Values
+		newEnum.Loc = enum.Loc
 
 		newSchema.Enums = append(newSchema.Enums, newEnum)
 		if len(enum.Extentions) > 0 {
@@ -461,7 +513,9 @@ func (s *Schema) mergeInputDefinition(newSchema *Schema) error {
 	for _, input := range s.Inputs {
 		newInput := new(InputDefinition)
 		newInput.Name = input.Name
+		newInput.Description = input.Description
 		newInput.Fields = input.Fields
+		newInput.Loc = input.Loc
 
 		newFields := make(FieldDefinitions, 0)
 
@@ -495,7 +549,9 @@ func (s *Schema) Merge() (*Schema, error) {
 	newSchema.tokens = s.tokens
 	newSchema.Indexes = s.Indexes
 	newSchema.Directives = s.Directives
-	
+	newSchema.Scalars = s.Scalars
+	newSchema.scalarValidators = maps.Clone(s.scalarValidators)
+
 	if err := s.mergeOperation(newSchema); err != nil {
 		return nil, err
 	}
@@ -595,7 +651,9 @@ func get[T DefinitionType](indexes *Indexes, key string, t T) T {
 
 type ScalarDefinition struct {
 	Name []byte
+	Description []byte
 	Directives []*Directive
+	Loc *Loc
 }
 
 type SchemaDefinition struct {
@@ -605,4 +663,5 @@ type SchemaDefinition struct {
 	Extentions []*SchemaDefinition
 
 	Directives []*Directive
+	Loc *Loc
 }
\ No newline at end of file
@@ -2,9 +2,11 @@ package schema_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/n9te9/goliteql/query"
 	"github.com/n9te9/goliteql/schema"
 )
 
@@ -56,7 +58,7 @@ func TestSchema_Merge(t *testing.T) {
 											Nullable: false,
 											IsList:   false,
 										},
-										Default: []byte("false"),
+										Default: &query.BooleanValue{Value: false},
 									},
 								},
 								Type: &schema.FieldType{
@@ -138,7 +140,7 @@ func TestSchema_Merge(t *testing.T) {
 											Nullable: false,
 											IsList:   false,
 										},
-										Default: []byte("false"),
+										Default: &query.BooleanValue{Value: false},
 									},
 								},
 								Type: &schema.FieldType{
@@ -495,7 +497,7 @@ func TestSchema_Merge(t *testing.T) {
 									Nullable: false,
 									IsList:   false,
 								},
-								Default:    []byte("false"),
+								Default:    &query.BooleanValue{Value: false},
 								Directives: []*schema.Directive{},
 								Location: &schema.Location{
 									Name: []byte("INPUT_FIELD_DEFINITION"),
@@ -539,6 +541,10 @@ func TestSchema_Merge(t *testing.T) {
 		schema.Indexes{},
 	}
 
+	ignoreLoc := cmp.FilterPath(func(p cmp.Path) bool {
+		return p.Last().String() == ".Loc"
+	}, cmp.Ignore())
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			lexer := schema.NewLexer()
@@ -555,9 +561,107 @@ func TestSchema_Merge(t *testing.T) {
 				t.Errorf("got error %v, want %v", err, tt.wantErr)
 			}
 
-			if diff := cmp.Diff(got, tt.want, cmpopts.IgnoreUnexported(ignores...), cmpopts.IgnoreFields(schema.Schema{}, "Indexes")); diff != "" {
+			if diff := cmp.Diff(got, tt.want, cmpopts.IgnoreUnexported(ignores...), cmpopts.IgnoreFields(schema.Schema{}, "Indexes"), ignoreLoc); diff != "" {
 				t.Errorf("Parse() mismatch (-got +want):\n%s", diff)
 			}
 		})
 	}
 }
+
+func TestNewFieldType(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *query.FieldType
+		want  string
+	}{
+		{
+			name:  "Named type",
+			input: &query.FieldType{Name: []byte("ID"), Nullable: true},
+			want:  "ID",
+		},
+		{
+			name: "Non-null list of non-null items",
+			input: &query.FieldType{
+				IsList:   true,
+				ListType: &query.FieldType{Name: []byte("Post")},
+			},
+			want: "[Post!]!",
+		},
+		{
+			name: "Nested lists",
+			input: &query.FieldType{
+				IsList:   true,
+				Nullable: true,
+				ListType: &query.FieldType{
+					IsList:   true,
+					Nullable: true,
+					ListType: &query.FieldType{Name: []byte("Int"), Nullable: true},
+				},
+			},
+			want: "[[Int]]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(schema.NewFieldType(tt.input).String(), tt.want); diff != "" {
+				t.Errorf("String() mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRegisterScalar(t *testing.T) {
+	source := []byte(`scalar DateTime
+
+type Query {
+	posts(since: DateTime): [ID!]!
+}`)
+	since := schema.MustParse(source).GetQuery().GetFieldByName([]byte("posts")).Arguments[0]
+
+	registered := schema.MustParse(source)
+	if err := schema.RegisterScalar[time.Time](registered, "DateTime"); err != nil {
+		t.Fatalf("RegisterScalar() error %v", err)
+	}
+
+	if err := schema.RegisterScalar[time.Time](registered, "String"); err == nil || err.Error() != "error registering scalar String: built-in scalars can't be overridden" {
+		t.Errorf("RegisterScalar() error = %v, want an error overriding a built-in scalar", err)
+	}
+
+	tests := []struct {
+		name    string
+		schema  *schema.Schema
+		value   query.InputValue
+		wantErr string
+	}{
+		{
+			name:   "Values of a registered scalar are decoded into its Go type",
+			schema: registered,
+			value:  &query.StringValue{Value: []byte("2024-01-01T00:00:00Z")},
+		},
+		{
+			name:    "Values which can't be decoded into the Go type are rejected",
+			schema:  registered,
+			value:   &query.StringValue{Value: []byte("yesterday")},
+			wantErr: `error validating value for argument since: expected DateTime value, got "yesterday"`,
+		},
+		{
+			name:   "Scalars registered to another schema aren't validated",
+			schema: schema.MustParse(source),
+			value:  &query.StringValue{Value: []byte("yesterday")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			if err := since.ValidateValueType(tt.schema, tt.value); err != nil {
+				got = err.Error()
+			}
+
+			if diff := cmp.Diff(got, tt.wantErr); diff != "" {
+				t.Errorf("ValidateValueType() mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
@@ -4,9 +4,11 @@ import "bytes"
 
 type UnionDefinition struct {
 	Name []byte
+	Description []byte
 	Types [][]byte
 	Extentions []*UnionDefinition
 	Directives []*Directive
+	Loc *Loc
 }
 
 func (u *UnionDefinition) GetFieldByName(name []byte) *FieldDefinition {
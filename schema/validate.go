@@ -0,0 +1,531 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// String returns the start of l as "source:line:column", or "line:column" if l has no source.
+func (l *Loc) String() string {
+	if l.Source == "" {
+		return fmt.Sprintf("%d:%d", l.Start.Line, l.Start.Column)
+	}
+
+	return fmt.Sprintf("%s:%d:%d", l.Source, l.Start.Line, l.Start.Column)
+}
+
+// ValidationError is a problem of a schema found by Validate in the definition located at Loc.
+// Loc is nil for the definitions which aren't parsed from a source, such as the built-in directives.
+type ValidationError struct {
+	Message string
+	Loc     *Loc
+}
+
+func (e *ValidationError) Error() string {
+	if e.Loc == nil {
+		return e.Message
+	}
+
+	return fmt.Sprintf("%s: %s", e.Loc, e.Message)
+}
+
+func newValidationError(loc *Loc, format string, args ...any) error {
+	return &ValidationError{Message: fmt.Sprintf(format, args...), Loc: loc}
+}
+
+// typeKind is the kind of a named type, as __TypeKind of introspection.
+type typeKind string
+
+const (
+	undefinedKind   typeKind = ""
+	scalarKind      typeKind = "scalar"
+	objectKind      typeKind = "object"
+	interfaceKind   typeKind = "interface"
+	unionKind       typeKind = "union"
+	enumKind        typeKind = "enum"
+	inputObjectKind typeKind = "input object"
+)
+
+// article returns k with its indefinite article, such as "an object".
+func (k typeKind) article() string {
+	switch k {
+	case objectKind, interfaceKind, enumKind, inputObjectKind:
+		return "an " + string(k)
+	}
+
+	return "a " + string(k)
+}
+
+func (k typeKind) isOutputType() bool {
+	return k == scalarKind || k == objectKind || k == interfaceKind || k == unionKind || k == enumKind
+}
+
+func (k typeKind) isInputType() bool {
+	return k == scalarKind || k == enumKind || k == inputObjectKind
+}
+
+var builtinScalars = []string{"String", "Int", "Float", "Boolean", "ID"}
+
+// Validate validates s, a merged schema, with the type system validation rules of the specification.
+// The returned error joins a *ValidationError for every problem found, so that all of them are reported at once.
+func Validate(s *Schema) error {
+	rules := []func(s *Schema) []error{
+		validateTypeNames,
+		validateRootOperationTypes,
+		validateObjectTypes,
+		validateInterfaceImplementations,
+		validateUnionTypes,
+		validateEnumTypes,
+		validateInputObjectTypes,
+		validateDirectiveDefinitions,
+	}
+
+	errs := make([]error, 0)
+	for _, rule := range rules {
+		errs = append(errs, rule(s)...)
+	}
+
+	return errors.Join(errs...)
+}
+
+// kindOf returns the kind of the type named name in s, or undefinedKind if s doesn't define it.
+func kindOf(s *Schema, name string) typeKind {
+	for _, scalar := range builtinScalars {
+		if scalar == name {
+			return scalarKind
+		}
+	}
+
+	for _, scalar := range s.Scalars {
+		if string(scalar.Name) == name {
+			return scalarKind
+		}
+	}
+
+	for _, op := range s.Operations {
+		if string(operationTypeName(op)) == name {
+			return objectKind
+		}
+	}
+
+	switch {
+	case s.Indexes.TypeIndex[name] != nil:
+		return objectKind
+	case s.Indexes.InterfaceIndex[name] != nil:
+		return interfaceKind
+	case s.Indexes.UnionIndex[name] != nil:
+		return unionKind
+	case s.Indexes.EnumIndex[name] != nil:
+		return enumKind
+	case s.Indexes.InputIndex[name] != nil:
+		return inputObjectKind
+	}
+
+	return undefinedKind
+}
+
+// operationTypeName returns the name of the type of op, which is named by its operation type such as Query.
+func operationTypeName(op *OperationDefinition) []byte {
+	switch op.OperationType {
+	case MutationOperation:
+		return []byte("Mutation")
+	case SubscriptionOperation:
+		return []byte("Subscription")
+	}
+
+	return []byte("Query")
+}
+
+func isReservedName(name []byte) bool {
+	return strings.HasPrefix(string(name), "__")
+}
+
+// validateTypeNames validates that every named type is defined once, and that no name is reserved by introspection.
+func validateTypeNames(s *Schema) []error {
+	errs := make([]error, 0)
+	defined := make(map[string]struct{})
+	for _, scalar := range builtinScalars {
+		defined[scalar] = struct{}{}
+	}
+
+	validate := func(name []byte, loc *Loc) {
+		if isReservedName(name) {
+			errs = append(errs, newValidationError(loc, "name %s must not begin with \"__\", which is reserved by introspection", name))
+		}
+
+		if _, ok := defined[string(name)]; ok {
+			errs = append(errs, newValidationError(loc, "there can be only one type named %s", name))
+		}
+		defined[string(name)] = struct{}{}
+	}
+
+	for _, op := range s.Operations {
+		validate(operationTypeName(op), op.Loc)
+	}
+	for _, t := range s.Types {
+		validate(t.Name, t.Loc)
+	}
+	for _, i := range s.Interfaces {
+		validate(i.Name, i.Loc)
+	}
+	for _, u := range s.Unions {
+		validate(u.Name, u.Loc)
+	}
+	for _, e := range s.Enums {
+		validate(e.Name, e.Loc)
+	}
+	for _, input := range s.Inputs {
+		validate(input.Name, input.Loc)
+	}
+	for _, scalar := range s.Scalars {
+		validate(scalar.Name, scalar.Loc)
+	}
+
+	return errs
+}
+
+// validateRootOperationTypes validates that the root operation types of the schema definition are object types.
+// The schema definition isn't validated unless it's written in the schema, as its root operation types are optional then.
+func validateRootOperationTypes(s *Schema) []error {
+	if s.Definition == nil || s.Definition.Loc == nil {
+		return nil
+	}
+
+	errs := make([]error, 0)
+	roots := []struct {
+		operation OperationType
+		name      []byte
+	}{
+		{QueryOperation, s.Definition.Query},
+		{MutationOperation, s.Definition.Mutation},
+		{SubscriptionOperation, s.Definition.Subscription},
+	}
+
+	for _, root := range roots {
+		if len(root.name) == 0 {
+			continue
+		}
+
+		if kind := kindOf(s, string(root.name)); kind != objectKind {
+			errs = append(errs, newValidationError(s.Definition.Loc, "%s root type %s must be an object type", root.operation, root.name))
+		}
+	}
+
+	return errs
+}
+
+// validateObjectTypes validates the fields of object types, root operation types and interfaces:
+// every one of them defines at least one field, and the types of their fields are defined output types
+// with arguments of defined input types.
+func validateObjectTypes(s *Schema) []error {
+	errs := make([]error, 0)
+	for _, op := range s.Operations {
+		errs = append(errs, validateFields(s, objectKind, operationTypeName(op), op.Fields, op.Loc)...)
+	}
+	for _, t := range s.Types {
+		errs = append(errs, validateFields(s, objectKind, t.Name, t.Fields, t.Loc)...)
+	}
+	for _, i := range s.Interfaces {
+		errs = append(errs, validateFields(s, interfaceKind, i.Name, i.Fields, i.Loc)...)
+	}
+
+	return errs
+}
+
+func validateFields(s *Schema, kind typeKind, typeName []byte, fields FieldDefinitions, loc *Loc) []error {
+	if len(fields) == 0 {
+		return []error{newValidationError(loc, "%s type %s must define one or more fields", kind, typeName)}
+	}
+
+	errs := make([]error, 0)
+	for _, f := range fields {
+		if isReservedName(f.Name) {
+			errs = append(errs, newValidationError(f.Loc, "field %s.%s must not begin with \"__\", which is reserved by introspection", typeName, f.Name))
+		}
+
+		name := f.Type.GetPremitiveType().Name
+		switch fieldKind := kindOf(s, string(name)); {
+		case fieldKind == undefinedKind:
+			errs = append(errs, newValidationError(f.Loc, "type %s of field %s.%s is not defined", name, typeName, f.Name))
+		case !fieldKind.isOutputType():
+			errs = append(errs, newValidationError(f.Loc, "field %s.%s must be of an output type, but %s is %s type", typeName, f.Name, name, fieldKind.article()))
+		}
+
+		errs = append(errs, validateArguments(s, fmt.Sprintf("field %s.%s", typeName, f.Name), f.Arguments)...)
+	}
+
+	return errs
+}
+
+// validateArguments validates that the arguments of owner, such as "field Query.post", have unique names
+// which aren't reserved, and are of defined input types.
+func validateArguments(s *Schema, owner string, args []*ArgumentDefinition) []error {
+	errs := make([]error, 0)
+	defined := make(map[string]struct{})
+	for _, arg := range args {
+		if isReservedName(arg.Name) {
+			errs = append(errs, newValidationError(arg.Loc, "argument %s of %s must not begin with \"__\", which is reserved by introspection", arg.Name, owner))
+		}
+
+		if _, ok := defined[string(arg.Name)]; ok {
+			errs = append(errs, newValidationError(arg.Loc, "there can be only one argument named %s of %s", arg.Name, owner))
+		}
+		defined[string(arg.Name)] = struct{}{}
+
+		name := arg.Type.GetPremitiveType().Name
+		switch argKind := kindOf(s, string(name)); {
+		case argKind == undefinedKind:
+			errs = append(errs, newValidationError(arg.Loc, "type %s of argument %s of %s is not defined", name, arg.Name, owner))
+		case !argKind.isInputType():
+			errs = append(errs, newValidationError(arg.Loc, "argument %s of %s must be of an input type, but %s is %s type", arg.Name, owner, name, argKind.article()))
+		}
+	}
+
+	return errs
+}
+
+// validateInterfaceImplementations validates that every object type implements the fields of its interfaces,
+// as described in "IsValidImplementation" of the specification.
+func validateInterfaceImplementations(s *Schema) []error {
+	errs := make([]error, 0)
+	for _, t := range s.Types {
+		for _, implemented := range t.Interfaces {
+			// the interfaces of a type are resolved when the type is parsed, before their extensions are merged
+			i := s.Indexes.InterfaceIndex[string(implemented.Name)]
+			if i == nil {
+				errs = append(errs, newValidationError(t.Loc, "type %s must implement only interfaces, but %s is not an interface", t.Name, implemented.Name))
+				continue
+			}
+
+			for _, interfaceField := range i.Fields {
+				f := t.GetFieldByName(interfaceField.Name)
+				if f == nil {
+					errs = append(errs, newValidationError(t.Loc, "type %s must define field %s of interface %s", t.Name, interfaceField.Name, i.Name))
+					continue
+				}
+
+				if !isValidImplementationFieldType(s, f.Type, interfaceField.Type) {
+					errs = append(errs, newValidationError(f.Loc, "field %s.%s of type %s must be a subtype of %s, the type of the field of interface %s", t.Name, f.Name, f.Type, interfaceField.Type, i.Name))
+				}
+
+				errs = append(errs, validateImplementationArguments(t, f, i, interfaceField)...)
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateImplementationArguments validates that f of t defines the arguments of interfaceField of i with the same types,
+// and that its additional arguments are optional.
+func validateImplementationArguments(t *TypeDefinition, f *FieldDefinition, i *InterfaceDefinition, interfaceField *FieldDefinition) []error {
+	errs := make([]error, 0)
+	for _, interfaceArg := range interfaceField.Arguments {
+		arg := findArgument(f.Arguments, interfaceArg.Name)
+		if arg == nil {
+			errs = append(errs, newValidationError(f.Loc, "field %s.%s must define argument %s of interface %s", t.Name, f.Name, interfaceArg.Name, i.Name))
+			continue
+		}
+
+		if arg.Type.String() != interfaceArg.Type.String() {
+			errs = append(errs, newValidationError(arg.Loc, "argument %s of field %s.%s must be of type %s as defined by interface %s, but it's of type %s", arg.Name, t.Name, f.Name, interfaceArg.Type, i.Name, arg.Type))
+		}
+	}
+
+	for _, arg := range f.Arguments {
+		if findArgument(interfaceField.Arguments, arg.Name) == nil && !arg.Type.Nullable && arg.Default == nil {
+			errs = append(errs, newValidationError(arg.Loc, "argument %s of field %s.%s must not be required, as interface %s doesn't define it", arg.Name, t.Name, f.Name, i.Name))
+		}
+	}
+
+	return errs
+}
+
+func findArgument(args []*ArgumentDefinition, name []byte) *ArgumentDefinition {
+	for _, arg := range args {
+		if string(arg.Name) == string(name) {
+			return arg
+		}
+	}
+
+	return nil
+}
+
+// isValidImplementationFieldType reports whether fieldType, the type of a field of an object type,
+// is a subtype of implementedType, the type of the field of an interface, as described in the specification.
+func isValidImplementationFieldType(s *Schema, fieldType, implementedType *FieldType) bool {
+	if fieldType.Nullable && !implementedType.Nullable {
+		return false
+	}
+
+	if fieldType.IsList || implementedType.IsList {
+		return fieldType.IsList && implementedType.IsList && isValidImplementationFieldType(s, fieldType.ListType, implementedType.ListType)
+	}
+
+	if string(fieldType.Name) == string(implementedType.Name) {
+		return true
+	}
+
+	if u := s.Indexes.UnionIndex[string(implementedType.Name)]; u != nil {
+		return u.HasType(string(fieldType.Name))
+	}
+
+	if t := s.Indexes.TypeIndex[string(fieldType.Name)]; t != nil {
+		for _, i := range t.Interfaces {
+			if string(i.Name) == string(implementedType.Name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// validateUnionTypes validates that every union has one or more members, which are distinct object types.
+func validateUnionTypes(s *Schema) []error {
+	errs := make([]error, 0)
+	for _, u := range s.Unions {
+		if len(u.Types) == 0 {
+			errs = append(errs, newValidationError(u.Loc, "union %s must have one or more member types", u.Name))
+		}
+
+		members := make(map[string]struct{})
+		for _, member := range u.Types {
+			if _, ok := members[string(member)]; ok {
+				errs = append(errs, newValidationError(u.Loc, "union %s can include %s only once", u.Name, member))
+			}
+			members[string(member)] = struct{}{}
+
+			switch kind := kindOf(s, string(member)); kind {
+			case undefinedKind:
+				errs = append(errs, newValidationError(u.Loc, "member %s of union %s is not defined", member, u.Name))
+			case objectKind:
+			default:
+				errs = append(errs, newValidationError(u.Loc, "member %s of union %s must be an object type, but it's %s type", member, u.Name, kind.article()))
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateEnumTypes validates that every enum has one or more values with distinct names,
+// which aren't true, false, null or reserved by introspection.
+func validateEnumTypes(s *Schema) []error {
+	errs := make([]error, 0)
+	for _, e := range s.Enums {
+		if len(e.Values) == 0 {
+			errs = append(errs, newValidationError(e.Loc, "enum %s must define one or more values", e.Name))
+		}
+
+		values := make(map[string]struct{})
+		for _, v := range e.Values {
+			switch string(v.Name) {
+			case "true", "false", "null":
+				errs = append(errs, newValidationError(v.Loc, "enum %s must not define value %s", e.Name, v.Name))
+			}
+
+			if isReservedName(v.Name) {
+				errs = append(errs, newValidationError(v.Loc, "value %s of enum %s must not begin with \"__\", which is reserved by introspection", v.Name, e.Name))
+			}
+
+			if _, ok := values[string(v.Name)]; ok {
+				errs = append(errs, newValidationError(v.Loc, "enum %s can define value %s only once", e.Name, v.Name))
+			}
+			values[string(v.Name)] = struct{}{}
+		}
+	}
+
+	return errs
+}
+
+// validateInputObjectTypes validates that every input object has one or more fields of defined input types,
+// and that no input object references itself through non-null fields, as it can't be given then.
+func validateInputObjectTypes(s *Schema) []error {
+	errs := make([]error, 0)
+	for _, input := range s.Inputs {
+		if len(input.Fields) == 0 {
+			errs = append(errs, newValidationError(input.Loc, "input object type %s must define one or more fields", input.Name))
+		}
+
+		for _, f := range input.Fields {
+			if isReservedName(f.Name) {
+				errs = append(errs, newValidationError(f.Loc, "field %s.%s must not begin with \"__\", which is reserved by introspection", input.Name, f.Name))
+			}
+
+			name := f.Type.GetPremitiveType().Name
+			switch fieldKind := kindOf(s, string(name)); {
+			case fieldKind == undefinedKind:
+				errs = append(errs, newValidationError(f.Loc, "type %s of field %s.%s is not defined", name, input.Name, f.Name))
+			case !fieldKind.isInputType():
+				errs = append(errs, newValidationError(f.Loc, "field %s.%s must be of an input type, but %s is %s type", input.Name, f.Name, name, fieldKind.article()))
+			}
+		}
+	}
+
+	cyclic := make(map[string]struct{})
+	for _, input := range s.Inputs {
+		if _, ok := cyclic[string(input.Name)]; ok {
+			continue
+		}
+
+		path := findNonNullInputCycle(s, input, input, make(map[string]struct{}))
+		if path == nil {
+			continue
+		}
+
+		for _, f := range path {
+			cyclic[strings.Split(f, ".")[0]] = struct{}{}
+		}
+		errs = append(errs, newValidationError(input.Loc, "input object type %s must not reference itself through non-null fields %s", input.Name, strings.Join(path, ", ")))
+	}
+
+	return errs
+}
+
+// findNonNullInputCycle returns the non-null fields, such as "A.b", which lead from input to start,
+// or nil if there is no such path.
+func findNonNullInputCycle(s *Schema, start, input *InputDefinition, visited map[string]struct{}) []string {
+	visited[string(input.Name)] = struct{}{}
+
+	for _, f := range input.Fields {
+		if f.Type.Nullable || f.Type.IsList {
+			continue
+		}
+
+		field := fmt.Sprintf("%s.%s", input.Name, f.Name)
+		if string(f.Type.Name) == string(start.Name) {
+			return []string{field}
+		}
+
+		next := s.Indexes.InputIndex[string(f.Type.Name)]
+		if next == nil {
+			continue
+		}
+
+		if _, ok := visited[string(next.Name)]; ok {
+			continue
+		}
+
+		if path := findNonNullInputCycle(s, start, next, visited); path != nil {
+			return append([]string{field}, path...)
+		}
+	}
+
+	return nil
+}
+
+// validateDirectiveDefinitions validates that the arguments of every directive are of defined input types.
+func validateDirectiveDefinitions(s *Schema) []error {
+	errs := make([]error, 0)
+	for _, d := range s.Directives {
+		if isReservedName(d.Name) {
+			errs = append(errs, newValidationError(d.Loc, "directive @%s must not begin with \"__\", which is reserved by introspection", d.Name))
+		}
+
+		errs = append(errs, validateArguments(s, fmt.Sprintf("directive @%s", d.Name), d.Arguments)...)
+	}
+
+	return errs
+}
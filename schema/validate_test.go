@@ -0,0 +1,212 @@
+package schema_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/schema"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		sources  []*schema.Source
+		expected []string
+	}{
+		{
+			name: "Valid schema with interfaces, unions and inputs",
+			sources: []*schema.Source{
+				{Name: "schema.graphql", Body: []byte(`interface Node {
+	id: ID!
+	related: Node
+	children(first: Int): [Node]
+}
+
+type User implements Node {
+	id: ID!
+	related: User!
+	children(first: Int, after: String): [User!]!
+}
+
+type Post {
+	id: ID!
+}
+
+union SearchResult = User | Post
+
+input PostFilter {
+	author: AuthorFilter
+	tags: [String!]
+}
+
+input AuthorFilter {
+	post: PostFilter
+}
+
+type Query {
+	search(filter: PostFilter): [SearchResult!]!
+}`)},
+			},
+			expected: nil,
+		},
+		{
+			name: "Fields and arguments of undefined types",
+			sources: []*schema.Source{
+				{Name: "schema.graphql", Body: []byte(`type Query {
+	post(id: PostID!): Post
+}`)},
+			},
+			expected: []string{
+				"schema.graphql:2:2: type Post of field Query.post is not defined",
+				"schema.graphql:2:7: type PostID of argument id of field Query.post is not defined",
+			},
+		},
+		{
+			name: "Object types must implement the fields of their interfaces",
+			sources: []*schema.Source{
+				{Name: "schema.graphql", Body: []byte(`interface Node {
+	id: ID!
+	parent(depth: Int): Node!
+	name: String
+}
+
+type User implements Node {
+	id: ID
+	parent(deep: Boolean!): User!
+}
+
+type Query {
+	users: [User!]!
+}`)},
+			},
+			expected: []string{
+				"schema.graphql:8:2: field User.id of type ID must be a subtype of ID!, the type of the field of interface Node",
+				"schema.graphql:9:2: field User.parent must define argument depth of interface Node",
+				"schema.graphql:9:9: argument deep of field User.parent must not be required, as interface Node doesn't define it",
+				"schema.graphql:7:1: type User must define field name of interface Node",
+			},
+		},
+		{
+			name: "Union members must be object types",
+			sources: []*schema.Source{
+				{Name: "schema.graphql", Body: []byte(`type Post {
+	id: ID!
+}
+
+enum Status {
+	DRAFT
+}
+
+union SearchResult = Post | Status | Comment
+
+type Query {
+	search: [SearchResult!]!
+}`)},
+			},
+			expected: []string{
+				"schema.graphql:9:1: member Status of union SearchResult must be an object type, but it's an enum type",
+				"schema.graphql:9:1: member Comment of union SearchResult is not defined",
+			},
+		},
+		{
+			name: "Input and output types must not be mixed",
+			sources: []*schema.Source{
+				{Name: "schema.graphql", Body: []byte(`type Post {
+	id: ID!
+	filter: PostFilter
+}
+
+input PostFilter {
+	post: Post
+}
+
+type Query {
+	posts(post: Post): [Post!]!
+}`)},
+			},
+			expected: []string{
+				"schema.graphql:11:8: argument post of field Query.posts must be of an input type, but Post is an object type",
+				"schema.graphql:3:2: field Post.filter must be of an output type, but PostFilter is an input object type",
+				"schema.graphql:7:2: field PostFilter.post must be of an input type, but Post is an object type",
+			},
+		},
+		{
+			name: "Input objects must not reference themselves through non-null fields",
+			sources: []*schema.Source{
+				{Name: "schema.graphql", Body: []byte(`input PostFilter {
+	author: AuthorFilter!
+}
+
+input AuthorFilter {
+	post: PostFilter!
+}
+
+type Query {
+	posts(filter: PostFilter): [ID!]!
+}`)},
+			},
+			expected: []string{
+				"schema.graphql:1:1: input object type PostFilter must not reference itself through non-null fields PostFilter.author, AuthorFilter.post",
+			},
+		},
+		{
+			name: "Names must be unique",
+			sources: []*schema.Source{
+				{Name: "schema.graphql", Body: []byte(`type Post {
+	id: ID!
+}
+
+enum Post {
+	DRAFT
+	DRAFT
+	null
+}
+
+type Query {
+	posts: [Post!]!
+}`)},
+			},
+			expected: []string{
+				"schema.graphql:5:1: there can be only one type named Post",
+				"schema.graphql:7:2: enum Post can define value DRAFT only once",
+				"schema.graphql:8:2: enum Post must not define value null",
+			},
+		},
+		{
+			name: "Problems are located in the sources they are found in",
+			sources: []*schema.Source{
+				{Name: "user.graphql", Body: []byte("type User {\n\tposts: [Post!]!\n}\n")},
+				{Name: "query.graphql", Body: []byte("type Query {\n\tuser: User\n\tcomments: [Comment!]!\n}\n")},
+			},
+			expected: []string{
+				"query.graphql:3:2: type Comment of field Query.comments is not defined",
+				"user.graphql:2:2: type Post of field User.posts is not defined",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := schema.NewParser(schema.NewLexer())
+			s, err := parser.ParseSources(tt.sources...)
+			if err != nil {
+				t.Fatalf("ParseSources() error %v", err)
+			}
+
+			s, err = s.Merge()
+			if err != nil {
+				t.Fatalf("Merge() error %v", err)
+			}
+
+			var got []string
+			if err := schema.Validate(s); err != nil {
+				got = strings.Split(err.Error(), "\n")
+			}
+
+			if diff := cmp.Diff(got, tt.expected); diff != "" {
+				t.Errorf("Validate() mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
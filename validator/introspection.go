@@ -0,0 +1,132 @@
+package validator
+
+import (
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/schema"
+)
+
+// introspection holds the types of the introspection system, which every schema has implicitly,
+// so that the selections of introspection queries, such as the IntrospectionQuery of GraphiQL,
+// are validated as the selections of the types of the schema are.
+var introspection = newIntrospectionSchema()
+
+type introspectionSchema struct {
+	// rootFields are the introspection fields of the query root type besides __typename.
+	rootFields schema.FieldDefinitions
+	types      map[string]*schema.TypeDefinition
+	enums      map[string]struct{}
+}
+
+// lookupRootField returns the definition of the introspection field of the query root type named name,
+// or nil if there isn't any.
+func (i *introspectionSchema) lookupRootField(name []byte) *schema.FieldDefinition {
+	for _, f := range i.rootFields {
+		if string(f.Name) == string(name) {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// newIntrospectionSchema returns the introspection types of the specification, as they are answered by executor.Introspect.
+func newIntrospectionSchema() *introspectionSchema {
+	named := func(name string) *schema.FieldType {
+		return &schema.FieldType{Name: []byte(name), Nullable: true}
+	}
+
+	nonNull := func(t *schema.FieldType) *schema.FieldType {
+		nonNull := *t
+		nonNull.Nullable = false
+		return &nonNull
+	}
+
+	list := func(t *schema.FieldType) *schema.FieldType {
+		return &schema.FieldType{IsList: true, ListType: t, Nullable: true}
+	}
+
+	field := func(name string, fieldType *schema.FieldType, args ...*schema.ArgumentDefinition) *schema.FieldDefinition {
+		return &schema.FieldDefinition{Name: []byte(name), Type: fieldType, Arguments: args}
+	}
+
+	object := func(name string, fields ...*schema.FieldDefinition) *schema.TypeDefinition {
+		return &schema.TypeDefinition{Name: []byte(name), Fields: fields}
+	}
+
+	includeDeprecated := &schema.ArgumentDefinition{
+		Name:    []byte("includeDeprecated"),
+		Type:    named("Boolean"),
+		Default: &query.BooleanValue{Value: false},
+	}
+
+	types := []*schema.TypeDefinition{
+		object("__Schema",
+			field("description", named("String")),
+			field("types", nonNull(list(nonNull(named("__Type"))))),
+			field("queryType", nonNull(named("__Type"))),
+			field("mutationType", named("__Type")),
+			field("subscriptionType", named("__Type")),
+			field("directives", nonNull(list(nonNull(named("__Directive"))))),
+		),
+		object("__Type",
+			field("kind", nonNull(named("__TypeKind"))),
+			field("name", named("String")),
+			field("description", named("String")),
+			field("specifiedByURL", named("String")),
+			field("fields", list(nonNull(named("__Field"))), includeDeprecated),
+			field("interfaces", list(nonNull(named("__Type")))),
+			field("possibleTypes", list(nonNull(named("__Type")))),
+			field("enumValues", list(nonNull(named("__EnumValue"))), includeDeprecated),
+			field("inputFields", list(nonNull(named("__InputValue")))),
+			field("ofType", named("__Type")),
+		),
+		object("__Field",
+			field("name", nonNull(named("String"))),
+			field("description", named("String")),
+			field("args", nonNull(list(nonNull(named("__InputValue"))))),
+			field("type", nonNull(named("__Type"))),
+			field("isDeprecated", nonNull(named("Boolean"))),
+			field("deprecationReason", named("String")),
+		),
+		object("__InputValue",
+			field("name", nonNull(named("String"))),
+			field("description", named("String")),
+			field("type", nonNull(named("__Type"))),
+			field("defaultValue", named("String")),
+		),
+		object("__EnumValue",
+			field("name", nonNull(named("String"))),
+			field("description", named("String")),
+			field("isDeprecated", nonNull(named("Boolean"))),
+			field("deprecationReason", named("String")),
+		),
+		object("__Directive",
+			field("name", nonNull(named("String"))),
+			field("description", named("String")),
+			field("locations", nonNull(list(nonNull(named("__DirectiveLocation"))))),
+			field("args", nonNull(list(nonNull(named("__InputValue"))))),
+			field("isRepeatable", nonNull(named("Boolean"))),
+		),
+	}
+
+	res := &introspectionSchema{
+		rootFields: schema.FieldDefinitions{
+			field("__schema", nonNull(named("__Schema"))),
+			field("__type", named("__Type"), &schema.ArgumentDefinition{
+				Name: []byte("name"),
+				Type: nonNull(named("String")),
+			}),
+		},
+		types: make(map[string]*schema.TypeDefinition, len(types)),
+		enums: map[string]struct{}{
+			"__TypeKind":          {},
+			"__DirectiveLocation": {},
+		},
+	}
+
+	for _, t := range types {
+		res.types[string(t.Name)] = t
+	}
+
+	return res
+}
@@ -0,0 +1,896 @@
+package validator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/schema"
+)
+
+// UniqueOperationNames reports operations which have the name of another operation.
+func UniqueOperationNames(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	operations := make(map[string]*query.Operation)
+	for _, op := range doc.Operations {
+		if op.Name == "" {
+			continue
+		}
+
+		if first, ok := operations[op.Name]; ok {
+			errs = append(errs, newError(fmt.Errorf("there can be only one operation named %s", op.Name), first.Loc, op.Loc))
+			continue
+		}
+		operations[op.Name] = op
+	}
+
+	return errs
+}
+
+// LoneAnonymousOperation reports anonymous operations in a document which has more than one operation.
+func LoneAnonymousOperation(s *schema.Schema, doc *query.Document) []error {
+	if len(doc.Operations) < 2 {
+		return nil
+	}
+
+	errs := make([]error, 0)
+	for _, op := range doc.Operations {
+		if op.Name == "" {
+			errs = append(errs, newError(errors.New("anonymous operation must be the only defined operation"), op.Loc))
+		}
+	}
+
+	return errs
+}
+
+// KnownOperationTypes reports a document without operations and operations whose root type isn't defined in the schema.
+func KnownOperationTypes(s *schema.Schema, doc *query.Document) []error {
+	if len(doc.Operations) == 0 {
+		return []error{errors.New("query does not have an operation")}
+	}
+
+	errs := make([]error, 0)
+	for _, op := range doc.Operations {
+		if rootOperation(s, op) == nil {
+			errs = append(errs, newError(fmt.Errorf("schema does not have a %s operation", op.OperationType), op.Loc))
+		}
+	}
+
+	return errs
+}
+
+// SingleFieldSubscriptions reports subscriptions which select more than one root field or an introspection root field,
+// as a subscription is a stream of the events of a single root field.
+func SingleFieldSubscriptions(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	for _, op := range doc.Operations {
+		if op.OperationType != query.SubscriptionOperation {
+			continue
+		}
+
+		name := "anonymous subscription"
+		if op.Name != "" {
+			name = "subscription " + op.Name
+		}
+
+		fields := rootFields(doc, op.Selections, make(map[string]struct{}))
+		keys := make(map[string]struct{})
+		extraLocs := make([]*query.Loc, 0)
+		for _, f := range fields {
+			key := string(f.ResponseKey())
+			if _, ok := keys[key]; !ok && len(keys) > 0 {
+				extraLocs = append(extraLocs, f.Loc)
+			}
+			keys[key] = struct{}{}
+		}
+
+		if len(keys) > 1 {
+			errs = append(errs, newError(fmt.Errorf("%s must select only one top level field", name), extraLocs...))
+		}
+
+		for _, f := range fields {
+			if isIntrospectionField(f.Name) {
+				errs = append(errs, newError(fmt.Errorf("%s must not select an introspection top level field", name), f.Loc))
+				break
+			}
+		}
+	}
+
+	return errs
+}
+
+// rootFields returns the fields in selections, including the fields in the fragments they spread.
+func rootFields(doc *query.Document, selections []query.Selection, spreading map[string]struct{}) []*query.Field {
+	res := make([]*query.Field, 0, len(selections))
+	for _, sel := range selections {
+		switch f := sel.(type) {
+		case *query.Field:
+			res = append(res, f)
+		case *query.InlineFragment:
+			res = append(res, rootFields(doc, f.Selections, spreading)...)
+		case *query.FragmentSpread:
+			fd := doc.FragmentDefinitions.GetFragment(f.Name)
+			if fd == nil {
+				continue
+			}
+
+			if _, ok := spreading[string(f.Name)]; ok {
+				continue
+			}
+
+			spreading[string(f.Name)] = struct{}{}
+			res = append(res, rootFields(doc, fd.Selections, spreading)...)
+			delete(spreading, string(f.Name))
+		}
+	}
+
+	return res
+}
+
+// KnownTypeNames reports types of variables, fragments and inline fragments which aren't defined in the schema.
+func KnownTypeNames(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	for _, op := range doc.Operations {
+		for _, v := range op.Variables {
+			if name := namedType(v.Type); !isDefinedType(s, name) {
+				errs = append(errs, newError(fmt.Errorf("error validating variable $%s: type %s is not defined in schema", v.Name, name), v.Type.Loc))
+			}
+		}
+	}
+
+	for _, fd := range doc.FragmentDefinitions {
+		if !isDefinedType(s, fd.BasedTypeName) {
+			errs = append(errs, newError(fmt.Errorf("error validating fragment %s: type %s is not defined in schema", fd.Name, fd.BasedTypeName), fd.Loc))
+		}
+	}
+
+	walk(s, doc, visitor{
+		inlineFragment: func(path []string, owner fieldsOwner, f *query.InlineFragment) {
+			if len(f.TypeCondition) > 0 && !isDefinedType(s, f.TypeCondition) {
+				errs = append(errs, newPathError(path, newError(fmt.Errorf("type %s is not defined in schema", f.TypeCondition), f.Loc)))
+			}
+		},
+	})
+
+	return errs
+}
+
+// FragmentsOnCompositeTypes reports fragments and inline fragments on types which don't have fields,
+// such as scalars and enums.
+func FragmentsOnCompositeTypes(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	for _, fd := range doc.FragmentDefinitions {
+		if isDefinedType(s, fd.BasedTypeName) && lookupFieldsOwner(s, fd.BasedTypeName) == nil {
+			errs = append(errs, newError(fmt.Errorf("fragment %s cannot condition on non composite type %s", fd.Name, fd.BasedTypeName), fd.Loc))
+		}
+	}
+
+	walk(s, doc, visitor{
+		inlineFragment: func(path []string, owner fieldsOwner, f *query.InlineFragment) {
+			if len(f.TypeCondition) > 0 && isDefinedType(s, f.TypeCondition) && lookupFieldsOwner(s, f.TypeCondition) == nil {
+				errs = append(errs, newPathError(path, newError(fmt.Errorf("inline fragment cannot condition on non composite type %s", f.TypeCondition), f.Loc)))
+			}
+		},
+	})
+
+	return errs
+}
+
+// KnownFragmentNames reports spreads of fragments which aren't defined in the document.
+func KnownFragmentNames(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	walk(s, doc, visitor{
+		fragmentSpread: func(path []string, owner fieldsOwner, f *query.FragmentSpread, definition *query.FragmentDefinition) {
+			if definition == nil {
+				errs = append(errs, newPathError(path, newError(fmt.Errorf("fragment %s is not defined", f.Name), f.Loc)))
+			}
+		},
+	})
+
+	return errs
+}
+
+// NoUnusedFragments reports fragments which aren't spread by any operation.
+func NoUnusedFragments(s *schema.Schema, doc *query.Document) []error {
+	used := make(map[string]struct{})
+	var collect func(selections []query.Selection)
+	collect = func(selections []query.Selection) {
+		for _, sel := range selections {
+			f, ok := sel.(*query.FragmentSpread)
+			if !ok {
+				collect(sel.GetSelections())
+				continue
+			}
+
+			if _, ok := used[string(f.Name)]; ok {
+				continue
+			}
+			used[string(f.Name)] = struct{}{}
+
+			if fd := doc.FragmentDefinitions.GetFragment(f.Name); fd != nil {
+				collect(fd.Selections)
+			}
+		}
+	}
+
+	for _, op := range doc.Operations {
+		collect(op.Selections)
+	}
+
+	errs := make([]error, 0)
+	for _, fd := range doc.FragmentDefinitions {
+		if _, ok := used[string(fd.Name)]; !ok {
+			errs = append(errs, newError(fmt.Errorf("fragment %s is never used", fd.Name), fd.Loc))
+		}
+	}
+
+	return errs
+}
+
+// NoFragmentCycles reports fragments which spread themselves, directly or through other fragments.
+func NoFragmentCycles(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	for _, fd := range doc.FragmentDefinitions {
+		if spreadsFragment(doc, fd.Selections, fd.Name, make(map[string]struct{})) {
+			errs = append(errs, newError(fmt.Errorf("fragment %s cannot spread itself", fd.Name), fd.Loc))
+		}
+	}
+
+	return errs
+}
+
+func spreadsFragment(doc *query.Document, selections []query.Selection, name []byte, visited map[string]struct{}) bool {
+	for _, sel := range selections {
+		f, ok := sel.(*query.FragmentSpread)
+		if !ok {
+			if spreadsFragment(doc, sel.GetSelections(), name, visited) {
+				return true
+			}
+			continue
+		}
+
+		if bytes.Equal(f.Name, name) {
+			return true
+		}
+
+		if _, ok := visited[string(f.Name)]; ok {
+			continue
+		}
+		visited[string(f.Name)] = struct{}{}
+
+		if fd := doc.FragmentDefinitions.GetFragment(f.Name); fd != nil && spreadsFragment(doc, fd.Selections, name, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PossibleFragmentSpreads reports fragments and inline fragments which can never apply,
+// as no object type is both a possible type of the fragment and of the type it's spread within.
+func PossibleFragmentSpreads(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	walk(s, doc, visitor{
+		inlineFragment: func(path []string, owner fieldsOwner, f *query.InlineFragment) {
+			if len(f.TypeCondition) == 0 {
+				return
+			}
+
+			if !canSpread(s, lookupFieldsOwner(s, f.TypeCondition), owner) {
+				errs = append(errs, newPathError(path, newError(fmt.Errorf("inline fragment on %s can never be spread within %s", f.TypeCondition, ownerName(s, owner)), f.Loc)))
+			}
+		},
+		fragmentSpread: func(path []string, owner fieldsOwner, f *query.FragmentSpread, definition *query.FragmentDefinition) {
+			if definition == nil {
+				return
+			}
+
+			if !canSpread(s, lookupFieldsOwner(s, definition.BasedTypeName), owner) {
+				errs = append(errs, newPathError(path, newError(fmt.Errorf("fragment %s is based on type %s, but field is of type %s", f.Name, definition.BasedTypeName, ownerName(s, owner)), f.Loc)))
+			}
+		},
+	})
+
+	return errs
+}
+
+// canSpread reports whether a fragment on fragmentOwner can apply within owner.
+// Unknown types are reported by other rules, so they can always be spread.
+func canSpread(s *schema.Schema, fragmentOwner, owner fieldsOwner) bool {
+	fragmentTypes := possibleTypeNames(s, fragmentOwner)
+	types := possibleTypeNames(s, owner)
+	if len(fragmentTypes) == 0 || len(types) == 0 {
+		return true
+	}
+
+	for name := range fragmentTypes {
+		if _, ok := types[name]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FieldsOnCorrectType reports fields which aren't defined on the type they're selected on.
+func FieldsOnCorrectType(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	walk(s, doc, visitor{
+		field: func(path []string, owner fieldsOwner, f *query.Field, definition *schema.FieldDefinition) {
+			if owner == nil || definition != nil {
+				return
+			}
+
+			if _, ok := owner.(*schema.OperationDefinition); ok {
+				if !isIntrospectionField(f.Name) {
+					errs = append(errs, newPathError(path, newError(fmt.Errorf("field %s is not defined in schema", f.Name), f.Loc)))
+				}
+				return
+			}
+
+			errs = append(errs, newPathError(path, newError(fmt.Errorf("field %s is not defined on %s in schema", f.Name, ownerName(s, owner)), f.Loc)))
+		},
+	})
+
+	return errs
+}
+
+// LeafFieldSelections reports fields of composite types without selections and fields of leaf types with selections.
+func LeafFieldSelections(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	walk(s, doc, visitor{
+		field: func(path []string, owner fieldsOwner, f *query.Field, definition *schema.FieldDefinition) {
+			if definition == nil {
+				return
+			}
+
+			typeName := definition.Type.GetPremitiveType().Name
+			switch t := lookupFieldsOwner(s, typeName).(type) {
+			case nil:
+				if len(f.Selections) > 0 {
+					errs = append(errs, newPathError(path, newError(fmt.Errorf("field %s of type %s must not have subfields", f.Name, typeName), f.Loc)))
+				}
+			case *schema.TypeDefinition, *schema.InterfaceDefinition, *schema.UnionDefinition:
+				if len(f.Selections) == 0 {
+					errs = append(errs, newPathError(path, newError(fmt.Errorf("%s type %s must have subfields", compositeKind(t), typeName), f.Loc)))
+				}
+			}
+		},
+	})
+
+	return errs
+}
+
+func compositeKind(owner fieldsOwner) string {
+	switch owner.(type) {
+	case *schema.InterfaceDefinition:
+		return "interface"
+	case *schema.UnionDefinition:
+		return "union"
+	}
+
+	return "object"
+}
+
+// OverlappingFieldsCanBeMerged reports fields selected with the same response key which can't be merged into one.
+func OverlappingFieldsCanBeMerged(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	for _, o := range validatedOperations(s, doc) {
+		errs = append(errs, validateFieldMerging(o.root, o.operation.Selections, doc.FragmentDefinitions, s)...)
+	}
+
+	return errs
+}
+
+type selectedField struct {
+	owner fieldsOwner
+	field *query.Field
+}
+
+// validateFieldMerging validates that the fields selected with the same response key, such as a field and an alias
+// of another field, can be merged into one. They must select the same field with the same arguments,
+// unless they are selected on different object types, which never apply to the same value.
+func validateFieldMerging(owner fieldsOwner, selections []query.Selection, fragmentDefinitions query.FragmentDefinitions, s *schema.Schema) []error {
+	keys := make([]string, 0, len(selections))
+	fields := make(map[string][]selectedField)
+
+	var collect func(owner fieldsOwner, selections []query.Selection, visited map[string]struct{})
+	collect = func(owner fieldsOwner, selections []query.Selection, visited map[string]struct{}) {
+		for _, sel := range selections {
+			switch f := sel.(type) {
+			case *query.Field:
+				key := string(f.ResponseKey())
+				if _, ok := fields[key]; !ok {
+					keys = append(keys, key)
+				}
+				fields[key] = append(fields[key], selectedField{owner: owner, field: f})
+			case *query.InlineFragment:
+				fragmentOwner := owner
+				if len(f.TypeCondition) > 0 {
+					fragmentOwner = lookupFieldsOwner(s, f.TypeCondition)
+				}
+				collect(fragmentOwner, f.Selections, visited)
+			case *query.FragmentSpread:
+				fd := fragmentDefinitions.GetFragment(f.Name)
+				if fd == nil {
+					continue
+				}
+
+				if _, ok := visited[string(f.Name)]; ok {
+					continue
+				}
+				visited[string(f.Name)] = struct{}{}
+
+				collect(lookupFieldsOwner(s, fd.BasedTypeName), fd.Selections, visited)
+			}
+		}
+	}
+	collect(owner, selections, make(map[string]struct{}))
+
+	errs := make([]error, 0)
+	for _, key := range keys {
+		sameKeyFields := fields[key]
+		first := sameKeyFields[0]
+		subSelections := make([]query.Selection, 0)
+		conflicted := false
+		for _, f := range sameKeyFields {
+			if bytes.Equal(first.field.Name, f.field.Name) {
+				subSelections = append(subSelections, f.field.Selections...)
+			}
+
+			if conflicted || f.field == first.field || first.owner != f.owner && isObjectOwner(first.owner) && isObjectOwner(f.owner) {
+				continue
+			}
+
+			if !bytes.Equal(first.field.Name, f.field.Name) {
+				errs = append(errs, newError(fmt.Errorf("fields %s conflict because %s and %s are different fields", key, first.field.Name, f.field.Name), first.field.Loc, f.field.Loc))
+				conflicted = true
+				continue
+			}
+
+			if !isSameArguments(first.field.Arguments, f.field.Arguments) {
+				errs = append(errs, newError(fmt.Errorf("fields %s conflict because they have differing arguments", key), first.field.Loc, f.field.Loc))
+				conflicted = true
+			}
+		}
+
+		if len(subSelections) == 0 || first.owner == nil {
+			continue
+		}
+
+		fd := first.owner.GetFieldByName(first.field.Name)
+		if fd == nil {
+			continue
+		}
+
+		errs = append(errs, validateFieldMerging(lookupFieldsOwner(s, fd.Type.GetPremitiveType().Name), subSelections, fragmentDefinitions, s)...)
+	}
+
+	return errs
+}
+
+func isSameArguments(a, b []*query.Argument) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, argA := range a {
+		found := false
+		for _, argB := range b {
+			if bytes.Equal(argA.Name, argB.Name) {
+				found = argA.Value.String() == argB.Value.String()
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// KnownDirectives reports directives which aren't defined in the schema or aren't allowed where they're used.
+func KnownDirectives(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	walk(s, doc, visitor{
+		directive: func(path []string, on query.Selection, d *query.Directive) {
+			def := s.Directives.Get(d.Name)
+			if def == nil {
+				errs = append(errs, newPathError(path, newError(fmt.Errorf("directive %s is not defined in schema", d.Name), d.Loc)))
+				return
+			}
+
+			switch f := on.(type) {
+			case *query.Field:
+				if !hasLocation(def, "FIELD") {
+					errs = append(errs, newPathError(path, newError(fmt.Errorf("directive %s is not allowed on field %s", d.Name, f.Name), d.Loc)))
+				}
+			case *query.InlineFragment:
+				if !hasLocation(def, "INLINE_FRAGMENT") {
+					errs = append(errs, newPathError(path, newError(fmt.Errorf("directive %s is not allowed on inline fragment", d.Name), d.Loc)))
+				}
+			case *query.FragmentSpread:
+				if !hasLocation(def, "FRAGMENT_SPREAD") {
+					errs = append(errs, newPathError(path, newError(fmt.Errorf("directive %s is not allowed on fragment spread %s", d.Name, f.Name), d.Loc)))
+				}
+			}
+		},
+	})
+
+	return errs
+}
+
+func hasLocation(def *schema.DirectiveDefinition, location string) bool {
+	for _, l := range def.Locations {
+		if string(l.Name) == location {
+			return true
+		}
+	}
+
+	return false
+}
+
+// KnownArgumentNames reports arguments which aren't defined on their field or directive.
+func KnownArgumentNames(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	walk(s, doc, visitor{
+		field: func(path []string, owner fieldsOwner, f *query.Field, definition *schema.FieldDefinition) {
+			if definition == nil {
+				return
+			}
+
+			for _, arg := range f.Arguments {
+				if lookupArgumentDefinition(definition.Arguments, arg.Name) == nil {
+					errs = append(errs, newPathError(appendPath(path, "field %s", f.Name), newError(fmt.Errorf("argument %s is not defined on field %s", arg.Name, f.Name), arg.Loc)))
+				}
+			}
+		},
+		directive: func(path []string, on query.Selection, d *query.Directive) {
+			def := s.Directives.Get(d.Name)
+			if def == nil {
+				return
+			}
+
+			for _, arg := range d.Arguments {
+				if lookupArgumentDefinition(def.Arguments, arg.Name) == nil {
+					errs = append(errs, newPathError(appendPath(path, "directive %s", d.Name), newError(fmt.Errorf("argument %s is not defined on directive %s", arg.Name, d.Name), arg.Loc)))
+				}
+			}
+		},
+	})
+
+	return errs
+}
+
+func lookupArgumentDefinition(definitions []*schema.ArgumentDefinition, name []byte) *schema.ArgumentDefinition {
+	for _, def := range definitions {
+		if bytes.Equal(def.Name, name) {
+			return def
+		}
+	}
+
+	return nil
+}
+
+// UniqueArgumentNames reports arguments given more than once to a field or a directive.
+func UniqueArgumentNames(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	validate := func(path []string, names [][]byte, locs []*query.Loc) {
+		seen := make(map[string]*query.Loc)
+		for i, name := range names {
+			if first, ok := seen[string(name)]; ok {
+				errs = append(errs, newPathError(path, newError(fmt.Errorf("there can be only one argument named %s", name), first, locs[i])))
+				continue
+			}
+			seen[string(name)] = locs[i]
+		}
+	}
+
+	walk(s, doc, visitor{
+		field: func(path []string, owner fieldsOwner, f *query.Field, definition *schema.FieldDefinition) {
+			names := make([][]byte, 0, len(f.Arguments))
+			locs := make([]*query.Loc, 0, len(f.Arguments))
+			for _, arg := range f.Arguments {
+				names = append(names, arg.Name)
+				locs = append(locs, arg.Loc)
+			}
+			validate(appendPath(path, "field %s", f.Name), names, locs)
+		},
+		directive: func(path []string, on query.Selection, d *query.Directive) {
+			names := make([][]byte, 0, len(d.Arguments))
+			locs := make([]*query.Loc, 0, len(d.Arguments))
+			for _, arg := range d.Arguments {
+				names = append(names, arg.Name)
+				locs = append(locs, arg.Loc)
+			}
+			validate(appendPath(path, "directive %s", d.Name), names, locs)
+		},
+	})
+
+	return errs
+}
+
+// ProvidedRequiredArguments reports fields and directives without their non-null arguments which don't have a default value.
+func ProvidedRequiredArguments(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	validate := func(path []string, loc *query.Loc, definitions []*schema.ArgumentDefinition, names [][]byte) {
+		missing := make([]string, 0)
+		for _, def := range definitions {
+			if def.Type.Nullable || def.Default != nil {
+				continue
+			}
+
+			provided := false
+			for _, name := range names {
+				if bytes.Equal(def.Name, name) {
+					provided = true
+					break
+				}
+			}
+
+			if !provided {
+				missing = append(missing, string(def.Name))
+			}
+		}
+
+		if len(missing) > 0 {
+			errs = append(errs, newPathError(path, newError(fmt.Errorf("missing required arguments: %v", missing), loc)))
+		}
+	}
+
+	walk(s, doc, visitor{
+		field: func(path []string, owner fieldsOwner, f *query.Field, definition *schema.FieldDefinition) {
+			if definition == nil {
+				return
+			}
+
+			names := make([][]byte, 0, len(f.Arguments))
+			for _, arg := range f.Arguments {
+				names = append(names, arg.Name)
+			}
+			validate(appendPath(path, "field %s", f.Name), f.Loc, definition.Arguments, names)
+		},
+		directive: func(path []string, on query.Selection, d *query.Directive) {
+			def := s.Directives.Get(d.Name)
+			if def == nil {
+				return
+			}
+
+			names := make([][]byte, 0, len(d.Arguments))
+			for _, arg := range d.Arguments {
+				names = append(names, arg.Name)
+			}
+			validate(appendPath(path, "directive %s", d.Name), d.Loc, def.Arguments, names)
+		},
+	})
+
+	return errs
+}
+
+// ValuesOfCorrectType reports literal values of arguments and default values of variables
+// which aren't values of the type of the argument or the variable.
+func ValuesOfCorrectType(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	for _, op := range doc.Operations {
+		for _, v := range op.Variables {
+			if v.DefaultValue == nil {
+				continue
+			}
+
+			if err := s.ValidateInputValue(schema.NewFieldType(v.Type), v.DefaultValue); err != nil {
+				errs = append(errs, newError(fmt.Errorf("error validating default value of variable $%s: %w", v.Name, err), v.DefaultValue.GetLoc()))
+			}
+		}
+	}
+
+	walk(s, doc, visitor{
+		field: func(path []string, owner fieldsOwner, f *query.Field, definition *schema.FieldDefinition) {
+			if definition == nil {
+				return
+			}
+
+			for _, arg := range f.Arguments {
+				def := lookupArgumentDefinition(definition.Arguments, arg.Name)
+				if def == nil {
+					continue
+				}
+
+				if err := def.ValidateValueType(s, arg.Value); err != nil {
+					errs = append(errs, newPathError(appendPath(path, "field %s", f.Name), newError(err, arg.Value.GetLoc())))
+				}
+			}
+		},
+		directive: func(path []string, on query.Selection, d *query.Directive) {
+			directiveDefinition := s.Directives.Get(d.Name)
+			if directiveDefinition == nil {
+				return
+			}
+
+			for _, arg := range d.Arguments {
+				def := lookupArgumentDefinition(directiveDefinition.Arguments, arg.Name)
+				if def == nil {
+					continue
+				}
+
+				if err := def.ValidateValueType(s, arg.Value); err != nil {
+					errs = append(errs, newPathError(appendPath(path, "directive %s", d.Name), newError(fmt.Errorf("error validating argument %s: %w", def.Name, err), arg.Value.GetLoc())))
+				}
+			}
+		},
+	})
+
+	return errs
+}
+
+// variableUsage is a variable given to an argument, with the type of the argument if it's defined.
+type variableUsage struct {
+	path       []string
+	name       []byte
+	loc        *query.Loc
+	location   *schema.FieldType
+	hasDefault bool
+}
+
+// variableUsages returns the variables used by the operation o, including the variables used in the fragments it spreads.
+func variableUsages(s *schema.Schema, doc *query.Document, o operationRoot) []variableUsage {
+	usages := make([]variableUsage, 0)
+	directiveUsages := func(path []string, d *query.Directive) {
+		def := s.Directives.Get(d.Name)
+		for _, arg := range d.Arguments {
+			var argumentDefinition *schema.ArgumentDefinition
+			if def != nil {
+				argumentDefinition = lookupArgumentDefinition(def.Arguments, arg.Name)
+			}
+			usages = appendVariableUsages(usages, appendPath(path, "directive %s", d.Name), arg.Value, argumentDefinition)
+		}
+	}
+
+	for _, d := range o.operation.Directives {
+		directiveUsages(nil, d)
+	}
+
+	walkOperation(s, doc, o, visitor{
+		field: func(path []string, owner fieldsOwner, f *query.Field, definition *schema.FieldDefinition) {
+			for _, arg := range f.Arguments {
+				var argumentDefinition *schema.ArgumentDefinition
+				if definition != nil {
+					argumentDefinition = lookupArgumentDefinition(definition.Arguments, arg.Name)
+				}
+				usages = appendVariableUsages(usages, appendPath(path, "field %s", f.Name), arg.Value, argumentDefinition)
+			}
+		},
+		directive: func(path []string, on query.Selection, d *query.Directive) {
+			directiveUsages(path, d)
+		},
+	})
+
+	return usages
+}
+
+// appendVariableUsages appends the variables used in value, the value of the argument defined by def, to usages.
+// Only a variable given as the whole value has the type of the argument,
+// as the variables in lists and objects are given to positions which aren't checked.
+func appendVariableUsages(usages []variableUsage, path []string, value query.InputValue, def *schema.ArgumentDefinition) []variableUsage {
+	for _, v := range query.Variables(value) {
+		usage := variableUsage{path: path, name: v.Name, loc: v.Loc}
+		if v == value && def != nil {
+			usage.location = def.Type
+			usage.hasDefault = def.Default != nil
+		}
+		usages = append(usages, usage)
+	}
+
+	return usages
+}
+
+func lookupVariable(op *query.Operation, name []byte) *query.Variable {
+	for _, v := range op.Variables {
+		if bytes.Equal(v.Name, name) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// NoUndefinedVariables reports variables which are used but aren't defined by the operation.
+func NoUndefinedVariables(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	for _, o := range validatedOperations(s, doc) {
+		for _, usage := range variableUsages(s, doc, o) {
+			if lookupVariable(o.operation, usage.name) != nil {
+				continue
+			}
+
+			if o.operation.Name == "" {
+				errs = append(errs, newPathError(usage.path, newError(fmt.Errorf("variable $%s is not defined", usage.name), usage.loc, o.operation.Loc)))
+				continue
+			}
+
+			errs = append(errs, newPathError(usage.path, newError(fmt.Errorf("variable $%s is not defined by operation %s", usage.name, o.operation.Name), usage.loc, o.operation.Loc)))
+		}
+	}
+
+	return errs
+}
+
+// NoUnusedVariables reports variables which are defined by an operation but aren't used.
+func NoUnusedVariables(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	for _, o := range validatedOperations(s, doc) {
+		used := make(map[string]struct{})
+		for _, usage := range variableUsages(s, doc, o) {
+			used[string(usage.name)] = struct{}{}
+		}
+
+		for _, v := range o.operation.Variables {
+			if _, ok := used[string(v.Name)]; ok {
+				continue
+			}
+
+			if o.operation.Name == "" {
+				errs = append(errs, newError(fmt.Errorf("variable $%s is never used", v.Name), v.Loc))
+				continue
+			}
+
+			errs = append(errs, newError(fmt.Errorf("variable $%s is never used in operation %s", v.Name, o.operation.Name), v.Loc))
+		}
+	}
+
+	return errs
+}
+
+// VariablesInAllowedPosition reports variables given to arguments of a type their values may not be of.
+// A nullable variable is allowed in a non-null position when the variable or the argument has a default value.
+func VariablesInAllowedPosition(s *schema.Schema, doc *query.Document) []error {
+	errs := make([]error, 0)
+	for _, o := range validatedOperations(s, doc) {
+		for _, usage := range variableUsages(s, doc, o) {
+			v := lookupVariable(o.operation, usage.name)
+			if v == nil || usage.location == nil {
+				continue
+			}
+
+			variableType := schema.NewFieldType(v.Type)
+			if variableType.Nullable && !usage.location.Nullable && (v.DefaultValue != nil || usage.hasDefault) {
+				nonNullType := *variableType
+				nonNullType.Nullable = false
+				variableType = &nonNullType
+			}
+
+			if !isSubType(variableType, usage.location) {
+				errs = append(errs, newPathError(usage.path, newError(fmt.Errorf("variable $%s of type %s used in position expecting type %s", v.Name, schema.NewFieldType(v.Type), usage.location), v.Loc, usage.loc)))
+			}
+		}
+	}
+
+	return errs
+}
+
+// isSubType reports whether every value of t is a value of super.
+func isSubType(t, super *schema.FieldType) bool {
+	if t.Nullable && !super.Nullable {
+		return false
+	}
+
+	if t.IsList != super.IsList {
+		return false
+	}
+
+	if t.IsList {
+		return isSubType(t.ListType, super.ListType)
+	}
+
+	return bytes.Equal(t.Name, super.Name)
+}
+
+func namedType(t *query.FieldType) []byte {
+	if t.IsList {
+		return namedType(t.ListType)
+	}
+
+	return t.Name
+}
@@ -2,9 +2,11 @@ package validator_test
 
 import (
 	"testing"
+	"time"
 
 	"errors"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/n9te9/goliteql/query"
 	"github.com/n9te9/goliteql/schema"
 	"github.com/n9te9/goliteql/validator"
@@ -1081,6 +1083,1307 @@ func TestValidator_Validate(t *testing.T) {
 			}`),
 			want: errors.New(`error validating operations: error validating field user: error validating directive include: error validating argument if: error validating value for argument if: expected boolean value, got 123`),
 		},
+		{
+			name: "Validate query with introspection fields",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user: User
+				}
+
+				type User {
+					id: ID!
+					name: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				__schema {
+					types {
+						name
+					}
+				}
+				user {
+					__typename
+					id
+				}
+			}`),
+			want: nil,
+		},
+		{
+			name: "Validate query with enum argument",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					users(role: Role!): [User]
+				}
+
+				enum Role {
+					ADMIN
+					MEMBER
+				}
+
+				type User {
+					id: ID!
+					name: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				users(role: ADMIN) {
+					id
+				}
+			}`),
+			want: nil,
+		},
+		{
+			name: "Validate query with unknown enum value in argument",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					users(role: Role!): [User]
+				}
+
+				enum Role {
+					ADMIN
+					MEMBER
+				}
+
+				type User {
+					id: ID!
+					name: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				users(role: GUEST) {
+					id
+				}
+			}`),
+			want: errors.New("error validating operations: error validating field users: error validating value for argument role: expected Role value, got GUEST"),
+		},
+		{
+			name: "Validate query with custom scalar argument",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					posts(since: DateTime!): [Post]
+				}
+
+				scalar DateTime
+
+				type Post {
+					id: ID!
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				posts(since: "2024-01-01T00:00:00Z") {
+					id
+				}
+			}`),
+			want: nil,
+		},
+		{
+			name: "Validate query with invalid custom scalar argument",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					posts(since: DateTime!): [Post]
+				}
+
+				scalar DateTime
+
+				type Post {
+					id: ID!
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				posts(since: "yesterday") {
+					id
+				}
+			}`),
+			want: errors.New(`error validating operations: error validating field posts: error validating value for argument since: expected DateTime value, got "yesterday"`),
+		},
+		{
+			name: "Validate query with list and input object arguments",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					posts(ids: [ID!], first: Int, filter: PostFilter): [Post]
+				}
+
+				input PostFilter {
+					title: String!
+					tags: [String!]
+					order: Order = ASC
+				}
+
+				enum Order {
+					ASC
+					DESC
+				}
+
+				type User {
+					id: ID!
+				}
+
+				type Post {
+					id: ID!
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				posts(ids: ["1", 2], filter: {title: "a", tags: "b", order: DESC}) {
+					id
+				}
+			}`),
+			want: nil,
+		},
+		{
+			name: "Validate query with invalid item of list argument",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					posts(ids: [ID!], first: Int, filter: PostFilter): [Post]
+				}
+
+				input PostFilter {
+					title: String!
+					tags: [String!]
+					order: Order = ASC
+				}
+
+				enum Order {
+					ASC
+					DESC
+				}
+
+				type User {
+					id: ID!
+				}
+
+				type Post {
+					id: ID!
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				posts(ids: ["1", true]) {
+					id
+				}
+			}`),
+			want: errors.New("error validating operations: error validating field posts: error validating value for argument ids: error validating item 1: expected ID but got true"),
+		},
+		{
+			name: "Validate query with input object argument missing required field",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					posts(ids: [ID!], first: Int, filter: PostFilter): [Post]
+				}
+
+				input PostFilter {
+					title: String!
+					tags: [String!]
+					order: Order = ASC
+				}
+
+				enum Order {
+					ASC
+					DESC
+				}
+
+				type User {
+					id: ID!
+				}
+
+				type Post {
+					id: ID!
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				posts(filter: {tags: ["b"]}) {
+					id
+				}
+			}`),
+			want: errors.New("error validating operations: error validating field posts: error validating value for argument filter: field title of non-null type String! is not provided"),
+		},
+		{
+			name: "Validate query with unknown field of input object argument",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					posts(ids: [ID!], first: Int, filter: PostFilter): [Post]
+				}
+
+				input PostFilter {
+					title: String!
+					tags: [String!]
+					order: Order = ASC
+				}
+
+				enum Order {
+					ASC
+					DESC
+				}
+
+				type User {
+					id: ID!
+				}
+
+				type Post {
+					id: ID!
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				posts(filter: {title: "a", author: "b"}) {
+					id
+				}
+			}`),
+			want: errors.New("error validating operations: error validating field posts: error validating value for argument filter: field author is not defined by input type PostFilter"),
+		},
+		{
+			name: "Validate query with invalid field of input object argument",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					posts(ids: [ID!], first: Int, filter: PostFilter): [Post]
+				}
+
+				input PostFilter {
+					title: String!
+					tags: [String!]
+					order: Order = ASC
+				}
+
+				enum Order {
+					ASC
+					DESC
+				}
+
+				type User {
+					id: ID!
+				}
+
+				type Post {
+					id: ID!
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				posts(filter: {title: "a", order: RANDOM}) {
+					id
+				}
+			}`),
+			want: errors.New("error validating operations: error validating field posts: error validating value for argument filter: error validating field order: expected Order value, got RANDOM"),
+		},
+		{
+			name: "Validate query with non-object input object argument",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					posts(ids: [ID!], first: Int, filter: PostFilter): [Post]
+				}
+
+				input PostFilter {
+					title: String!
+					tags: [String!]
+					order: Order = ASC
+				}
+
+				enum Order {
+					ASC
+					DESC
+				}
+
+				type User {
+					id: ID!
+				}
+
+				type Post {
+					id: ID!
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				posts(filter: "str") {
+					id
+				}
+			}`),
+			want: errors.New(`error validating operations: error validating field posts: error validating value for argument filter: expected an object of input type PostFilter, got "str"`),
+		},
+		{
+			name: "Validate query with null non-null argument",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					posts(ids: [ID!], first: Int, filter: PostFilter): [Post]
+				}
+
+				input PostFilter {
+					title: String!
+					tags: [String!]
+					order: Order = ASC
+				}
+
+				enum Order {
+					ASC
+					DESC
+				}
+
+				type User {
+					id: ID!
+				}
+
+				type Post {
+					id: ID!
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				user(id: null) {
+					id
+				}
+			}`),
+			want: errors.New("error validating operations: error validating field user: error validating value for argument id: expected ID! value, got null"),
+		},
+		{
+			name: "Validate query with variable default value of wrong type",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					posts(first: Int, filter: PostFilter): [Post]
+				}
+
+				input PostFilter {
+					title: String!
+					order: Order = ASC
+				}
+
+				enum Order {
+					ASC
+					DESC
+				}
+
+				type Post {
+					id: ID!
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query ($first: Int = "x") {
+				posts(first: $first) {
+					id
+				}
+			}`),
+			want: errors.New(`error validating operations: error validating default value of variable $first: expected integer value, got "x"`),
+		},
+		{
+			name: "Validate query with variable default input object without its required field",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					posts(first: Int, filter: PostFilter): [Post]
+				}
+
+				input PostFilter {
+					title: String!
+					order: Order = ASC
+				}
+
+				enum Order {
+					ASC
+					DESC
+				}
+
+				type Post {
+					id: ID!
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query ($filter: PostFilter = { order: DESC }) {
+				posts(filter: $filter) {
+					id
+				}
+			}`),
+			want: errors.New("error validating operations: error validating default value of variable $filter: field title of non-null type String! is not provided"),
+		},
+		{
+			name: "Validate query with valid variable default values",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					posts(first: Int, filter: PostFilter): [Post]
+				}
+
+				input PostFilter {
+					title: String!
+					order: Order = ASC
+				}
+
+				enum Order {
+					ASC
+					DESC
+				}
+
+				type Post {
+					id: ID!
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query ($first: Int = 10, $filter: PostFilter = { title: "title", order: DESC }) {
+				posts(first: $first, filter: $filter) {
+					id
+				}
+			}`),
+			want: nil,
+		},
+		{
+			name: "Validate query with Int argument out of range",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					posts(ids: [ID!], first: Int, filter: PostFilter): [Post]
+				}
+
+				input PostFilter {
+					title: String!
+					tags: [String!]
+					order: Order = ASC
+				}
+
+				enum Order {
+					ASC
+					DESC
+				}
+
+				type User {
+					id: ID!
+				}
+
+				type Post {
+					id: ID!
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				posts(first: 3000000000) {
+					id
+				}
+			}`),
+			want: errors.New("error validating operations: error validating field posts: error validating value for argument first: expected 32-bit signed integer value, got 3000000000"),
+		},
+		{
+			name: "Validate query with field aliases",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					users(role: String): [User]
+				}
+
+				type User {
+					id: ID!
+					name: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				admins: users(role: "ADMIN") {
+					userId: id
+					name
+				}
+				users {
+					id
+				}
+			}`),
+			want: nil,
+		},
+		{
+			name: "Validate query with conflicting field aliases",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					users(role: String): [User]
+				}
+
+				type User {
+					id: ID!
+					name: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				users {
+					id: name
+					id
+				}
+			}`),
+			want: errors.New("error validating operations: fields id conflict because name and id are different fields"),
+		},
+		{
+			name: "Validate query with aliases of fields with differing arguments",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					users(role: String): [User]
+				}
+
+				type User {
+					id: ID!
+					name: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				users: users(role: "ADMIN") {
+					id
+				}
+				users {
+					id
+				}
+			}`),
+			want: errors.New("error validating operations: fields users conflict because they have differing arguments"),
+		},
+		{
+			name: "Validate query reporting every error",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					users(limit: Int = 10): [User]
+				}
+
+				type User {
+					id: ID!
+					name: String
+					posts: [Post]
+				}
+
+				type Post {
+					id: ID!
+					title: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				user {
+					unknownField
+				}
+				users {
+					posts
+				}
+			}`),
+			want: errors.New("error validating operations: error validating field user: field unknownField is not defined on User in schema\nerror validating field users: object type Post must have subfields\nerror validating field user: missing required arguments: [id]"),
+		},
+		{
+			name: "Validate query with duplicated operation names",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					users(limit: Int = 10): [User]
+				}
+
+				type User {
+					id: ID!
+					name: String
+					posts: [Post]
+				}
+
+				type Post {
+					id: ID!
+					title: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query GetUser {
+				user(id: 1) {
+					id
+				}
+			}
+
+			query GetUser {
+				users {
+					id
+				}
+			}`),
+			want: errors.New("error validating operations: there can be only one operation named GetUser"),
+		},
+		{
+			name: "Validate query with anonymous operation among other operations",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					users(limit: Int = 10): [User]
+				}
+
+				type User {
+					id: ID!
+					name: String
+					posts: [Post]
+				}
+
+				type Post {
+					id: ID!
+					title: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				user(id: 1) {
+					id
+				}
+			}
+
+			query GetUsers {
+				users {
+					id
+				}
+			}`),
+			want: errors.New("error validating operations: anonymous operation must be the only defined operation"),
+		},
+		{
+			name: "Validate query with subfields on leaf field",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					users(limit: Int = 10): [User]
+				}
+
+				type User {
+					id: ID!
+					name: String
+					posts: [Post]
+				}
+
+				type Post {
+					id: ID!
+					title: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				users {
+					id {
+						value
+					}
+				}
+			}`),
+			want: errors.New("error validating operations: error validating field users: field id of type ID must not have subfields"),
+		},
+		{
+			name: "Validate query with fragment cycle",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					users(limit: Int = 10): [User]
+				}
+
+				type User {
+					id: ID!
+					name: String
+					posts: [Post]
+				}
+
+				type Post {
+					id: ID!
+					title: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				users {
+					...UserFields
+				}
+			}
+
+			fragment UserFields on User {
+				id
+				...MoreUserFields
+			}
+
+			fragment MoreUserFields on User {
+				name
+				...UserFields
+			}`),
+			want: errors.New("error validating operations: fragment UserFields cannot spread itself\nfragment MoreUserFields cannot spread itself"),
+		},
+		{
+			name: "Validate query with unused fragment",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					users(limit: Int = 10): [User]
+				}
+
+				type User {
+					id: ID!
+					name: String
+					posts: [Post]
+				}
+
+				type Post {
+					id: ID!
+					title: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				users {
+					id
+				}
+			}
+
+			fragment UserFields on User {
+				id
+			}`),
+			want: errors.New("error validating operations: fragment UserFields is never used"),
+		},
+		{
+			name: "Validate query with fragment on scalar type",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					users(limit: Int = 10): [User]
+				}
+
+				type User {
+					id: ID!
+					name: String
+					posts: [Post]
+				}
+
+				type Post {
+					id: ID!
+					title: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				users {
+					...on String {
+						id
+					}
+				}
+			}`),
+			want: errors.New("error validating operations: error validating field users: inline fragment cannot condition on non composite type String"),
+		},
+		{
+			name: "Validate query with variables",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					users(limit: Int = 10): [User]
+				}
+
+				type User {
+					id: ID!
+					name: String
+					posts: [Post]
+				}
+
+				type Post {
+					id: ID!
+					title: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query GetUser($id: ID!, $withName: Boolean = true) {
+				user(id: $id) {
+					id
+					name @include(if: $withName)
+				}
+			}`),
+			want: nil,
+		},
+		{
+			name: "Validate query with undefined and unused variables",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					users(limit: Int = 10): [User]
+				}
+
+				type User {
+					id: ID!
+					name: String
+					posts: [Post]
+				}
+
+				type Post {
+					id: ID!
+					title: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query GetUser($userId: ID!) {
+				user(id: $id) {
+					id
+				}
+			}`),
+			want: errors.New("error validating operations: error validating field user: variable $id is not defined by operation GetUser\nvariable $userId is never used in operation GetUser"),
+		},
+		{
+			name: "Validate query with variable in disallowed position",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					users(limit: Int = 10): [User]
+				}
+
+				type User {
+					id: ID!
+					name: String
+					posts: [Post]
+				}
+
+				type Post {
+					id: ID!
+					title: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query GetUser($id: ID, $limit: String) {
+				user(id: $id) {
+					id
+				}
+				users(limit: $limit) {
+					id
+				}
+			}`),
+			want: errors.New("error validating operations: error validating field user: variable $id of type ID used in position expecting type ID!\nerror validating field users: variable $limit of type String used in position expecting type Int"),
+		},
+		{
+			name: "Validate query with nullable variable with default value in non-null position",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					users(limit: Int = 10): [User]
+				}
+
+				type User {
+					id: ID!
+					name: String
+					posts: [Post]
+				}
+
+				type Post {
+					id: ID!
+					title: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query GetUser($id: ID = 1) {
+				user(id: $id) {
+					id
+				}
+			}`),
+			want: nil,
+		},
+		{
+			name: "Validate query with unknown and duplicated arguments",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					users(limit: Int = 10): [User]
+				}
+
+				type User {
+					id: ID!
+					name: String
+					posts: [Post]
+				}
+
+				type Post {
+					id: ID!
+					title: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				user(id: 1, id: 2, name: "alice") {
+					id
+				}
+			}`),
+			want: errors.New("error validating operations: error validating field user: argument name is not defined on field user\nerror validating field user: there can be only one argument named id"),
+		},
+		{
+			name: "Validate query with unknown directive",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+					users(limit: Int = 10): [User]
+				}
+
+				type User {
+					id: ID!
+					name: String
+					posts: [Post]
+				}
+
+				type Post {
+					id: ID!
+					title: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`query {
+				users {
+					id @unknown
+				}
+			}`),
+			want: errors.New("error validating operations: error validating field users: directive unknown is not defined in schema"),
+		},
+		{
+			name: "Validate mutation",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+				}
+
+				type Mutation {
+					createUser(name: String!): User
+				}
+
+				type Subscription {
+					userCreated: User
+					userDeleted: User
+				}
+
+				type User {
+					id: ID!
+					name: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`mutation CreateUser($name: String!) {
+				createUser(name: $name) {
+					id
+					name
+				}
+			}`),
+			want: nil,
+		},
+		{
+			name: "Validate mutation with undefined field",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+				}
+
+				type Mutation {
+					createUser(name: String!): User
+				}
+
+				type Subscription {
+					userCreated: User
+					userDeleted: User
+				}
+
+				type User {
+					id: ID!
+					name: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`mutation {
+				createUser {
+					id
+					email
+				}
+			}`),
+			want: errors.New("error validating operations: error validating field createUser: field email is not defined on User in schema\nerror validating field createUser: missing required arguments: [name]"),
+		},
+		{
+			name: "Validate subscription",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+				}
+
+				type Mutation {
+					createUser(name: String!): User
+				}
+
+				type Subscription {
+					userCreated: User
+					userDeleted: User
+				}
+
+				type User {
+					id: ID!
+					name: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`subscription {
+				userCreated {
+					id
+				}
+			}`),
+			want: nil,
+		},
+		{
+			name: "Validate subscription with more than one root field",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+				}
+
+				type Mutation {
+					createUser(name: String!): User
+				}
+
+				type Subscription {
+					userCreated: User
+					userDeleted: User
+				}
+
+				type User {
+					id: ID!
+					name: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`subscription OnUserChanged {
+				userCreated {
+					id
+				}
+				...on Subscription {
+					userDeleted {
+						id
+					}
+				}
+			}`),
+			want: errors.New("error validating operations: subscription OnUserChanged must select only one top level field"),
+		},
+		{
+			name: "Validate subscription with introspection root field",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+				}
+
+				type Mutation {
+					createUser(name: String!): User
+				}
+
+				type Subscription {
+					userCreated: User
+					userDeleted: User
+				}
+
+				type User {
+					id: ID!
+					name: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`subscription {
+				__typename
+			}`),
+			want: errors.New("error validating operations: anonymous subscription must not select an introspection top level field"),
+		},
+		{
+			name: "Validate mutation on schema without mutation",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+				}
+
+				type User {
+					id: ID!
+					name: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`mutation {
+				createUser(name: "alice") {
+					id
+				}
+			}`),
+			want: errors.New("error validating operations: schema does not have a mutation operation"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -1088,6 +2391,9 @@ func TestValidator_Validate(t *testing.T) {
 			lexer := schema.NewLexer()
 			s := tt.schemaFunc(schema.NewParser(lexer))
 			mergedSchema, _ := s.Merge()
+			if err := schema.RegisterScalar[time.Time](mergedSchema, "DateTime"); err != nil {
+				t.Fatalf("RegisterScalar() error %v", err)
+			}
 
 			queryLexer := query.NewLexer()
 			queryParser := query.NewParser(queryLexer)
@@ -1117,3 +2423,240 @@ func TestValidator_Validate(t *testing.T) {
 		})
 	}
 }
+
+// introspectionQuery is the IntrospectionQuery sent by GraphiQL and other tools to learn a schema.
+const introspectionQuery = `query IntrospectionQuery {
+	__schema {
+		queryType { name }
+		mutationType { name }
+		subscriptionType { name }
+		types {
+			...FullType
+		}
+		directives {
+			name
+			description
+			locations
+			args {
+				...InputValue
+			}
+		}
+	}
+}
+
+fragment FullType on __Type {
+	kind
+	name
+	description
+	fields(includeDeprecated: true) {
+		name
+		description
+		args {
+			...InputValue
+		}
+		type {
+			...TypeRef
+		}
+		isDeprecated
+		deprecationReason
+	}
+	inputFields {
+		...InputValue
+	}
+	interfaces {
+		...TypeRef
+	}
+	enumValues(includeDeprecated: true) {
+		name
+		description
+		isDeprecated
+		deprecationReason
+	}
+	possibleTypes {
+		...TypeRef
+	}
+}
+
+fragment InputValue on __InputValue {
+	name
+	description
+	type { ...TypeRef }
+	defaultValue
+}
+
+fragment TypeRef on __Type {
+	kind
+	name
+	ofType {
+		kind
+		name
+		ofType {
+			kind
+			name
+			ofType {
+				kind
+				name
+				ofType {
+					kind
+					name
+					ofType {
+						kind
+						name
+						ofType {
+							kind
+							name
+							ofType {
+								kind
+								name
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}`
+
+func TestValidateDocument_Introspection(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "the IntrospectionQuery of GraphiQL is valid",
+			query: introspectionQuery,
+		},
+		{
+			name:  "__type with its name is valid",
+			query: `query { __type(name: "Post") { name kind fields { name type { name ofType { name } } } } }`,
+		},
+		{
+			name:  "a field which isn't defined on __Type is reported",
+			query: `query { __type(name: "Post") { unknown } }`,
+			want:  []string{"error validating field __type: field unknown is not defined on __Type in schema"},
+		},
+		{
+			name:  "__type without its name is reported",
+			query: `query { __type { name } }`,
+			want:  []string{"error validating field __type: missing required arguments: [name]"},
+		},
+		{
+			name:  "an introspection object type without subfields is reported",
+			query: `query { __schema { queryType } }`,
+			want:  []string{"error validating field __schema: object type __Type must have subfields"},
+		},
+		{
+			name:  "a fragment on an introspection enum is reported",
+			query: `query { __schema { queryType { kind ...Kind } } } fragment Kind on __TypeKind { name }`,
+			want:  []string{"fragment Kind cannot condition on non composite type __TypeKind"},
+		},
+	}
+
+	s := schema.MustParse([]byte(`type Query {
+		post(id: ID!): Post
+	}
+
+	type Post {
+		id: ID!
+		title: String
+	}`))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := query.NewParser(query.NewLexer()).Parse([]byte(tt.query))
+			if err != nil {
+				t.Fatalf("error parsing query: %v", err)
+			}
+
+			got := make([]string, 0)
+			for _, err := range validator.ValidateDocument(s, doc) {
+				got = append(got, err.Error())
+			}
+
+			want := tt.want
+			if want == nil {
+				want = []string{}
+			}
+
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("ValidateDocument() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateDocument_Locations(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  [][]query.Position
+	}{
+		{
+			name:  "an unknown field is located at the field",
+			query: "query {\n  post(id: 1) {\n    unknown\n  }\n}",
+			want:  [][]query.Position{{{Line: 3, Column: 5, Offset: 28}}},
+		},
+		{
+			name:  "operations with the same name are located at both operations",
+			query: "query A { post(id: 1) { id } }\nquery A { post(id: 2) { id } }",
+			want:  [][]query.Position{{{Line: 1, Column: 1, Offset: 0}, {Line: 2, Column: 1, Offset: 31}}},
+		},
+		{
+			name:  "a value of a wrong type is located at the value",
+			query: `query { post(id: 1.5) { id } }`,
+			want:  [][]query.Position{{{Line: 1, Column: 18, Offset: 17}}},
+		},
+		{
+			name:  "a default value of a wrong type is located at the value",
+			query: `query ($id: ID = 1.5) { post(id: $id) { id } }`,
+			want:  [][]query.Position{{{Line: 1, Column: 18, Offset: 17}}},
+		},
+		{
+			name:  "conflicting fields are located at both fields",
+			query: `query { post(id: 1) { id id: title } }`,
+			want:  [][]query.Position{{{Line: 1, Column: 23, Offset: 22}, {Line: 1, Column: 26, Offset: 25}}},
+		},
+		{
+			name:  "an unused variable is located at its definition",
+			query: `query ($id: ID) { post(id: 1) { id } }`,
+			want:  [][]query.Position{{{Line: 1, Column: 8, Offset: 7}}},
+		},
+	}
+
+	s := schema.MustParse([]byte(`type Query {
+		post(id: ID!): Post
+	}
+
+	type Post {
+		id: ID!
+		title: String
+	}`))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := query.NewParser(query.NewLexer()).Parse([]byte(tt.query))
+			if err != nil {
+				t.Fatalf("error parsing query: %v", err)
+			}
+
+			got := make([][]query.Position, 0)
+			for _, err := range validator.ValidateDocument(s, doc) {
+				var located *validator.Error
+				if !errors.As(err, &located) {
+					t.Fatalf("error %v is not located", err)
+				}
+
+				positions := make([]query.Position, 0)
+				for _, loc := range located.Locations() {
+					positions = append(positions, loc.Start)
+				}
+				got = append(got, positions)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ValidateDocument() locations mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/schema"
+)
+
+// fieldsOwner is a type whose fields are selected, which is a composite type or a root operation.
+type fieldsOwner interface {
+	GetFieldByName(name []byte) *schema.FieldDefinition
+}
+
+var typenameFieldDefinition = &schema.FieldDefinition{
+	Name: []byte("__typename"),
+	Type: &schema.FieldType{Name: []byte("String"), Nullable: false},
+}
+
+func lookupFieldsOwner(s *schema.Schema, name []byte) fieldsOwner {
+	if td := s.Indexes.GetTypeDefinition(string(name)); td != nil {
+		return td
+	}
+
+	if id := s.Indexes.GetInterfaceDefinition(string(name)); id != nil {
+		return id
+	}
+
+	if ud := s.Indexes.GetUnionDefinition(string(name)); ud != nil {
+		return ud
+	}
+
+	for _, op := range s.Operations {
+		if bytes.Equal(rootTypeName(s, op), name) {
+			return op
+		}
+	}
+
+	if td, ok := introspection.types[string(name)]; ok {
+		return td
+	}
+
+	return nil
+}
+
+func isObjectOwner(owner fieldsOwner) bool {
+	switch owner.(type) {
+	case *schema.TypeDefinition, *schema.OperationDefinition:
+		return true
+	}
+
+	return false
+}
+
+// lookupFieldDefinition returns the definition of the field named name on owner,
+// or nil if owner is unknown or doesn't have the field.
+func lookupFieldDefinition(owner fieldsOwner, name []byte) *schema.FieldDefinition {
+	if owner == nil {
+		return nil
+	}
+
+	if string(name) == "__typename" {
+		return typenameFieldDefinition
+	}
+
+	// the fields of a union are the fields of its members, which are selected through fragments
+	if _, ok := owner.(*schema.UnionDefinition); ok {
+		return nil
+	}
+
+	// __schema and __type are fields of the query root type which the schema doesn't define
+	if op, ok := owner.(*schema.OperationDefinition); ok && op.OperationType == schema.QueryOperation {
+		if fd := introspection.lookupRootField(name); fd != nil {
+			return fd
+		}
+	}
+
+	return owner.GetFieldByName(name)
+}
+
+// rootTypeName returns the name of the root type of op, which is the name of its operation type
+// such as Query unless the schema definition names it.
+func rootTypeName(s *schema.Schema, op *schema.OperationDefinition) []byte {
+	if len(op.Name) > 0 {
+		return op.Name
+	}
+
+	if s.Definition != nil {
+		switch op.OperationType {
+		case schema.QueryOperation:
+			if len(s.Definition.Query) > 0 {
+				return s.Definition.Query
+			}
+		case schema.MutationOperation:
+			if len(s.Definition.Mutation) > 0 {
+				return s.Definition.Mutation
+			}
+		case schema.SubscriptionOperation:
+			if len(s.Definition.Subscription) > 0 {
+				return s.Definition.Subscription
+			}
+		}
+	}
+
+	name := string(op.OperationType)
+	return []byte(strings.ToUpper(name[:1]) + name[1:])
+}
+
+// ownerName returns the name of the type owner.
+func ownerName(s *schema.Schema, owner fieldsOwner) []byte {
+	switch t := owner.(type) {
+	case *schema.TypeDefinition:
+		return t.Name
+	case *schema.InterfaceDefinition:
+		return t.Name
+	case *schema.UnionDefinition:
+		return t.Name
+	case *schema.OperationDefinition:
+		return rootTypeName(s, t)
+	}
+
+	return nil
+}
+
+// isDefinedType reports whether name is a built-in scalar, an introspection type or a type defined in s.
+func isDefinedType(s *schema.Schema, name []byte) bool {
+	switch string(name) {
+	case "ID", "String", "Int", "Float", "Boolean":
+		return true
+	}
+
+	if lookupFieldsOwner(s, name) != nil {
+		return true
+	}
+
+	if _, ok := s.Indexes.EnumIndex[string(name)]; ok {
+		return true
+	}
+
+	if _, ok := introspection.enums[string(name)]; ok {
+		return true
+	}
+
+	if _, ok := s.Indexes.InputIndex[string(name)]; ok {
+		return true
+	}
+
+	for _, scalar := range s.Scalars {
+		if bytes.Equal(scalar.Name, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// possibleTypeNames returns the names of the object types a value of owner can be.
+func possibleTypeNames(s *schema.Schema, owner fieldsOwner) map[string]struct{} {
+	res := make(map[string]struct{})
+	switch t := owner.(type) {
+	case *schema.TypeDefinition:
+		res[string(t.Name)] = struct{}{}
+	case *schema.OperationDefinition:
+		res[string(rootTypeName(s, t))] = struct{}{}
+	case *schema.InterfaceDefinition:
+		for _, td := range s.Indexes.GetImplementedType(t) {
+			res[string(td.Name)] = struct{}{}
+		}
+	case *schema.UnionDefinition:
+		for _, name := range t.Types {
+			res[string(name)] = struct{}{}
+		}
+	}
+
+	return res
+}
+
+// operationRoot is an operation of a document with the root type it's validated against.
+type operationRoot struct {
+	operation *query.Operation
+	root      *schema.OperationDefinition
+}
+
+// rootOperation returns the root type of s which op is executed on, or nil if s doesn't define it.
+func rootOperation(s *schema.Schema, op *query.Operation) *schema.OperationDefinition {
+	switch op.OperationType {
+	case query.QueryOperation:
+		return s.GetQuery()
+	case query.MutationOperation:
+		return s.GetMutation()
+	case query.SubscriptionOperation:
+		return s.GetSubscription()
+	}
+
+	return nil
+}
+
+// validatedOperations returns the operations of doc with the root types of s they're validated against.
+// Operations whose root type isn't defined are reported by KnownOperationTypes.
+func validatedOperations(s *schema.Schema, doc *query.Document) []operationRoot {
+	res := make([]operationRoot, 0, len(doc.Operations))
+	for _, op := range doc.Operations {
+		if root := rootOperation(s, op); root != nil {
+			res = append(res, operationRoot{operation: op, root: root})
+		}
+	}
+
+	return res
+}
+
+// visitor holds the functions called for the selections found while walking the operations of a document.
+// path is the prefixes of the errors about a selection, such as "field user" or "fragment UserFields",
+// and owner is the type the selection is selected on, which is nil if it's unknown.
+type visitor struct {
+	field          func(path []string, owner fieldsOwner, f *query.Field, definition *schema.FieldDefinition)
+	inlineFragment func(path []string, owner fieldsOwner, f *query.InlineFragment)
+	fragmentSpread func(path []string, owner fieldsOwner, f *query.FragmentSpread, definition *query.FragmentDefinition)
+	directive      func(path []string, on query.Selection, d *query.Directive)
+}
+
+// walk walks the selections of the validated operations of doc.
+func walk(s *schema.Schema, doc *query.Document, v visitor) {
+	for _, o := range validatedOperations(s, doc) {
+		walkOperation(s, doc, o, v)
+	}
+}
+
+// walkOperation walks the selections of an operation,
+// entering every fragment spread once along a path so that fragment cycles end.
+func walkOperation(s *schema.Schema, doc *query.Document, o operationRoot, v visitor) {
+	walkSelections(s, doc, v, nil, o.root, o.operation.Selections, make(map[string]struct{}))
+}
+
+func (v visitor) visitDirectives(path []string, on query.Selection, directives []*query.Directive) {
+	if v.directive == nil {
+		return
+	}
+
+	for _, d := range directives {
+		v.directive(path, on, d)
+	}
+}
+
+func walkSelections(s *schema.Schema, doc *query.Document, v visitor, path []string, owner fieldsOwner, selections []query.Selection, spreading map[string]struct{}) {
+	for _, sel := range selections {
+		switch f := sel.(type) {
+		case *query.Field:
+			definition := lookupFieldDefinition(owner, f.Name)
+			if v.field != nil {
+				v.field(path, owner, f, definition)
+			}
+			v.visitDirectives(path, f, f.Directives)
+
+			var fieldOwner fieldsOwner
+			if definition != nil {
+				fieldOwner = lookupFieldsOwner(s, definition.Type.GetPremitiveType().Name)
+			}
+
+			walkSelections(s, doc, v, appendPath(path, "field %s", f.Name), fieldOwner, f.Selections, spreading)
+		case *query.InlineFragment:
+			if v.inlineFragment != nil {
+				v.inlineFragment(path, owner, f)
+			}
+			v.visitDirectives(path, f, f.Directives)
+
+			fragmentOwner := owner
+			if len(f.TypeCondition) > 0 {
+				fragmentOwner = lookupFieldsOwner(s, f.TypeCondition)
+			}
+
+			walkSelections(s, doc, v, path, fragmentOwner, f.Selections, spreading)
+		case *query.FragmentSpread:
+			definition := doc.FragmentDefinitions.GetFragment(f.Name)
+			if v.fragmentSpread != nil {
+				v.fragmentSpread(path, owner, f, definition)
+			}
+			v.visitDirectives(path, f, f.Directives)
+
+			if definition == nil {
+				continue
+			}
+
+			if _, ok := spreading[string(f.Name)]; ok {
+				continue
+			}
+
+			spreading[string(f.Name)] = struct{}{}
+			walkSelections(s, doc, v, appendPath(path, "fragment %s", f.Name), lookupFieldsOwner(s, definition.BasedTypeName), definition.Selections, spreading)
+			delete(spreading, string(f.Name))
+		}
+	}
+}
+
+func appendPath(path []string, format string, name []byte) []string {
+	return append(append(make([]string, 0, len(path)+1), path...), fmt.Sprintf(format, name))
+}
+
+// newPathError prefixes err with path, from the outermost selection.
+func newPathError(path []string, err error) error {
+	for i := len(path) - 1; i >= 0; i-- {
+		err = fmt.Errorf("error validating %s: %w", path[i], err)
+	}
+
+	return err
+}
+
+// Error is an error found by a rule at nodes of a document, such as a field or the fields it conflicts with.
+type Error struct {
+	err  error
+	locs []*query.Loc
+}
+
+// newError returns err found at the nodes located at locs.
+func newError(err error, locs ...*query.Loc) error {
+	return &Error{err: err, locs: locs}
+}
+
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Locations returns the locations of the nodes the error is found at.
+func (e *Error) Locations() []*query.Loc {
+	return e.locs
+}